@@ -0,0 +1,75 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenibcheck"
+	"strings"
+)
+
+//consistencyChecker is the part of the UE-NIB Checker API used by the commands.
+type consistencyChecker interface {
+	Check(gNb string) ([]uenibcheck.Finding, error)
+	Repair(gNb string, findings []uenibcheck.Finding) (int, error)
+}
+
+func (c *cli) runCheck(args []string) error {
+	findings, err := c.checker.Check(args[0])
+	if err != nil {
+		return err
+	}
+	if err = c.printFindings(findings); err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("%d findings", len(findings))
+	}
+	return nil
+}
+
+func (c *cli) runRepair(args []string) error {
+	findings, err := c.checker.Check(args[0])
+	if err != nil {
+		return err
+	}
+	if err = c.printFindings(findings); err != nil {
+		return err
+	}
+	repaired, err := c.checker.Repair(args[0], findings)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.errOut, "repaired %d of %d findings\n", repaired, len(findings))
+	if repaired < len(findings) {
+		return fmt.Errorf("%d findings cannot be repaired", len(findings)-repaired)
+	}
+	return nil
+}
+
+func (c *cli) printFindings(findings []uenibcheck.Finding) error {
+	if c.format == formatJSON {
+		if findings == nil {
+			findings = []uenibcheck.Finding{}
+		}
+		return c.printJSON(findings)
+	}
+	t := c.newTable("TYPE", "ENB_UE_X2AP_ID", "DESCRIPTION", "REPAIRABLE", "KEYS")
+	for _, f := range findings {
+		ue := f.ENbUeX2ApID
+		if ue == "" {
+			ue = "-"
+		}
+		repairable := "no"
+		if f.Repair != nil {
+			repairable = "yes"
+		}
+		t.row(f.Type.String(), ue, f.Description, repairable, strings.Join(f.Keys, " "))
+	}
+	return t.flush()
+}
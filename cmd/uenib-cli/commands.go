@@ -0,0 +1,364 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package main
+
+import (
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//Default number of state history entries shown by the state command.
+const defaultStateHistoryCount = 10
+
+//ueNibReader is the part of the UE-NIB Reader API used by the commands.
+type ueNibReader interface {
+	GetUeIDs(gNb string) ([]uenib.UeID, error)
+	GetMeNbUEX2APID(ueID *uenib.UeID) (uint32, error)
+	GetSgNbUEX2APID(ueID *uenib.UeID) (uint32, error)
+	GetState(ueID *uenib.UeID) (*uenib.UeState, error)
+	GetStateHistory(ueID *uenib.UeID, limit int) ([]uenib.UeState, error)
+	GetServingCells(ueID *uenib.UeID) (*uenib.ServingCells, error)
+	GetBearers(ueID *uenib.UeID) ([]uenib.Bearer, error)
+	SubscribeEvents(gNbs []string, eventCategories []uenibreader.EventCategory, callback uenibreader.EventCallback) error
+}
+
+//cli holds the command execution context.
+type cli struct {
+	reader     ueNibReader
+	checker    consistencyChecker
+	out        io.Writer
+	errOut     io.Writer
+	format     string
+	sgNbX2ApID bool
+	wait       func() //Blocks until the watch command is stopped.
+}
+
+//command defines a command. New commands are added by adding a row to the commandTable.
+type command struct {
+	args    string //Argument synopsis shown in the usage.
+	help    string
+	minArgs int
+	maxArgs int
+	run     func(c *cli, args []string) error
+}
+
+var commandTable = map[string]command{
+	"ues": {
+		args: "<gNb>", help: "List UEs of a gNB.",
+		minArgs: 1, maxArgs: 1, run: (*cli).runUes,
+	},
+	"ue": {
+		args: "<gNb> <x2apid>", help: "Show UE's X2AP IDs, state, serving cells and bearers.",
+		minArgs: 2, maxArgs: 2, run: (*cli).runUe,
+	},
+	"bearers": {
+		args: "<gNb> <x2apid>", help: "Show UE's bearers.",
+		minArgs: 2, maxArgs: 2, run: (*cli).runBearers,
+	},
+	"pscell": {
+		args: "<gNb> <x2apid>", help: "Show UE's PSCell and SCells.",
+		minArgs: 2, maxArgs: 2, run: (*cli).runPsCell,
+	},
+	"state": {
+		args: "<gNb> <x2apid> [count]", help: "Show UE's state and state history.",
+		minArgs: 2, maxArgs: 3, run: (*cli).runState,
+	},
+	"check": {
+		args: "<gNb>", help: "Check consistency of gNB's UE-NIB data.",
+		minArgs: 1, maxArgs: 1, run: (*cli).runCheck,
+	},
+	"repair": {
+		args: "<gNb>", help: "Check consistency of gNB's UE-NIB data and repair the findings.",
+		minArgs: 1, maxArgs: 1, run: (*cli).runRepair,
+	},
+	"watch": {
+		args: "<gNb>", help: "Stream parsed dual connectivity events of a gNB.",
+		minArgs: 1, maxArgs: 1, run: (*cli).runWatch,
+	},
+}
+
+func printCommandUsages(w io.Writer) {
+	names := make([]string, 0, len(commandTable))
+	for name := range commandTable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmd := commandTable[name]
+		fmt.Fprintf(w, "  %-32s %s\n", name+" "+cmd.args, cmd.help)
+	}
+}
+
+func lookupCommand(args []string) (command, error) {
+	if len(args) == 0 {
+		return command{}, fmt.Errorf("missing command")
+	}
+	cmd, ok := commandTable[args[0]]
+	if !ok {
+		return command{}, fmt.Errorf("unknown command '%s'", args[0])
+	}
+	if cnt := len(args) - 1; cnt < cmd.minArgs || cnt > cmd.maxArgs {
+		return command{}, fmt.Errorf("wrong number of arguments, usage: %s %s", args[0], cmd.args)
+	}
+	return cmd, nil
+}
+
+//run executes the command given in args, args[0] is the command name.
+func (c *cli) run(args []string) error {
+	cmd, err := lookupCommand(args)
+	if err != nil {
+		return err
+	}
+	return cmd.run(c, args[1:])
+}
+
+func (c *cli) ueID(gNb string, x2ApID string) *uenib.UeID {
+	if c.sgNbX2ApID {
+		return &uenib.UeID{GNb: gNb, GNbUeX2ApID: x2ApID}
+	}
+	return &uenib.UeID{GNb: gNb, ENbUeX2ApID: x2ApID}
+}
+
+func (c *cli) runUes(args []string) error {
+	ueIDs, err := c.reader.GetUeIDs(args[0])
+	if err != nil {
+		return err
+	}
+	if c.format == formatJSON {
+		if ueIDs == nil {
+			ueIDs = []uenib.UeID{}
+		}
+		return c.printJSON(ueIDs)
+	}
+	t := c.newTable("ENB_UE_X2AP_ID", "GNB_UE_X2AP_ID")
+	for _, ueID := range ueIDs {
+		t.row(ueID.ENbUeX2ApID, ueID.GNbUeX2ApID)
+	}
+	return t.flush()
+}
+
+//ueSummary is the output of the ue command. Missing data of a found UE is left empty.
+type ueSummary struct {
+	UeID         uenib.UeID          `json:"ueId"`
+	State        *uenib.UeState      `json:"state,omitempty"`
+	ServingCells *uenib.ServingCells `json:"servingCells,omitempty"`
+	Bearers      []uenib.Bearer      `json:"bearers,omitempty"`
+}
+
+func (c *cli) runUe(args []string) error {
+	var err error
+	ueID := c.ueID(args[0], args[1])
+	summary := ueSummary{UeID: *ueID}
+	if err = c.resolveX2ApIDs(&summary.UeID); err != nil {
+		return err
+	}
+	if summary.State, err = c.reader.GetState(ueID); ignoreValueNotFoundFailure(err) != nil {
+		return err
+	}
+	if summary.ServingCells, err = c.reader.GetServingCells(ueID); ignoreValueNotFoundFailure(err) != nil {
+		return err
+	}
+	if summary.Bearers, err = c.reader.GetBearers(ueID); ignoreValueNotFoundFailure(err) != nil {
+		return err
+	}
+
+	if c.format == formatJSON {
+		return c.printJSON(summary)
+	}
+	t := c.newTable("FIELD", "VALUE")
+	t.row("GNb", summary.UeID.GNb)
+	t.row("MeNB UE X2AP ID", summary.UeID.ENbUeX2ApID)
+	t.row("SgNB UE X2AP ID", summary.UeID.GNbUeX2ApID)
+	if summary.State != nil {
+		t.row("State", stateString(*summary.State))
+	}
+	if summary.ServingCells != nil {
+		t.row("PSCell", cellString(summary.ServingCells.PsCell))
+		for _, sCell := range summary.ServingCells.SCells {
+			t.row("SCell "+fmt.Sprint(sCell.SCellIndex), cellString(sCell.Cell))
+		}
+	}
+	for _, bearer := range summary.Bearers {
+		t.row("Bearer "+fmt.Sprint(bearer.ErabID), fmt.Sprintf("%s QCI %d S1-U UL %s",
+			bearer.BearerType.String(), bearer.Qci, tunnelEndpointString(bearer.S1ULGtpTE)))
+	}
+	return t.flush()
+}
+
+//resolveX2ApIDs fills in the X2AP ID missing from the UE ID. A value not found failure is
+//returned, if the UE is not found.
+func (c *cli) resolveX2ApIDs(ueID *uenib.UeID) error {
+	if len(ueID.GNbUeX2ApID) == 0 {
+		id, err := c.reader.GetSgNbUEX2APID(ueID)
+		if err != nil {
+			return err
+		}
+		ueID.GNbUeX2ApID = fmt.Sprint(id)
+		return nil
+	}
+	id, err := c.reader.GetMeNbUEX2APID(ueID)
+	if err != nil {
+		return err
+	}
+	ueID.ENbUeX2ApID = fmt.Sprint(id)
+	return nil
+}
+
+func (c *cli) runBearers(args []string) error {
+	bearers, err := c.reader.GetBearers(c.ueID(args[0], args[1]))
+	if err != nil {
+		return err
+	}
+	if c.format == formatJSON {
+		return c.printJSON(bearers)
+	}
+	t := c.newTable("ERAB_ID", "DRB_ID", "TYPE", "QCI", "ARP_PL", "S1U_UL_TE", "S1U_DL_TE", "X2U_TE")
+	for _, b := range bearers {
+		t.row(fmt.Sprint(b.ErabID), fmt.Sprint(b.DrbID), b.BearerType.String(), fmt.Sprint(b.Qci),
+			fmt.Sprint(b.ArpPL), tunnelEndpointString(b.S1ULGtpTE), tunnelEndpointString(b.S1DLGtpTE),
+			tunnelEndpointString(b.X2UGtpTE))
+	}
+	return t.flush()
+}
+
+func (c *cli) runPsCell(args []string) error {
+	cells, err := c.reader.GetServingCells(c.ueID(args[0], args[1]))
+	if err != nil {
+		return err
+	}
+	if c.format == formatJSON {
+		return c.printJSON(cells)
+	}
+	t := c.newTable("CELL", "PCI", "SSB_FREQ", "NR_CGI", "RSRP", "RSRQ", "SINR")
+	t.row(append([]string{"PSCell"}, cellColumns(cells.PsCell)...)...)
+	for _, sCell := range cells.SCells {
+		t.row(append([]string{"SCell " + fmt.Sprint(sCell.SCellIndex)}, cellColumns(sCell.Cell)...)...)
+	}
+	return t.flush()
+}
+
+//stateOutput is the output of the state command.
+type stateOutput struct {
+	State   uenib.UeState   `json:"state"`
+	History []uenib.UeState `json:"history"`
+}
+
+func (c *cli) runState(args []string) error {
+	count := defaultStateHistoryCount
+	if len(args) == 3 {
+		var err error
+		if count, err = strconv.Atoi(args[2]); err != nil || count < 0 {
+			return fmt.Errorf("invalid state history count '%s'", args[2])
+		}
+	}
+	ueID := c.ueID(args[0], args[1])
+	state, err := c.reader.GetState(ueID)
+	if err != nil {
+		return err
+	}
+	output := stateOutput{State: *state, History: []uenib.UeState{}}
+	if count > 0 {
+		//A UE without recorded history has no history key.
+		history, err := c.reader.GetStateHistory(ueID, count)
+		if ignoreValueNotFoundFailure(err) != nil {
+			return err
+		}
+		output.History = append(output.History, history...)
+	}
+
+	if c.format == formatJSON {
+		return c.printJSON(output)
+	}
+	t := c.newTable("", "TIME", "MESSAGE", "CAUSE")
+	t.row(append([]string{"current"}, stateColumns(output.State)...)...)
+	for i, state := range output.History {
+		t.row(append([]string{fmt.Sprint(i + 1)}, stateColumns(state)...)...)
+	}
+	return t.flush()
+}
+
+func (c *cli) runWatch(args []string) error {
+	gNb := args[0]
+	if c.format == formatTable {
+		fmt.Fprintf(c.out, "%-22s %-40s %s\n", "EVENT", "UE", "DETAILS")
+	}
+	err := c.reader.SubscribeEvents([]string{gNb}, []uenibreader.EventCategory{uenibreader.DualConnectivity},
+		func(gNb string, eventCategory uenibreader.EventCategory, events []string) {
+			for _, evtStr := range events {
+				c.printDcEvent(evtStr)
+			}
+		})
+	if err != nil {
+		return err
+	}
+	c.wait()
+	return nil
+}
+
+func (c *cli) printDcEvent(evtStr string) {
+	evt, err := uenibreader.ParseDcEvent(evtStr)
+	if err != nil {
+		fmt.Fprintf(c.errOut, "event '%s' parse failure: %s\n", evtStr, err.Error())
+		return
+	}
+	if c.format == formatJSON {
+		if err = c.printJSONLine(evt); err != nil {
+			fmt.Fprintf(c.errOut, "event '%s' output failure: %s\n", evtStr, err.Error())
+		}
+		return
+	}
+	evtType := strings.TrimPrefix(evt.EventType.String(), "_")
+	ue := fmt.Sprintf("%s#%s#%s", evt.UeID.GNb, evt.UeID.GNbUeX2ApID, evt.UeID.ENbUeX2ApID)
+	if evt.EventType == uenibreader.DC_EVENT_GNB_ALL_UES_REMOVE || evt.EventType == uenibreader.DC_EVENT_UNKNOWN {
+		ue = "-"
+	}
+	fmt.Fprintf(c.out, "%-22s %-40s %s\n", evtType, ue, dcEventDetails(evt, evtStr))
+}
+
+func dcEventDetails(evt uenibreader.DcEvent, evtStr string) string {
+	var details []string
+	switch evt.EventType {
+	case uenibreader.DC_EVENT_S1UL_TUNNEL_ESTABLISH, uenibreader.DC_EVENT_S1UL_TUNNEL_RELEASE:
+		for _, t := range evt.S1ULGtpTunnels {
+			tunnel := fmt.Sprintf("%s/%d", t.Addr, t.Teid)
+			if t.HasErabID {
+				tunnel = fmt.Sprintf("E-RAB %d %s", t.ErabID, tunnel)
+			}
+			details = append(details, tunnel)
+		}
+	case uenibreader.DC_EVENT_BEARER_TYPE_CHANGE:
+		for _, b := range evt.BearerTypes {
+			details = append(details, fmt.Sprintf("E-RAB %d %s", b.ErabID, b.BearerType.String()))
+		}
+	case uenibreader.DC_EVENT_PSCELL_CHANGE:
+		details = append(details, cellString(evt.PsCell))
+	case uenibreader.DC_EVENT_BEARER_MODIFY:
+		for _, erabID := range evt.ErabIDs {
+			details = append(details, fmt.Sprintf("E-RAB %d", erabID))
+		}
+	case uenibreader.DC_EVENT_GNB_ALL_UES_REMOVE:
+		if evt.GNb != "" {
+			details = append(details, evt.GNb)
+		}
+	case uenibreader.DC_EVENT_UNKNOWN:
+		details = append(details, evtStr)
+	}
+	return strings.Join(details, ", ")
+}
+
+func ignoreValueNotFoundFailure(err error) error {
+	if uenibreader.IsValueNotFoundFailure(err) {
+		return nil
+	}
+	return err
+}
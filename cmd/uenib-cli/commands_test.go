@@ -0,0 +1,196 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibcheck"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeReader struct {
+	ueIDs      []uenib.UeID
+	bearers    []uenib.Bearer
+	events     []string
+	err        error
+	dataErr    error //Error of UE's state, serving cells and bearers, if err is nil.
+	historyErr error //Error of UE's state history, if err is nil.
+	lastUeID   *uenib.UeID
+}
+
+func (r *fakeReader) dataError() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.dataErr
+}
+
+func (r *fakeReader) GetUeIDs(gNb string) ([]uenib.UeID, error) {
+	return r.ueIDs, r.err
+}
+
+func (r *fakeReader) GetMeNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
+	return 0, r.err
+}
+
+func (r *fakeReader) GetSgNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
+	return 0, r.err
+}
+
+func (r *fakeReader) GetState(ueID *uenib.UeID) (*uenib.UeState, error) {
+	return &uenib.UeState{}, r.dataError()
+}
+
+func (r *fakeReader) GetStateHistory(ueID *uenib.UeID, limit int) ([]uenib.UeState, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return nil, r.historyErr
+}
+
+func (r *fakeReader) GetServingCells(ueID *uenib.UeID) (*uenib.ServingCells, error) {
+	return &uenib.ServingCells{}, r.dataError()
+}
+
+func (r *fakeReader) GetBearers(ueID *uenib.UeID) ([]uenib.Bearer, error) {
+	r.lastUeID = ueID
+	return r.bearers, r.dataError()
+}
+
+func (r *fakeReader) SubscribeEvents(gNbs []string, eventCategories []uenibreader.EventCategory,
+	callback uenibreader.EventCallback) error {
+	if r.err != nil {
+		return r.err
+	}
+	callback(gNbs[0], uenibreader.DualConnectivity, r.events)
+	return nil
+}
+
+func newTestCli(reader *fakeReader, format string) (*cli, *bytes.Buffer, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	return &cli{reader: reader, out: out, errOut: errOut, format: format, wait: func() {}}, out, errOut
+}
+
+func TestLookupCommand(t *testing.T) {
+	_, err := lookupCommand([]string{"ues", "gnb1"})
+	assert.Nil(t, err)
+	_, err = lookupCommand([]string{})
+	assert.EqualError(t, err, "missing command")
+	_, err = lookupCommand([]string{"foo"})
+	assert.EqualError(t, err, "unknown command 'foo'")
+	_, err = lookupCommand([]string{"state", "gnb1"})
+	assert.EqualError(t, err, "wrong number of arguments, usage: state <gNb> <x2apid> [count]")
+}
+
+func TestUesTable(t *testing.T) {
+	reader := &fakeReader{ueIDs: []uenib.UeID{
+		{GNb: "gnb1", ENbUeX2ApID: "1", GNbUeX2ApID: "11"},
+		{GNb: "gnb1", ENbUeX2ApID: "2", GNbUeX2ApID: "22"},
+	}}
+	c, out, _ := newTestCli(reader, formatTable)
+	err := c.run([]string{"ues", "gnb1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "ENB_UE_X2AP_ID  GNB_UE_X2AP_ID\n1               11\n2               22\n", out.String())
+}
+
+func TestUesJSONIsEmptyListIfNoUes(t *testing.T) {
+	c, out, _ := newTestCli(&fakeReader{}, formatJSON)
+	err := c.run([]string{"ues", "gnb1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", out.String())
+}
+
+func TestBearersUsesSgNbX2ApIDIfRequested(t *testing.T) {
+	reader := &fakeReader{}
+	c, _, _ := newTestCli(reader, formatTable)
+	c.sgNbX2ApID = true
+	err := c.run([]string{"bearers", "gnb1", "22"})
+	assert.Nil(t, err)
+	assert.Equal(t, &uenib.UeID{GNb: "gnb1", GNbUeX2ApID: "22"}, reader.lastUeID)
+}
+
+func TestCommandReturnsReaderError(t *testing.T) {
+	c, _, _ := newTestCli(&fakeReader{err: errors.New("some error")}, formatTable)
+	err := c.run([]string{"bearers", "gnb1", "1"})
+	assert.EqualError(t, err, "some error")
+}
+
+func TestUeReturnsErrorIfUeNotFound(t *testing.T) {
+	c, out, _ := newTestCli(&fakeReader{err: uenibreader.ErrNotFound}, formatTable)
+	err := c.run([]string{"ue", "gnb1", "1"})
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+	assert.Empty(t, out.String())
+}
+
+func TestUeIgnoresMissingDataOfFoundUe(t *testing.T) {
+	c, out, _ := newTestCli(&fakeReader{dataErr: uenibreader.ErrNotFound}, formatTable)
+	err := c.run([]string{"ue", "gnb1", "1"})
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), "SgNB UE X2AP ID")
+}
+
+func TestStateShowsEmptyHistoryIfUeHasNoHistory(t *testing.T) {
+	c, out, _ := newTestCli(&fakeReader{historyErr: uenibreader.ErrNotFound}, formatJSON)
+	err := c.run([]string{"state", "gnb1", "1"})
+	assert.Nil(t, err)
+	assert.Contains(t, out.String(), `"history": []`)
+}
+
+func TestStateReturnsErrorIfInvalidCount(t *testing.T) {
+	c, _, _ := newTestCli(&fakeReader{}, formatTable)
+	err := c.run([]string{"state", "gnb1", "1", "x"})
+	assert.EqualError(t, err, "invalid state history count 'x'")
+}
+
+func TestWatchPrintsParsedEventsAndParseErrors(t *testing.T) {
+	reader := &fakeReader{events: []string{"gnb1#2#1_ADD", "GNB_ALL_UES_REMOVE", "v9|foo"}}
+	c, out, errOut := newTestCli(reader, formatJSON)
+	err := c.run([]string{"watch", "gnb1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, bytes.Count(out.Bytes(), []byte("\n")))
+	assert.Contains(t, errOut.String(), "event 'v9|foo' parse failure")
+}
+
+type fakeChecker struct {
+	findings []uenibcheck.Finding
+	repaired int
+}
+
+func (c *fakeChecker) Check(gNb string) ([]uenibcheck.Finding, error) {
+	return c.findings, nil
+}
+
+func (c *fakeChecker) Repair(gNb string, findings []uenibcheck.Finding) (int, error) {
+	return c.repaired, nil
+}
+
+func TestCheckReturnsErrorIfFindings(t *testing.T) {
+	c, out, _ := newTestCli(&fakeReader{}, formatTable)
+	c.checker = &fakeChecker{findings: []uenibcheck.Finding{{Type: uenibcheck.FINDING_ORPHANED,
+		ENbUeX2ApID: "1", Keys: []string{"1,6,UE_ERAB_DRB_ID"}, Description: "E-RAB 6 is not listed",
+		Repair: &uenibcheck.Repair{Remove: []string{"1,6,UE_ERAB_DRB_ID"}}}}}
+	err := c.run([]string{"check", "gnb1"})
+	assert.EqualError(t, err, "1 findings")
+	assert.Contains(t, out.String(), "ORPHANED  1               E-RAB 6 is not listed  yes         1,6,UE_ERAB_DRB_ID")
+
+	c.checker = &fakeChecker{}
+	assert.Nil(t, c.run([]string{"check", "gnb1"}))
+}
+
+func TestRepairReturnsErrorIfUnrepairedFindings(t *testing.T) {
+	c, _, errOut := newTestCli(&fakeReader{}, formatJSON)
+	c.checker = &fakeChecker{findings: []uenibcheck.Finding{{}, {}}, repaired: 1}
+	err := c.run([]string{"repair", "gnb1"})
+	assert.EqualError(t, err, "1 findings cannot be repaired")
+	assert.Equal(t, "repaired 1 of 2 findings\n", errOut.String())
+}
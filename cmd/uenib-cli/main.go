@@ -0,0 +1,88 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Command uenib-cli inspects UE-NIB data from the shell.
+//
+//Usage:
+//
+//	uenib-cli [-o table|json] [-sgnb] <command> [arguments]
+//
+//Commands:
+//
+//	ues <gNb>                     List UEs of a gNB.
+//	ue <gNb> <x2apid>             Show UE's X2AP IDs, state, serving cells and bearers.
+//	bearers <gNb> <x2apid>        Show UE's bearers.
+//	pscell <gNb> <x2apid>         Show UE's PSCell and SCells.
+//	state <gNb> <x2apid> [count]  Show UE's state and state history (default 10 entries).
+//	watch <gNb>                   Stream parsed dual connectivity events of a gNB.
+//	check <gNb>                   Check consistency of gNB's UE-NIB data.
+//	repair <gNb>                  Check consistency of gNB's UE-NIB data and repair the findings.
+//
+//Commands check and repair exit with status 1, if there are findings left unrepaired.
+//Parameter x2apid is the MeNB UE X2AP ID of a UE. With -sgnb option it is the SgNB UE X2AP ID.
+//Database backend connection is configured as for any other UE-NIB Reader user, by the
+//environment variables of the SDL library.
+package main
+
+import (
+	"flag"
+	"fmt"
+	sdl "gerrit.o-ran-sc.org/r/ric-plt/sdlgo"
+	"github.com/nokia/ue-nib-library/pkg/uenibcheck"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	flags := flag.NewFlagSet("uenib-cli", flag.ExitOnError)
+	format := flags.String("o", "table", "output format: table or json")
+	sgNbX2ApID := flags.Bool("sgnb", false, "x2apid argument is SgNB UE X2AP ID instead of MeNB UE X2AP ID")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: uenib-cli [-o table|json] [-sgnb] <command> [arguments]\n\nCommands:\n")
+		printCommandUsages(flags.Output())
+		fmt.Fprintf(flags.Output(), "\nOptions:\n")
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	if *format != formatTable && *format != formatJSON {
+		fmt.Fprintf(os.Stderr, "unknown output format '%s'\n", *format)
+		flags.Usage()
+		os.Exit(2)
+	}
+	if _, err := lookupCommand(flags.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	db := sdl.NewSyncStorage()
+	reader := uenibreader.NewReaderWithDbBackend(db)
+	c := &cli{
+		reader:     reader,
+		checker:    uenibcheck.NewChecker(db),
+		out:        os.Stdout,
+		errOut:     os.Stderr,
+		format:     *format,
+		sgNbX2ApID: *sgNbX2ApID,
+		wait:       waitForSignal,
+	}
+	err := c.run(flags.Args())
+	reader.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func waitForSignal() {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	<-sigs
+}
@@ -0,0 +1,107 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"strings"
+	"text/tabwriter"
+)
+
+//Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+//table writes tab aligned columns.
+type table struct {
+	w *tabwriter.Writer
+}
+
+func (c *cli) newTable(headers ...string) *table {
+	t := &table{w: tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)}
+	t.row(headers...)
+	return t
+}
+
+func (t *table) row(columns ...string) {
+	fmt.Fprintln(t.w, strings.Join(columns, "\t"))
+}
+
+func (t *table) flush() error {
+	return t.w.Flush()
+}
+
+func (c *cli) printJSON(v interface{}) error {
+	enc := json.NewEncoder(c.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+//printJSONLine prints a value as a single line JSON, which suits for streaming output.
+func (c *cli) printJSONLine(v interface{}) error {
+	return json.NewEncoder(c.out).Encode(v)
+}
+
+func tunnelEndpointString(tep uenib.TunnelEndpoint) string {
+	if tep.IsEmpty() {
+		return "-"
+	}
+	addr, addrErr := tep.AddressString()
+	teid, teidErr := tep.TeidValue()
+	if addrErr != nil || teidErr != nil {
+		return fmt.Sprintf("%q/%q", tep.Address, tep.Teid)
+	}
+	return fmt.Sprintf("%s/%d", addr, teid)
+}
+
+func cellColumns(cell uenib.Cell) []string {
+	nrCgi := "-"
+	if !cell.NrCgi.IsEmpty() {
+		nrCgi = cell.NrCgi.String()
+	}
+	columns := []string{fmt.Sprint(cell.Pci), fmt.Sprint(cell.SsbFreq), nrCgi}
+	if m := cell.Measurement; m != nil {
+		return append(columns,
+			fmt.Sprintf("%.0f dBm", m.RsrpDbm()),
+			fmt.Sprintf("%.1f dB", m.RsrqDb()),
+			fmt.Sprintf("%.1f dB", m.SinrDb()))
+	}
+	return append(columns, "-", "-", "-")
+}
+
+func cellString(cell uenib.Cell) string {
+	str := fmt.Sprintf("PCI %d SSB NR-ARFCN %d", cell.Pci, cell.SsbFreq)
+	if !cell.NrCgi.IsEmpty() {
+		str += " NR-CGI " + cell.NrCgi.String()
+	}
+	return str
+}
+
+func stateColumns(state uenib.UeState) []string {
+	ts := "-"
+	if t := state.Timestamp(); !t.IsZero() {
+		ts = t.Format("2006-01-02T15:04:05.000Z07:00")
+	}
+	msg := state.MessageName()
+	if msg == "" {
+		msg = "-"
+	}
+	cause := state.Cause
+	if cause == "" {
+		cause = "-"
+	}
+	return []string{ts, msg, cause}
+}
+
+func stateString(state uenib.UeState) string {
+	return strings.Join(stateColumns(state), " ")
+}
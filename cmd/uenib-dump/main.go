@@ -0,0 +1,121 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Command uenib-dump dumps a gNB's UE-NIB namespace to a file and restores it to the database.
+//
+//Usage:
+//
+//	uenib-dump dump <gNb> [file]
+//	uenib-dump restore [-gnb <gNb>] <file>
+//
+//The dump is written to the standard output and the restore reads the standard input, if the
+//file is not given or it is "-". By default the dump is restored to the gNB it was taken from,
+//option -gnb restores it to another gNB. Restore replaces all the existing UE-NIB data of the gNB.
+//Database backend connection is configured by the environment variables of the SDL library.
+//The file format is described in the uenibdump package.
+package main
+
+import (
+	"flag"
+	"fmt"
+	sdl "gerrit.o-ran-sc.org/r/ric-plt/sdlgo"
+	"github.com/nokia/ue-nib-library/pkg/uenibdump"
+	"io"
+	"os"
+)
+
+const usage = `Usage:
+  uenib-dump dump <gNb> [file]
+  uenib-dump restore [-gnb <gNb>] <file>
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		exitWithUsage("missing command")
+	}
+	var err error
+	switch os.Args[1] {
+	case "dump":
+		err = runDump(os.Args[2:])
+	case "restore":
+		err = runRestore(os.Args[2:])
+	default:
+		exitWithUsage(fmt.Sprintf("unknown command '%s'", os.Args[1]))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+func runDump(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		exitWithUsage("wrong number of arguments")
+	}
+	db := sdl.NewSyncStorage()
+	defer db.Close()
+	dump, err := uenibdump.DumpGNb(db, args[0])
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 || args[1] == "-" {
+		return dump.Write(os.Stdout)
+	}
+	f, err := os.Create(args[1])
+	if err != nil {
+		return err
+	}
+	if err = dump.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "dumped %d UEs and %d keys of gNB '%s' to %s\n",
+		len(dump.Ues), len(dump.Entries()), dump.GNb, args[1])
+	return nil
+}
+
+func runRestore(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	gNb := flags.String("gnb", "", "gNB to restore to instead of the dumped gNB")
+	flags.Parse(args)
+	if flags.NArg() > 1 {
+		exitWithUsage("wrong number of arguments")
+	}
+	var r io.Reader = os.Stdin
+	if name := flags.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	dump, err := uenibdump.Read(r)
+	if err != nil {
+		return err
+	}
+	db := sdl.NewSyncStorage()
+	defer db.Close()
+	if err = uenibdump.Restore(db, dump, *gNb); err != nil {
+		return err
+	}
+	target := *gNb
+	if target == "" {
+		target = dump.GNb
+	}
+	fmt.Fprintf(os.Stderr, "restored %d UEs and %d keys of gNB '%s' to gNB '%s'\n",
+		len(dump.Ues), len(dump.Entries()), dump.GNb, target)
+	return nil
+}
+
+func exitWithUsage(msg string) {
+	fmt.Fprintf(os.Stderr, "%s\n%s", msg, usage)
+	os.Exit(2)
+}
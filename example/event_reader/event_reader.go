@@ -60,6 +60,12 @@ func handleEvent(evtInfo *uenibreader.DcEvent) {
 		doSomeDbQueries(evtInfo)
 	case uenibreader.DC_EVENT_S1UL_TUNNEL_RELEASE:
 		doSomeDbQueries(evtInfo)
+	case uenibreader.DC_EVENT_BEARER_TYPE_CHANGE:
+		doSomeDbQueries(evtInfo)
+	case uenibreader.DC_EVENT_PSCELL_CHANGE:
+		doSomeDbQueries(evtInfo)
+	case uenibreader.DC_EVENT_BEARER_MODIFY:
+		doSomeDbQueries(evtInfo)
 	case uenibreader.DC_EVENT_ADD:
 	case uenibreader.DC_EVENT_REMOVE:
 	case uenibreader.DC_EVENT_GNB_ALL_UES_REMOVE:
@@ -85,10 +91,15 @@ func doSomeUeDbQueries(evtInfo *uenibreader.DcEvent) {
 	if err != nil {
 		panic(fmt.Sprintf("GetState(%s) failed, error: %s\n", ueID.String(), err.Error()))
 	}
+	stateHistory, _ := myReader.GetStateHistory(ueID, 10)
 	cell, err := myReader.GetPsCell(ueID)
 	if err != nil {
 		panic(fmt.Sprintf("GetPsCell(%s) failed, error: %s\n", ueID.String(), err.Error()))
 	}
+	cells, err := myReader.GetServingCells(ueID)
+	if err != nil {
+		panic(fmt.Sprintf("GetServingCells(%s) failed, error: %s\n", ueID.String(), err.Error()))
+	}
 	erabIDs, err := myReader.GetBearerIDs(ueID)
 	if err != nil {
 		panic(fmt.Sprintf("GetBearerIDs(%s) failed, error: %s\n", ueID.String(), err.Error()))
@@ -101,7 +112,9 @@ func doSomeUeDbQueries(evtInfo *uenibreader.DcEvent) {
 	log.lines = append(log.lines, fmt.Sprintf("GetMeNbUEX2APID(%s) = %d", ueID.String(), eNbUeX2ApID))
 	log.lines = append(log.lines, fmt.Sprintf("GetSgNbUEX2APID(%s) = %d", ueID.String(), gNbUeX2ApID))
 	log.lines = append(log.lines, fmt.Sprintf("GetState(%s) = %v", ueID.String(), ueState))
+	log.lines = append(log.lines, fmt.Sprintf("GetStateHistory(%s, 10) = %v", ueID.String(), stateHistory))
 	log.lines = append(log.lines, fmt.Sprintf("GetPsCell(%s) = %v", ueID.String(), cell))
+	log.lines = append(log.lines, fmt.Sprintf("GetServingCells(%s) = %v", ueID.String(), cells))
 	log.lines = append(log.lines, fmt.Sprintf("GetBearerIDs(%s) = %v", ueID.String(), erabIDs))
 	log.lines = append(log.lines, fmt.Sprintf("GetBearers(%s) = %v", ueID.String(), erabs))
 	logChannel <- log
@@ -156,18 +169,21 @@ func logger(wg *sync.WaitGroup, channel chan logEvent) {
 }
 
 func subscribeEvents() {
-	err := myReader.SubscribeEvents([]string{someGNb}, []uenibreader.EventCategory{uenibreader.DualConnectivity},
-		func(evGNb string, eventCategory uenibreader.EventCategory, evs []string) {
+	err := myReader.SubscribeParsedEvents([]string{someGNb},
+		[]uenibreader.EventCategory{uenibreader.DualConnectivity, uenibreader.Mobility},
+		func(evGNb string, eventCategory uenibreader.EventCategory, evs []uenibreader.Event) {
 			for _, ev := range evs {
-				switch eventCategory {
-				case uenibreader.DualConnectivity:
-					evInfo, err := uenibreader.ParseDcEvent(ev)
-					if err != nil {
-						panic(fmt.Sprintf("Event parsing failed: %s\n", err.Error()))
-					}
+				if ev.Err != nil {
+					panic(fmt.Sprintf("Event parsing failed: %s\n", ev.Err.Error()))
+				}
+				switch evInfo := ev.Parsed.(type) {
+				case uenibreader.DcEvent:
 					if evInfo.EventType != uenibreader.DC_EVENT_UNKNOWN {
 						ueDcEventHandlerChannel <- evInfo
 					}
+				case uenibreader.MobilityEvent:
+					logChannel <- logEvent{function: evInfo.EventType.String(),
+						lines: []string{fmt.Sprintf("Event = %v", evInfo)}}
 				}
 			}
 		})
@@ -1,9 +1,10 @@
 module github.com/nokia/ue-nib-library
 
-go 1.12
+go 1.13
 
 require (
 	gerrit.o-ran-sc.org/r/ric-plt/sdlgo v0.7.0
+	github.com/golang/protobuf v1.4.2
 	github.com/stretchr/testify v1.3.0
 )
 
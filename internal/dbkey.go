@@ -10,14 +10,71 @@ package internal
 import (
 	"fmt"
 	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"strconv"
+	"strings"
 )
 
 func DbKeyUeMapGNbToENbUeX2ApID(ueID *uenib.UeID) string {
-	return ueID.GNbUeX2ApID + ",UEMAP_ENBUEX2APID"
+	return ueID.GNbUeX2ApID + dbKeyUeMapGNbToENbUeX2ApIDSuffix
+}
+
+const dbKeyUeMapGNbToENbUeX2ApIDSuffix = ",UEMAP_ENBUEX2APID"
+
+//ParseDbKeyUeMapGNbToENbUeX2ApID returns GNbUeX2ApID of a DbKeyUeMapGNbToENbUeX2ApID key.
+//Its value is the ENbUeX2ApID of the UE. False is returned, if the key is some other key.
+func ParseDbKeyUeMapGNbToENbUeX2ApID(key string) (string, bool) {
+	if !strings.HasSuffix(key, dbKeyUeMapGNbToENbUeX2ApIDSuffix) {
+		return "", false
+	}
+	gNbUeX2ApID := strings.TrimSuffix(key, dbKeyUeMapGNbToENbUeX2ApIDSuffix)
+	if len(gNbUeX2ApID) == 0 || strings.Contains(gNbUeX2ApID, ",") {
+		return "", false
+	}
+	return gNbUeX2ApID, true
+}
+
+//ParseDbKeyENbUeX2ApID returns ENbUeX2ApID of a UE key, which begins with ENbUeX2ApID.
+//False is returned, if the key is DbKeyUeMapGNbToENbUeX2ApID key or not a UE key.
+func ParseDbKeyENbUeX2ApID(key string) (string, bool) {
+	if strings.HasSuffix(key, dbKeyUeMapGNbToENbUeX2ApIDSuffix) {
+		return "", false
+	}
+	fields := strings.SplitN(key, ",", 2)
+	if len(fields) != 2 || len(fields[0]) == 0 || len(fields[1]) == 0 {
+		return "", false
+	}
+	return fields[0], true
 }
 
 func DbKeyUeMapENbToGNbUeX2ApID(ueID *uenib.UeID) string {
-	return ueID.ENbUeX2ApID + ",UEMAP_GNBUEX2APID"
+	return ueID.ENbUeX2ApID + dbKeyUeMapENbToGNbUeX2ApIDSuffix
+}
+
+const dbKeyUeMapENbToGNbUeX2ApIDSuffix = ",UEMAP_GNBUEX2APID"
+
+//ParseDbKeyUeMapENbToGNbUeX2ApID returns ENbUeX2ApID of a DbKeyUeMapENbToGNbUeX2ApID key.
+//False is returned, if the key is some other key.
+func ParseDbKeyUeMapENbToGNbUeX2ApID(key string) (string, bool) {
+	if !strings.HasSuffix(key, dbKeyUeMapENbToGNbUeX2ApIDSuffix) {
+		return "", false
+	}
+	eNbUeX2ApID := strings.TrimSuffix(key, dbKeyUeMapENbToGNbUeX2ApIDSuffix)
+	if len(eNbUeX2ApID) == 0 || strings.Contains(eNbUeX2ApID, ",") {
+		return "", false
+	}
+	return eNbUeX2ApID, true
+}
+
+//DbKeyUeVersion is the key of UE's version, which the UE-NIB writer increments in the same
+//atomic write as it updates the other keys of the UE.
+func DbKeyUeVersion(ueID *uenib.UeID) string {
+	return ueID.ENbUeX2ApID + ",UE_VERSION"
+}
+
+//DbKeyUeLastUpdated is the key of the time when the UE-NIB writer updated the UE last time,
+//see uenib.FormatLastUpdated().
+func DbKeyUeLastUpdated(ueID *uenib.UeID) string {
+	return ueID.ENbUeX2ApID + ",UE_LAST_UPDATED"
 }
 
 func DbKeyUeStateEvent(ueID *uenib.UeID) string {
@@ -28,6 +85,18 @@ func DbKeyUeStateCause(ueID *uenib.UeID) string {
 	return ueID.ENbUeX2ApID + ",UE_STATE_CAUSE"
 }
 
+func DbKeyUeStateHistorySeqs(ueID *uenib.UeID) string {
+	return ueID.ENbUeX2ApID + ",UE_STATE_HISTORY_SEQS"
+}
+
+func DbKeyUeStateHistoryEvent(ueID *uenib.UeID, seq uint64) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(seq) + ",UE_STATE_HISTORY_EVENT"
+}
+
+func DbKeyUeStateHistoryCause(ueID *uenib.UeID, seq uint64) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(seq) + ",UE_STATE_HISTORY_CAUSE"
+}
+
 func DbKeyPsCellPci(ueID *uenib.UeID) string {
 	return ueID.ENbUeX2ApID + ",UE_PSCELL_PCI"
 }
@@ -36,6 +105,50 @@ func DbKeyPsCellSsbFreq(ueID *uenib.UeID) string {
 	return ueID.ENbUeX2ApID + ",UE_PSCELL_FREQ"
 }
 
+func DbKeyPsCellNrCgi(ueID *uenib.UeID) string {
+	return ueID.ENbUeX2ApID + ",UE_PSCELL_NRCGI"
+}
+
+func DbKeyPsCellRsrp(ueID *uenib.UeID) string {
+	return ueID.ENbUeX2ApID + ",UE_PSCELL_RSRP"
+}
+
+func DbKeyPsCellRsrq(ueID *uenib.UeID) string {
+	return ueID.ENbUeX2ApID + ",UE_PSCELL_RSRQ"
+}
+
+func DbKeyPsCellSinr(ueID *uenib.UeID) string {
+	return ueID.ENbUeX2ApID + ",UE_PSCELL_SINR"
+}
+
+func DbKeyUeSCellIndexes(ueID *uenib.UeID) string {
+	return ueID.ENbUeX2ApID + ",UE_SCELL_INDEXES"
+}
+
+func DbKeySCellPci(ueID *uenib.UeID, sCellIndex uenib.SCellIndex) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(sCellIndex) + ",UE_SCELL_PCI"
+}
+
+func DbKeySCellSsbFreq(ueID *uenib.UeID, sCellIndex uenib.SCellIndex) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(sCellIndex) + ",UE_SCELL_FREQ"
+}
+
+func DbKeySCellNrCgi(ueID *uenib.UeID, sCellIndex uenib.SCellIndex) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(sCellIndex) + ",UE_SCELL_NRCGI"
+}
+
+func DbKeySCellRsrp(ueID *uenib.UeID, sCellIndex uenib.SCellIndex) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(sCellIndex) + ",UE_SCELL_RSRP"
+}
+
+func DbKeySCellRsrq(ueID *uenib.UeID, sCellIndex uenib.SCellIndex) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(sCellIndex) + ",UE_SCELL_RSRQ"
+}
+
+func DbKeySCellSinr(ueID *uenib.UeID, sCellIndex uenib.SCellIndex) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(sCellIndex) + ",UE_SCELL_SINR"
+}
+
 func DbKeyUeErabIDs(ueID *uenib.UeID) string {
 	return ueID.ENbUeX2ApID + ",UE_ERAB_IDS"
 }
@@ -52,6 +165,26 @@ func DbKeyErabS1UlGtpTendpTeid(ueID *uenib.UeID, erabID uenib.ErabID) string {
 	return ueID.ENbUeX2ApID + "," + fmt.Sprint(erabID) + ",UE_ERAB_S1_UL_GTP_TUNNEL_TEID"
 }
 
+func DbKeyErabS1DlGtpTendpAddr(ueID *uenib.UeID, erabID uenib.ErabID) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(erabID) + ",UE_ERAB_S1_DL_GTP_TUNNEL_ADDR"
+}
+
+func DbKeyErabS1DlGtpTendpTeid(ueID *uenib.UeID, erabID uenib.ErabID) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(erabID) + ",UE_ERAB_S1_DL_GTP_TUNNEL_TEID"
+}
+
+func DbKeyErabX2UGtpTendpAddr(ueID *uenib.UeID, erabID uenib.ErabID) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(erabID) + ",UE_ERAB_X2U_GTP_TUNNEL_ADDR"
+}
+
+func DbKeyErabX2UGtpTendpTeid(ueID *uenib.UeID, erabID uenib.ErabID) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(erabID) + ",UE_ERAB_X2U_GTP_TUNNEL_TEID"
+}
+
+func DbKeyErabBearerType(ueID *uenib.UeID, erabID uenib.ErabID) string {
+	return ueID.ENbUeX2ApID + "," + fmt.Sprint(erabID) + ",UE_ERAB_BEARER_TYPE"
+}
+
 func DbKeyErabQosArpPL(ueID *uenib.UeID, erabID uenib.ErabID) string {
 	return ueID.ENbUeX2ApID + "," + fmt.Sprint(erabID) + ",UE_ERAB_QOS_ARP_PL"
 }
@@ -67,9 +200,46 @@ func GetErabAllDbKeys(ueID *uenib.UeID, erabID uenib.ErabID) []string {
 		DbKeyErabS1UlGtpTendpTeid(ueID, erabID),
 		DbKeyErabQosArpPL(ueID, erabID),
 		DbKeyErabQosQci(ueID, erabID),
+		DbKeyErabBearerType(ueID, erabID),
+		DbKeyErabS1DlGtpTendpAddr(ueID, erabID),
+		DbKeyErabS1DlGtpTendpTeid(ueID, erabID),
+		DbKeyErabX2UGtpTendpAddr(ueID, erabID),
+		DbKeyErabX2UGtpTendpTeid(ueID, erabID),
+	}
+}
+
+func GetPsCellAllDbKeys(ueID *uenib.UeID) []string {
+	return []string{
+		DbKeyPsCellPci(ueID),
+		DbKeyPsCellSsbFreq(ueID),
+		DbKeyPsCellNrCgi(ueID),
+		DbKeyPsCellRsrp(ueID),
+		DbKeyPsCellRsrq(ueID),
+		DbKeyPsCellSinr(ueID),
+	}
+}
+
+func GetSCellAllDbKeys(ueID *uenib.UeID, sCellIndex uenib.SCellIndex) []string {
+	return []string{
+		DbKeySCellPci(ueID, sCellIndex),
+		DbKeySCellSsbFreq(ueID, sCellIndex),
+		DbKeySCellNrCgi(ueID, sCellIndex),
+		DbKeySCellRsrp(ueID, sCellIndex),
+		DbKeySCellRsrq(ueID, sCellIndex),
+		DbKeySCellSinr(ueID, sCellIndex),
 	}
 }
 
 func GetUeNibNs(gNb string) string {
 	return "uenib/" + gNb
 }
+
+//LessX2ApID compares X2AP IDs numerically, if both are numbers, otherwise as strings.
+func LessX2ApID(a string, b string) bool {
+	aVal, aErr := strconv.ParseUint(a, 10, 64)
+	bVal, bErr := strconv.ParseUint(b, 10, 64)
+	if aErr == nil && bErr == nil {
+		return aVal < bVal
+	}
+	return a < b
+}
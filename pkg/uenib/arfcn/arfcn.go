@@ -0,0 +1,141 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package arfcn provides NR Absolute Radio Frequency Channel Number (NR-ARFCN) conversions
+//according to the global frequency raster defined in 3GPP TS 38.104 section 5.4.2.1 and
+//the NR operating band tables 5.4.2.3-1 and 5.4.2.3-2.
+package arfcn
+
+import (
+	"fmt"
+)
+
+//MaxArfcn is the biggest valid NR-ARFCN value.
+const MaxArfcn uint32 = 3279165
+
+//Band type identifies an NR operating band, for example Band(78) is band n78.
+type Band uint32
+
+//Global frequency raster parameters, 3GPP TS 38.104 table 5.4.2.1-1.
+type rasterRange struct {
+	minKHz   uint32 //F_REF-Offs in kHz
+	maxKHz   uint32
+	stepKHz  uint32 //ΔF_Global in kHz
+	minArfcn uint32 //N_REF-Offs
+	maxArfcn uint32
+}
+
+var globalRaster = [...]rasterRange{
+	{minKHz: 0, maxKHz: 2999995, stepKHz: 5, minArfcn: 0, maxArfcn: 599999},
+	{minKHz: 3000000, maxKHz: 24249990, stepKHz: 15, minArfcn: 600000, maxArfcn: 2016666},
+	{minKHz: 24250080, maxKHz: 99999960, stepKHz: 60, minArfcn: 2016667, maxArfcn: MaxArfcn},
+}
+
+//Downlink NR-ARFCN range of an NR operating band.
+type bandRange struct {
+	band     Band
+	minArfcn uint32
+	maxArfcn uint32
+}
+
+//NR operating band downlink NR-ARFCN ranges, 3GPP TS 38.104 tables 5.4.2.3-1 and 5.4.2.3-2.
+//Supplementary uplink bands are not listed, because they do not have downlink.
+var bandRanges = [...]bandRange{
+	{1, 422000, 434000},
+	{2, 386000, 398000},
+	{3, 361000, 376000},
+	{5, 173800, 178800},
+	{7, 524000, 538000},
+	{8, 185000, 192000},
+	{12, 145800, 149200},
+	{14, 151600, 153600},
+	{18, 172000, 175000},
+	{20, 158200, 164200},
+	{25, 386000, 399000},
+	{26, 171800, 178800},
+	{28, 151600, 160600},
+	{29, 143400, 145600},
+	{30, 470000, 472000},
+	{34, 402000, 405000},
+	{38, 514000, 524000},
+	{39, 376000, 384000},
+	{40, 460000, 480000},
+	{41, 499200, 537999},
+	{48, 636667, 646666},
+	{50, 286400, 303400},
+	{51, 285400, 286400},
+	{53, 496700, 499000},
+	{65, 422000, 440000},
+	{66, 422000, 440000},
+	{70, 399000, 404000},
+	{71, 123400, 130400},
+	{74, 295000, 303600},
+	{75, 286400, 303400},
+	{76, 285400, 286400},
+	{77, 620000, 680000},
+	{78, 620000, 653333},
+	{79, 693334, 733333},
+	{90, 499200, 537999},
+	{91, 285400, 286400},
+	{92, 286400, 303400},
+	{93, 285400, 286400},
+	{94, 286400, 303400},
+	{257, 2054166, 2104165},
+	{258, 2016667, 2070832},
+	{259, 2270833, 2337499},
+	{260, 2229166, 2279165},
+	{261, 2070833, 2084999},
+}
+
+//String returns NR operating band as a string, for example "n78".
+func (band Band) String() string {
+	return fmt.Sprintf("n%d", uint32(band))
+}
+
+//IsFR2 returns true if the band is in frequency range 2 (mmWave).
+func (band Band) IsFR2() bool {
+	return band >= 257
+}
+
+//ArfcnToKHz converts NR-ARFCN to an absolute RF reference frequency in kHz.
+//An error is returned, if NR-ARFCN is out of the valid range.
+func ArfcnToKHz(arfcn uint32) (uint32, error) {
+	for _, r := range globalRaster {
+		if arfcn >= r.minArfcn && arfcn <= r.maxArfcn {
+			return r.minKHz + r.stepKHz*(arfcn-r.minArfcn), nil
+		}
+	}
+	return 0, fmt.Errorf("NR-ARFCN %d is out of range 0-%d", arfcn, MaxArfcn)
+}
+
+//KHzToArfcn converts an absolute RF reference frequency in kHz to NR-ARFCN.
+//An error is returned, if the frequency is not on the global frequency raster.
+func KHzToArfcn(kHz uint32) (uint32, error) {
+	for _, r := range globalRaster {
+		if kHz >= r.minKHz && kHz <= r.maxKHz {
+			if (kHz-r.minKHz)%r.stepKHz != 0 {
+				return 0, fmt.Errorf("frequency %d kHz is not on the %d kHz global raster",
+					kHz, r.stepKHz)
+			}
+			return r.minArfcn + (kHz-r.minKHz)/r.stepKHz, nil
+		}
+	}
+	return 0, fmt.Errorf("frequency %d kHz is not on the global raster", kHz)
+}
+
+//CandidateBands returns NR operating bands whose downlink NR-ARFCN range contains the given
+//NR-ARFCN. Many bands overlap, hence several candidates may be returned. Nil is returned, if
+//NR-ARFCN does not belong to any known band.
+func CandidateBands(arfcn uint32) []Band {
+	var bands []Band
+	for _, r := range bandRanges {
+		if arfcn >= r.minArfcn && arfcn <= r.maxArfcn {
+			bands = append(bands, r.band)
+		}
+	}
+	return bands
+}
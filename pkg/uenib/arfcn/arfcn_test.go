@@ -0,0 +1,93 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package arfcn_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib/arfcn"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestArfcnToKHzSuccess(t *testing.T) {
+	testCases := []struct {
+		arfcn uint32
+		kHz   uint32
+	}{
+		{0, 0},
+		{428000, 2140000},
+		{599999, 2999995},
+		{600000, 3000000},
+		{632628, 3489420},
+		{2016666, 24249990},
+		{2016667, 24250080},
+		{2079165, 27999960},
+		{arfcn.MaxArfcn, 99999960},
+	}
+	for _, tc := range testCases {
+		ret, err := arfcn.ArfcnToKHz(tc.arfcn)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.kHz, ret)
+	}
+}
+
+func TestArfcnToKHzReturnsErrorIfArfcnOutOfRange(t *testing.T) {
+	_, err := arfcn.ArfcnToKHz(arfcn.MaxArfcn + 1)
+	assert.NotNil(t, err)
+}
+
+func TestKHzToArfcnSuccess(t *testing.T) {
+	testCases := []struct {
+		kHz   uint32
+		arfcn uint32
+	}{
+		{2140000, 428000},
+		{3489420, 632628},
+		{24250080, 2016667},
+		{27999960, 2079165},
+	}
+	for _, tc := range testCases {
+		ret, err := arfcn.KHzToArfcn(tc.kHz)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.arfcn, ret)
+	}
+}
+
+func TestKHzToArfcnReturnsErrorIfFrequencyNotOnRaster(t *testing.T) {
+	_, err := arfcn.KHzToArfcn(2140001)
+	assert.NotNil(t, err)
+	_, err = arfcn.KHzToArfcn(3000005)
+	assert.NotNil(t, err)
+	_, err = arfcn.KHzToArfcn(24250000)
+	assert.NotNil(t, err)
+	_, err = arfcn.KHzToArfcn(100000000)
+	assert.NotNil(t, err)
+}
+
+func TestArfcnConversionRoundTrip(t *testing.T) {
+	for nrArfcn := uint32(0); nrArfcn <= arfcn.MaxArfcn; nrArfcn += 997 {
+		kHz, err := arfcn.ArfcnToKHz(nrArfcn)
+		assert.Nil(t, err)
+		ret, err := arfcn.KHzToArfcn(kHz)
+		assert.Nil(t, err)
+		assert.Equal(t, nrArfcn, ret)
+	}
+}
+
+func TestCandidateBands(t *testing.T) {
+	assert.Equal(t, []arfcn.Band{77, 78}, arfcn.CandidateBands(632628))
+	assert.Equal(t, []arfcn.Band{79}, arfcn.CandidateBands(700000))
+	assert.Equal(t, []arfcn.Band{1, 65, 66}, arfcn.CandidateBands(428000))
+	assert.Equal(t, []arfcn.Band{257, 261}, arfcn.CandidateBands(2079165))
+	assert.Nil(t, arfcn.CandidateBands(0))
+}
+
+func TestBandString(t *testing.T) {
+	assert.Equal(t, "n78", arfcn.Band(78).String())
+	assert.Equal(t, false, arfcn.Band(78).IsFR2())
+	assert.Equal(t, true, arfcn.Band(261).IsFR2())
+}
@@ -0,0 +1,112 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib
+
+import (
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib/arfcn"
+	"strconv"
+	"strings"
+)
+
+//Maximum value of a 36 bit NR Cell Identity.
+const maxNrCellID uint64 = 1<<36 - 1
+
+//SsbFreqKHz returns the absolute frequency of the cell's SSB in kHz.
+//An error is returned, if SsbFreq is not a valid NR-ARFCN.
+func (cell Cell) SsbFreqKHz() (uint32, error) {
+	return arfcn.ArfcnToKHz(cell.SsbFreq)
+}
+
+//SsbBands returns candidate NR operating bands of the cell's SSB frequency.
+func (cell Cell) SsbBands() []arfcn.Band {
+	return arfcn.CandidateBands(cell.SsbFreq)
+}
+
+//SameFrequency returns true if the cells have an SSB on the same absolute frequency.
+func (cell Cell) SameFrequency(other Cell) bool {
+	return cell.SsbFreq == other.SsbFreq
+}
+
+//Helper function to print Cell with the SSB frequency in MHz and candidate bands.
+func (cell Cell) String() string {
+	var str string
+	kHz, err := cell.SsbFreqKHz()
+	if err != nil {
+		str = fmt.Sprintf("Cell:[Pci:%d,SsbFreq:%d", cell.Pci, cell.SsbFreq)
+	} else {
+		str = fmt.Sprintf("Cell:[Pci:%d,SsbFreq:%d (%d.%03d MHz %v)",
+			cell.Pci, cell.SsbFreq, kHz/1000, kHz%1000, cell.SsbBands())
+	}
+	if !cell.NrCgi.IsEmpty() {
+		str += ",NrCgi:" + cell.NrCgi.String()
+	}
+	if cell.Measurement != nil {
+		str += fmt.Sprintf(",Rsrp:%d,Rsrq:%d,Sinr:%d",
+			cell.Measurement.Rsrp, cell.Measurement.Rsrq, cell.Measurement.Sinr)
+	}
+	return str + "]"
+}
+
+//IsEmpty returns true if NR-CGI is not set.
+func (nrCgi NrCgi) IsEmpty() bool {
+	return nrCgi == NrCgi{}
+}
+
+//String returns NR-CGI as a string form of: <3 MCC digits>-<2 or 3 MNC digits>-<NCI as 9 hex digits>.
+func (nrCgi NrCgi) String() string {
+	return fmt.Sprintf("%s-%s-%09x", nrCgi.Mcc, nrCgi.Mnc, nrCgi.NrCellID)
+}
+
+//ParseNrCgi converts an NR-CGI string form of:
+//<3 MCC digits>-<2 or 3 MNC digits>-<NCI in hex> to NrCgi.
+//An error is returned, if the string is not a valid NR-CGI.
+func ParseNrCgi(str string) (NrCgi, error) {
+	fields := strings.Split(str, "-")
+	if len(fields) != 3 {
+		return NrCgi{}, fmt.Errorf("NR-CGI '%s' has wrong number of fields", str)
+	}
+	if !isDigits(fields[0], 3, 3) {
+		return NrCgi{}, fmt.Errorf("NR-CGI '%s' has invalid MCC", str)
+	}
+	if !isDigits(fields[1], 2, 3) {
+		return NrCgi{}, fmt.Errorf("NR-CGI '%s' has invalid MNC", str)
+	}
+	nci, err := strconv.ParseUint(fields[2], 16, 64)
+	if err != nil || nci > maxNrCellID {
+		return NrCgi{}, fmt.Errorf("NR-CGI '%s' has invalid NR Cell Identity", str)
+	}
+	return NrCgi{Mcc: fields[0], Mnc: fields[1], NrCellID: nci}, nil
+}
+
+//RsrpDbm returns the lower bound of the reported SS-RSRP range in dBm.
+func (meas CellMeasurement) RsrpDbm() float64 {
+	return float64(meas.Rsrp) - 157
+}
+
+//RsrqDb returns the lower bound of the reported SS-RSRQ range in dB.
+func (meas CellMeasurement) RsrqDb() float64 {
+	return (float64(meas.Rsrq) - 87) / 2
+}
+
+//SinrDb returns the lower bound of the reported SS-SINR range in dB.
+func (meas CellMeasurement) SinrDb() float64 {
+	return (float64(meas.Sinr) - 47) / 2
+}
+
+func isDigits(str string, minLen int, maxLen int) bool {
+	if len(str) < minLen || len(str) > maxLen {
+		return false
+	}
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
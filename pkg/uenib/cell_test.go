@@ -0,0 +1,82 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenib/arfcn"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCellSsbFreqKHz(t *testing.T) {
+	cell := uenib.Cell{Pci: 1, SsbFreq: 632628}
+	kHz, err := cell.SsbFreqKHz()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(3489420), kHz)
+	assert.Equal(t, []arfcn.Band{77, 78}, cell.SsbBands())
+}
+
+func TestCellSsbFreqKHzReturnsErrorIfInvalidArfcn(t *testing.T) {
+	cell := uenib.Cell{Pci: 1, SsbFreq: 4000000}
+	_, err := cell.SsbFreqKHz()
+	assert.NotNil(t, err)
+	assert.Equal(t, "Cell:[Pci:1,SsbFreq:4000000]", cell.String())
+}
+
+func TestCellString(t *testing.T) {
+	cell := uenib.Cell{Pci: 10, SsbFreq: 632628}
+	assert.Equal(t, "Cell:[Pci:10,SsbFreq:632628 (3489.420 MHz [n77 n78])]", cell.String())
+}
+
+func TestCellSameFrequency(t *testing.T) {
+	cell := uenib.Cell{Pci: 10, SsbFreq: 632628}
+	assert.Equal(t, true, cell.SameFrequency(uenib.Cell{Pci: 11, SsbFreq: 632628}))
+	assert.Equal(t, false, cell.SameFrequency(uenib.Cell{Pci: 10, SsbFreq: 632629}))
+}
+
+func TestCellStringWithNrCgiAndMeasurement(t *testing.T) {
+	cell := uenib.Cell{
+		Pci:         10,
+		SsbFreq:     4000000,
+		NrCgi:       uenib.NrCgi{Mcc: "310", Mnc: "41", NrCellID: 0xabc},
+		Measurement: &uenib.CellMeasurement{Rsrp: 60, Rsrq: 70, Sinr: 80},
+	}
+	assert.Equal(t, "Cell:[Pci:10,SsbFreq:4000000,NrCgi:310-41-000000abc,Rsrp:60,Rsrq:70,Sinr:80]", cell.String())
+}
+
+func TestParseNrCgiSuccess(t *testing.T) {
+	ret, err := uenib.ParseNrCgi("310-410-b5c677880")
+	assert.Nil(t, err)
+	assert.Equal(t, uenib.NrCgi{Mcc: "310", Mnc: "410", NrCellID: 0xb5c677880}, ret)
+	assert.Equal(t, "310-410-b5c677880", ret.String())
+}
+
+func TestParseNrCgiReturnsErrorIfInvalid(t *testing.T) {
+	for _, str := range []string{"", "310-410", "31-410-1", "310-4-1", "310-41a-1", "310-410-x",
+		"310-410-1000000000", "310-410-1-2"} {
+		_, err := uenib.ParseNrCgi(str)
+		assert.NotNil(t, err, str)
+	}
+}
+
+func TestNrCgiIsEmpty(t *testing.T) {
+	assert.Equal(t, true, uenib.NrCgi{}.IsEmpty())
+	assert.Equal(t, false, uenib.NrCgi{Mcc: "310"}.IsEmpty())
+}
+
+func TestCellMeasurementConversions(t *testing.T) {
+	meas := uenib.CellMeasurement{Rsrp: 1, Rsrq: 1, Sinr: 1}
+	assert.Equal(t, float64(-156), meas.RsrpDbm())
+	assert.Equal(t, float64(-43), meas.RsrqDb())
+	assert.Equal(t, float64(-23), meas.SinrDb())
+	meas = uenib.CellMeasurement{Rsrp: 126, Rsrq: 126, Sinr: 126}
+	assert.Equal(t, float64(-31), meas.RsrpDbm())
+	assert.Equal(t, 19.5, meas.RsrqDb())
+	assert.Equal(t, 39.5, meas.SinrDb())
+}
@@ -0,0 +1,111 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib
+
+import (
+	"encoding/json"
+)
+
+//Canonical JSON form of the UE-NIB types uses lowerCamelCase field names as given in the
+//struct field tags. Bearer types are strings ("MCG", "SCG" or "SPLIT"), NR-CGIs are strings
+//form of <MCC>-<MNC>-<NCI in hex> and tunnel endpoints are objects with a readable
+//address string and a numeric TEID:
+//   {"address":"192.0.2.1+2001:db8::68","teid":1234}
+//Empty NR-CGIs and tunnel endpoints are null.
+
+//tunnelEndpointJSON is the canonical JSON form of TunnelEndpoint.
+type tunnelEndpointJSON struct {
+	Address string `json:"address"`
+	Teid    uint32 `json:"teid"`
+}
+
+//MarshalJSON returns bearer type as a JSON string.
+func (bearerType BearerType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bearerType.String())
+}
+
+//UnmarshalJSON parses bearer type from a JSON string. Bearer type string "UNKNOWN" and
+//null are accepted too, both result BEARER_TYPE_UNKNOWN.
+func (bearerType *BearerType) UnmarshalJSON(data []byte) error {
+	var str *string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	if str == nil || *str == BEARER_TYPE_UNKNOWN.String() {
+		*bearerType = BEARER_TYPE_UNKNOWN
+		return nil
+	}
+	parsed, err := ParseBearerType(*str)
+	if err != nil {
+		return err
+	}
+	*bearerType = parsed
+	return nil
+}
+
+//MarshalJSON returns NR-CGI as a JSON string or null, if the NR-CGI is empty.
+func (nrCgi NrCgi) MarshalJSON() ([]byte, error) {
+	if nrCgi.IsEmpty() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nrCgi.String())
+}
+
+//UnmarshalJSON parses NR-CGI from a JSON string or null.
+func (nrCgi *NrCgi) UnmarshalJSON(data []byte) error {
+	var str *string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	if str == nil {
+		*nrCgi = NrCgi{}
+		return nil
+	}
+	parsed, err := ParseNrCgi(*str)
+	if err != nil {
+		return err
+	}
+	*nrCgi = parsed
+	return nil
+}
+
+//MarshalJSON returns tunnel endpoint as a JSON object with a readable address and TEID or
+//null, if the tunnel endpoint is empty. An error is returned, if the address or TEID is
+//not in the form described in TunnelEndpoint.
+func (tep TunnelEndpoint) MarshalJSON() ([]byte, error) {
+	if tep.IsEmpty() {
+		return []byte("null"), nil
+	}
+	addr, err := tep.AddressString()
+	if err != nil {
+		return nil, err
+	}
+	teid, err := tep.TeidValue()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tunnelEndpointJSON{Address: addr, Teid: teid})
+}
+
+//UnmarshalJSON parses tunnel endpoint from a JSON object or null.
+func (tep *TunnelEndpoint) UnmarshalJSON(data []byte) error {
+	var tepJSON *tunnelEndpointJSON
+	if err := json.Unmarshal(data, &tepJSON); err != nil {
+		return err
+	}
+	if tepJSON == nil {
+		*tep = TunnelEndpoint{}
+		return nil
+	}
+	parsed, err := NewTunnelEndpoint(tepJSON.Address, tepJSON.Teid)
+	if err != nil {
+		return err
+	}
+	*tep = parsed
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib_test
+
+import (
+	"encoding/json"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUeIDJSON(t *testing.T) {
+	ueID := uenib.UeID{GNb: "somegnb:310-410-b5c67788", GNbUeX2ApID: "100", ENbUeX2ApID: "200"}
+	data, err := json.Marshal(ueID)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"gNb":"somegnb:310-410-b5c67788","gNbUeX2ApId":"100","eNbUeX2ApId":"200"}`, string(data))
+
+	var retUeID uenib.UeID
+	assert.Nil(t, json.Unmarshal(data, &retUeID))
+	assert.Equal(t, ueID, retUeID)
+}
+
+func TestBearerJSON(t *testing.T) {
+	s1ul, _ := uenib.NewTunnelEndpoint("10.20.30.40+2001:db8::68", 5000)
+	s1dl, _ := uenib.NewTunnelEndpoint("2001:db8::69", 6000)
+	bearer := uenib.Bearer{
+		ErabID:     5,
+		DrbID:      6,
+		ArpPL:      7,
+		Qci:        9,
+		BearerType: uenib.BEARER_TYPE_SCG,
+		S1ULGtpTE:  s1ul,
+		S1DLGtpTE:  s1dl,
+	}
+	data, err := json.Marshal(bearer)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"erabId":5,"drbId":6,"arpPriorityLevel":7,"qci":9,"bearerType":"SCG",`+
+		`"s1ulGtpTunnelEndpoint":{"address":"10.20.30.40+2001:db8::68","teid":5000},`+
+		`"s1dlGtpTunnelEndpoint":{"address":"2001:db8::69","teid":6000},`+
+		`"x2uGtpTunnelEndpoint":null}`, string(data))
+
+	var retBearer uenib.Bearer
+	assert.Nil(t, json.Unmarshal(data, &retBearer))
+	assert.Equal(t, bearer, retBearer)
+}
+
+func TestBearerJSONWithTunnelEndpointStoredByWriter(t *testing.T) {
+	bearer := uenib.Bearer{
+		ErabID:    5,
+		S1ULGtpTE: uenib.TunnelEndpoint{Address: []byte("10.20.30.40"), Teid: []byte("1999")},
+	}
+	data, err := json.Marshal(bearer)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"s1ulGtpTunnelEndpoint":{"address":"10.20.30.40","teid":1999}`)
+
+	var retBearer uenib.Bearer
+	assert.Nil(t, json.Unmarshal(data, &retBearer))
+	assert.Equal(t, bearer, retBearer)
+}
+
+func TestBearerJSONMarshalFailureIfInvalidTunnelEndpoint(t *testing.T) {
+	bearer := uenib.Bearer{S1ULGtpTE: uenib.TunnelEndpoint{Address: []byte("10.20.30.40"), Teid: []byte{0, 0, 0x07, 0xcf}}}
+	_, err := json.Marshal(bearer)
+	assert.NotNil(t, err)
+
+	bearer = uenib.Bearer{S1ULGtpTE: uenib.TunnelEndpoint{Address: []byte{10, 20, 30, 40}, Teid: []byte("1999")}}
+	_, err = json.Marshal(bearer)
+	assert.NotNil(t, err)
+}
+
+func TestBearerJSONUnmarshalFailure(t *testing.T) {
+	var bearer uenib.Bearer
+	assert.NotNil(t, json.Unmarshal([]byte(`{"bearerType":"XYZ"}`), &bearer))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"s1ulGtpTunnelEndpoint":{"address":"x","teid":1}}`), &bearer))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"s1ulGtpTunnelEndpoint":{"address":"10.20.30.40","teid":"1999"}}`), &bearer))
+}
+
+func TestServingCellsJSON(t *testing.T) {
+	cells := uenib.ServingCells{
+		PsCell: uenib.Cell{
+			Pci:         500,
+			SsbFreq:     632628,
+			NrCgi:       uenib.NrCgi{Mcc: "310", Mnc: "410", NrCellID: 0xb5c677880},
+			Measurement: &uenib.CellMeasurement{Rsrp: 60, Rsrq: 70, Sinr: 80},
+		},
+		SCells: []uenib.SCell{
+			uenib.SCell{SCellIndex: 1, Cell: uenib.Cell{Pci: 501, SsbFreq: 632628}},
+		},
+	}
+	data, err := json.Marshal(cells)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"psCell":{"pci":500,"ssbFreq":632628,"nrCgi":"310-410-b5c677880",`+
+		`"measurement":{"rsrp":60,"rsrq":70,"sinr":80}},`+
+		`"sCells":[{"sCellIndex":1,"pci":501,"ssbFreq":632628,"nrCgi":null}]}`, string(data))
+
+	var retCells uenib.ServingCells
+	assert.Nil(t, json.Unmarshal(data, &retCells))
+	assert.Equal(t, cells, retCells)
+}
+
+func TestCellJSONUnmarshalFailsIfInvalidNrCgi(t *testing.T) {
+	var cell uenib.Cell
+	assert.NotNil(t, json.Unmarshal([]byte(`{"pci":1,"ssbFreq":2,"nrCgi":"1-2-3"}`), &cell))
+}
+
+func TestUeStateJSON(t *testing.T) {
+	state := uenib.UeState{Event: "1582638562.133 SgNBAdditionRequest", Cause: "radioNetwork:unspecified"}
+	data, err := json.Marshal(state)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"event":"1582638562.133 SgNBAdditionRequest","cause":"radioNetwork:unspecified"}`, string(data))
+}
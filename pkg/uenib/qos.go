@@ -0,0 +1,47 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib/qos"
+	"time"
+)
+
+//QosCharacteristics returns standardized QoS characteristics of the bearer's QCI. The second
+//return value is false, if the QCI is operator-specific and its characteristics have not been
+//registered with qos.RegisterOperatorSpecificQci().
+func (bearer Bearer) QosCharacteristics() (qos.Characteristics, bool) {
+	return qos.LookupQci(bearer.Qci)
+}
+
+//ResourceType returns resource type of the bearer's QCI. RESOURCE_TYPE_UNKNOWN is returned,
+//if QCI characteristics are unknown.
+func (bearer Bearer) ResourceType() qos.ResourceType {
+	characteristics, _ := bearer.QosCharacteristics()
+	return characteristics.ResourceType
+}
+
+//IsGBR returns true if the bearer is a guaranteed bit rate bearer. False is returned also
+//when QCI characteristics are unknown.
+func (bearer Bearer) IsGBR() bool {
+	return bearer.ResourceType().IsGBR()
+}
+
+//PacketDelayBudget returns packet delay budget of the bearer's QCI. Zero is returned, if QCI
+//characteristics are unknown.
+func (bearer Bearer) PacketDelayBudget() time.Duration {
+	characteristics, _ := bearer.QosCharacteristics()
+	return characteristics.PacketDelayBudget
+}
+
+//PriorityLevel returns priority level of the bearer's QCI. Lower value means higher priority.
+//Zero is returned, if QCI characteristics are unknown.
+func (bearer Bearer) PriorityLevel() float64 {
+	characteristics, _ := bearer.QosCharacteristics()
+	return characteristics.PriorityLevel
+}
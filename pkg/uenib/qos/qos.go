@@ -0,0 +1,183 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package qos provides standardized QoS characteristics of LTE QoS Class Identifiers (QCI)
+//defined in 3GPP TS 23.203 table 6.1.7-A and of 5G QoS Identifiers (5QI) defined in
+//3GPP TS 23.501 table 5.7.4-1.
+//
+//QCI and 5QI values 128-254 are reserved for operator-specific use. Their characteristics are
+//not standardized, but they can be registered by the application with
+//RegisterOperatorSpecificQci() and RegisterOperatorSpecific5qi() functions.
+package qos
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//ResourceType defines resource type of a QoS flow or a bearer.
+type ResourceType int
+
+const (
+	RESOURCE_TYPE_UNKNOWN ResourceType = iota
+	RESOURCE_TYPE_GBR
+	RESOURCE_TYPE_NON_GBR
+	RESOURCE_TYPE_DELAY_CRITICAL_GBR
+)
+
+const (
+	//OperatorSpecificMin is the smallest QCI and 5QI value reserved for operator-specific use.
+	OperatorSpecificMin uint32 = 128
+	//OperatorSpecificMax is the biggest QCI and 5QI value reserved for operator-specific use.
+	OperatorSpecificMax uint32 = 254
+)
+
+//Characteristics is a holder for QoS characteristics of a QCI or a 5QI value.
+type Characteristics struct {
+	ResourceType        ResourceType
+	PriorityLevel       float64       //Lower value means higher priority.
+	PacketDelayBudget   time.Duration //Upper bound for the time that a packet may be delayed.
+	PacketErrorLossRate float64       //Upper bound for the rate of lost or corrupted packets.
+	MaxDataBurstVolume  uint32        //Bytes. Set only for the delay critical GBR resource type.
+}
+
+//String returns resource type as a string.
+func (resourceType ResourceType) String() string {
+	resourceTypes := [...]string{"UNKNOWN", "GBR", "NON_GBR", "DELAY_CRITICAL_GBR"}
+	if resourceType < RESOURCE_TYPE_UNKNOWN || resourceType > RESOURCE_TYPE_DELAY_CRITICAL_GBR {
+		return resourceTypes[RESOURCE_TYPE_UNKNOWN]
+	}
+	return resourceTypes[resourceType]
+}
+
+//IsGBR returns true for the resource types, which have a guaranteed flow bit rate.
+func (resourceType ResourceType) IsGBR() bool {
+	return resourceType == RESOURCE_TYPE_GBR || resourceType == RESOURCE_TYPE_DELAY_CRITICAL_GBR
+}
+
+//Standardized QCI to QoS characteristics mapping, 3GPP TS 23.203 table 6.1.7-A.
+var standardizedQcis = map[uint32]Characteristics{
+	1:  {RESOURCE_TYPE_GBR, 2, 100 * time.Millisecond, 1e-2, 0},
+	2:  {RESOURCE_TYPE_GBR, 4, 150 * time.Millisecond, 1e-3, 0},
+	3:  {RESOURCE_TYPE_GBR, 3, 50 * time.Millisecond, 1e-3, 0},
+	4:  {RESOURCE_TYPE_GBR, 5, 300 * time.Millisecond, 1e-6, 0},
+	65: {RESOURCE_TYPE_GBR, 0.7, 75 * time.Millisecond, 1e-2, 0},
+	66: {RESOURCE_TYPE_GBR, 2, 100 * time.Millisecond, 1e-2, 0},
+	67: {RESOURCE_TYPE_GBR, 1.5, 100 * time.Millisecond, 1e-3, 0},
+	71: {RESOURCE_TYPE_GBR, 5.6, 150 * time.Millisecond, 1e-6, 0},
+	72: {RESOURCE_TYPE_GBR, 5.6, 300 * time.Millisecond, 1e-4, 0},
+	73: {RESOURCE_TYPE_GBR, 5.6, 300 * time.Millisecond, 1e-8, 0},
+	74: {RESOURCE_TYPE_GBR, 5.6, 500 * time.Millisecond, 1e-8, 0},
+	75: {RESOURCE_TYPE_GBR, 2.5, 50 * time.Millisecond, 1e-2, 0},
+	76: {RESOURCE_TYPE_GBR, 5.6, 500 * time.Millisecond, 1e-4, 0},
+	5:  {RESOURCE_TYPE_NON_GBR, 1, 100 * time.Millisecond, 1e-6, 0},
+	6:  {RESOURCE_TYPE_NON_GBR, 6, 300 * time.Millisecond, 1e-6, 0},
+	7:  {RESOURCE_TYPE_NON_GBR, 7, 100 * time.Millisecond, 1e-3, 0},
+	8:  {RESOURCE_TYPE_NON_GBR, 8, 300 * time.Millisecond, 1e-6, 0},
+	9:  {RESOURCE_TYPE_NON_GBR, 9, 300 * time.Millisecond, 1e-6, 0},
+	69: {RESOURCE_TYPE_NON_GBR, 0.5, 60 * time.Millisecond, 1e-6, 0},
+	70: {RESOURCE_TYPE_NON_GBR, 5.5, 200 * time.Millisecond, 1e-6, 0},
+	79: {RESOURCE_TYPE_NON_GBR, 6.5, 50 * time.Millisecond, 1e-2, 0},
+	80: {RESOURCE_TYPE_NON_GBR, 6.8, 10 * time.Millisecond, 1e-6, 0},
+	82: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 1.9, 10 * time.Millisecond, 1e-4, 255},
+	83: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 2.2, 10 * time.Millisecond, 1e-4, 1358},
+	84: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 2.4, 30 * time.Millisecond, 1e-5, 1354},
+	85: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 2.1, 5 * time.Millisecond, 1e-5, 255},
+}
+
+//Standardized 5QI to QoS characteristics mapping, 3GPP TS 23.501 table 5.7.4-1.
+var standardized5qis = map[uint32]Characteristics{
+	1:  {RESOURCE_TYPE_GBR, 20, 100 * time.Millisecond, 1e-2, 0},
+	2:  {RESOURCE_TYPE_GBR, 40, 150 * time.Millisecond, 1e-3, 0},
+	3:  {RESOURCE_TYPE_GBR, 30, 50 * time.Millisecond, 1e-3, 0},
+	4:  {RESOURCE_TYPE_GBR, 50, 300 * time.Millisecond, 1e-6, 0},
+	65: {RESOURCE_TYPE_GBR, 7, 75 * time.Millisecond, 1e-2, 0},
+	66: {RESOURCE_TYPE_GBR, 20, 100 * time.Millisecond, 1e-2, 0},
+	67: {RESOURCE_TYPE_GBR, 15, 100 * time.Millisecond, 1e-3, 0},
+	71: {RESOURCE_TYPE_GBR, 56, 150 * time.Millisecond, 1e-6, 0},
+	72: {RESOURCE_TYPE_GBR, 56, 300 * time.Millisecond, 1e-4, 0},
+	73: {RESOURCE_TYPE_GBR, 56, 300 * time.Millisecond, 1e-8, 0},
+	74: {RESOURCE_TYPE_GBR, 56, 500 * time.Millisecond, 1e-8, 0},
+	75: {RESOURCE_TYPE_GBR, 25, 50 * time.Millisecond, 1e-2, 0},
+	76: {RESOURCE_TYPE_GBR, 56, 500 * time.Millisecond, 1e-4, 0},
+	5:  {RESOURCE_TYPE_NON_GBR, 10, 100 * time.Millisecond, 1e-6, 0},
+	6:  {RESOURCE_TYPE_NON_GBR, 60, 300 * time.Millisecond, 1e-6, 0},
+	7:  {RESOURCE_TYPE_NON_GBR, 70, 100 * time.Millisecond, 1e-3, 0},
+	8:  {RESOURCE_TYPE_NON_GBR, 80, 300 * time.Millisecond, 1e-6, 0},
+	9:  {RESOURCE_TYPE_NON_GBR, 90, 300 * time.Millisecond, 1e-6, 0},
+	69: {RESOURCE_TYPE_NON_GBR, 5, 60 * time.Millisecond, 1e-6, 0},
+	70: {RESOURCE_TYPE_NON_GBR, 55, 200 * time.Millisecond, 1e-6, 0},
+	79: {RESOURCE_TYPE_NON_GBR, 65, 50 * time.Millisecond, 1e-2, 0},
+	80: {RESOURCE_TYPE_NON_GBR, 68, 10 * time.Millisecond, 1e-6, 0},
+	82: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 19, 10 * time.Millisecond, 1e-4, 255},
+	83: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 22, 10 * time.Millisecond, 1e-4, 1354},
+	84: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 24, 30 * time.Millisecond, 1e-5, 1354},
+	85: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 21, 5 * time.Millisecond, 1e-5, 255},
+	86: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 18, 5 * time.Millisecond, 1e-4, 1354},
+	87: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 25, 5 * time.Millisecond, 1e-3, 500},
+	88: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 25, 10 * time.Millisecond, 1e-3, 1125},
+	89: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 25, 15 * time.Millisecond, 1e-4, 17000},
+	90: {RESOURCE_TYPE_DELAY_CRITICAL_GBR, 25, 20 * time.Millisecond, 1e-4, 63000},
+}
+
+var operatorSpecificMutex sync.RWMutex
+var operatorSpecificQcis = map[uint32]Characteristics{}
+var operatorSpecific5qis = map[uint32]Characteristics{}
+
+//IsOperatorSpecific returns true if a QCI or a 5QI value is in the range reserved for
+//operator-specific use.
+func IsOperatorSpecific(value uint32) bool {
+	return value >= OperatorSpecificMin && value <= OperatorSpecificMax
+}
+
+//LookupQci returns QoS characteristics of a QCI value. The second return value is false, if the
+//QCI is neither standardized nor registered as an operator-specific QCI.
+func LookupQci(qci uint32) (Characteristics, bool) {
+	return lookup(standardizedQcis, operatorSpecificQcis, qci)
+}
+
+//Lookup5qi returns QoS characteristics of a 5QI value. The second return value is false, if the
+//5QI is neither standardized nor registered as an operator-specific 5QI.
+func Lookup5qi(fiveQi uint32) (Characteristics, bool) {
+	return lookup(standardized5qis, operatorSpecific5qis, fiveQi)
+}
+
+//RegisterOperatorSpecificQci sets QoS characteristics for an operator-specific QCI value.
+//An error is returned, if the QCI is not in the operator-specific range.
+func RegisterOperatorSpecificQci(qci uint32, characteristics Characteristics) error {
+	return register(operatorSpecificQcis, "QCI", qci, characteristics)
+}
+
+//RegisterOperatorSpecific5qi sets QoS characteristics for an operator-specific 5QI value.
+//An error is returned, if the 5QI is not in the operator-specific range.
+func RegisterOperatorSpecific5qi(fiveQi uint32, characteristics Characteristics) error {
+	return register(operatorSpecific5qis, "5QI", fiveQi, characteristics)
+}
+
+func lookup(standardized map[uint32]Characteristics, operatorSpecific map[uint32]Characteristics,
+	value uint32) (Characteristics, bool) {
+	if characteristics, ok := standardized[value]; ok {
+		return characteristics, ok
+	}
+	operatorSpecificMutex.RLock()
+	defer operatorSpecificMutex.RUnlock()
+	characteristics, ok := operatorSpecific[value]
+	return characteristics, ok
+}
+
+func register(operatorSpecific map[uint32]Characteristics, name string, value uint32,
+	characteristics Characteristics) error {
+	if !IsOperatorSpecific(value) {
+		return fmt.Errorf("%s %d is not in the operator-specific range %d-%d",
+			name, value, OperatorSpecificMin, OperatorSpecificMax)
+	}
+	operatorSpecificMutex.Lock()
+	defer operatorSpecificMutex.Unlock()
+	operatorSpecific[value] = characteristics
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package qos_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib/qos"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLookupQciSuccessForGbrQci(t *testing.T) {
+	ret, ok := qos.LookupQci(1)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, qos.Characteristics{
+		ResourceType:        qos.RESOURCE_TYPE_GBR,
+		PriorityLevel:       2,
+		PacketDelayBudget:   100 * time.Millisecond,
+		PacketErrorLossRate: 1e-2,
+	}, ret)
+}
+
+func TestLookupQciSuccessForNonGbrQci(t *testing.T) {
+	ret, ok := qos.LookupQci(9)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, qos.RESOURCE_TYPE_NON_GBR, ret.ResourceType)
+	assert.Equal(t, float64(9), ret.PriorityLevel)
+	assert.Equal(t, 300*time.Millisecond, ret.PacketDelayBudget)
+}
+
+func TestLookupQciSuccessForDelayCriticalGbrQci(t *testing.T) {
+	ret, ok := qos.LookupQci(83)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, qos.RESOURCE_TYPE_DELAY_CRITICAL_GBR, ret.ResourceType)
+	assert.Equal(t, uint32(1358), ret.MaxDataBurstVolume)
+}
+
+func TestLookupQciReturnsFalseForUnknownQci(t *testing.T) {
+	ret, ok := qos.LookupQci(10)
+	assert.Equal(t, false, ok)
+	assert.Equal(t, qos.Characteristics{}, ret)
+}
+
+func TestLookup5qiSuccess(t *testing.T) {
+	ret, ok := qos.Lookup5qi(82)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, qos.Characteristics{
+		ResourceType:        qos.RESOURCE_TYPE_DELAY_CRITICAL_GBR,
+		PriorityLevel:       19,
+		PacketDelayBudget:   10 * time.Millisecond,
+		PacketErrorLossRate: 1e-4,
+		MaxDataBurstVolume:  255,
+	}, ret)
+}
+
+func TestLookup5qiReturnsFalseForUnknown5qi(t *testing.T) {
+	_, ok := qos.Lookup5qi(200)
+	assert.Equal(t, false, ok)
+}
+
+func TestRegisterOperatorSpecificQciSuccess(t *testing.T) {
+	characteristics := qos.Characteristics{
+		ResourceType:      qos.RESOURCE_TYPE_NON_GBR,
+		PriorityLevel:     9.5,
+		PacketDelayBudget: 300 * time.Millisecond,
+	}
+	err := qos.RegisterOperatorSpecificQci(128, characteristics)
+	assert.Nil(t, err)
+	ret, ok := qos.LookupQci(128)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, characteristics, ret)
+	_, ok = qos.Lookup5qi(128)
+	assert.Equal(t, false, ok)
+}
+
+func TestRegisterOperatorSpecific5qiSuccess(t *testing.T) {
+	characteristics := qos.Characteristics{ResourceType: qos.RESOURCE_TYPE_GBR}
+	err := qos.RegisterOperatorSpecific5qi(254, characteristics)
+	assert.Nil(t, err)
+	ret, ok := qos.Lookup5qi(254)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, characteristics, ret)
+}
+
+func TestRegisterOperatorSpecificQciReturnsErrorIfQciIsNotOperatorSpecific(t *testing.T) {
+	err := qos.RegisterOperatorSpecificQci(9, qos.Characteristics{})
+	assert.NotNil(t, err)
+	err = qos.RegisterOperatorSpecificQci(255, qos.Characteristics{})
+	assert.NotNil(t, err)
+	ret, _ := qos.LookupQci(9)
+	assert.Equal(t, qos.RESOURCE_TYPE_NON_GBR, ret.ResourceType)
+}
+
+func TestIsOperatorSpecific(t *testing.T) {
+	assert.Equal(t, false, qos.IsOperatorSpecific(127))
+	assert.Equal(t, true, qos.IsOperatorSpecific(128))
+	assert.Equal(t, true, qos.IsOperatorSpecific(254))
+	assert.Equal(t, false, qos.IsOperatorSpecific(255))
+}
+
+func TestResourceTypeIsGBR(t *testing.T) {
+	assert.Equal(t, true, qos.RESOURCE_TYPE_GBR.IsGBR())
+	assert.Equal(t, true, qos.RESOURCE_TYPE_DELAY_CRITICAL_GBR.IsGBR())
+	assert.Equal(t, false, qos.RESOURCE_TYPE_NON_GBR.IsGBR())
+	assert.Equal(t, false, qos.RESOURCE_TYPE_UNKNOWN.IsGBR())
+}
+
+func TestResourceTypeString(t *testing.T) {
+	assert.Equal(t, "GBR", qos.RESOURCE_TYPE_GBR.String())
+	assert.Equal(t, "NON_GBR", qos.RESOURCE_TYPE_NON_GBR.String())
+	assert.Equal(t, "DELAY_CRITICAL_GBR", qos.RESOURCE_TYPE_DELAY_CRITICAL_GBR.String())
+	assert.Equal(t, "UNKNOWN", qos.ResourceType(100).String())
+}
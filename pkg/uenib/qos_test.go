@@ -0,0 +1,41 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenib/qos"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBearerQosHelpersForGbrBearer(t *testing.T) {
+	bearer := uenib.Bearer{Qci: 1}
+	assert.Equal(t, true, bearer.IsGBR())
+	assert.Equal(t, qos.RESOURCE_TYPE_GBR, bearer.ResourceType())
+	assert.Equal(t, 100*time.Millisecond, bearer.PacketDelayBudget())
+	assert.Equal(t, float64(2), bearer.PriorityLevel())
+}
+
+func TestBearerQosHelpersForNonGbrBearer(t *testing.T) {
+	bearer := uenib.Bearer{Qci: 69}
+	assert.Equal(t, false, bearer.IsGBR())
+	assert.Equal(t, qos.RESOURCE_TYPE_NON_GBR, bearer.ResourceType())
+	assert.Equal(t, 60*time.Millisecond, bearer.PacketDelayBudget())
+	assert.Equal(t, 0.5, bearer.PriorityLevel())
+}
+
+func TestBearerQosHelpersForUnknownQci(t *testing.T) {
+	bearer := uenib.Bearer{Qci: 200}
+	_, ok := bearer.QosCharacteristics()
+	assert.Equal(t, false, ok)
+	assert.Equal(t, false, bearer.IsGBR())
+	assert.Equal(t, qos.RESOURCE_TYPE_UNKNOWN, bearer.ResourceType())
+	assert.Equal(t, time.Duration(0), bearer.PacketDelayBudget())
+}
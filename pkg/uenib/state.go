@@ -0,0 +1,247 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//X2MessageType defines X2AP messages of the EN-DC SgNB procedures, which UE-NIB tracks
+//as a UE state.
+type X2MessageType int
+
+const (
+	X2_MSG_UNKNOWN X2MessageType = iota
+	X2_MSG_SGNB_ADDITION_REQUEST
+	X2_MSG_SGNB_ADDITION_REQUEST_ACKNOWLEDGE
+	X2_MSG_SGNB_ADDITION_REQUEST_REJECT
+	X2_MSG_SGNB_RECONFIGURATION_COMPLETE
+	X2_MSG_SGNB_MODIFICATION_REQUEST
+	X2_MSG_SGNB_MODIFICATION_REQUEST_ACKNOWLEDGE
+	X2_MSG_SGNB_MODIFICATION_REQUEST_REJECT
+	X2_MSG_SGNB_MODIFICATION_REQUIRED
+	X2_MSG_SGNB_MODIFICATION_CONFIRM
+	X2_MSG_SGNB_MODIFICATION_REFUSE
+	X2_MSG_SGNB_RELEASE_REQUEST
+	X2_MSG_SGNB_RELEASE_REQUEST_ACKNOWLEDGE
+	X2_MSG_SGNB_RELEASE_REQUEST_REJECT
+	X2_MSG_SGNB_RELEASE_REQUIRED
+	X2_MSG_SGNB_RELEASE_CONFIRM
+	X2_MSG_SGNB_CHANGE_REQUIRED
+	X2_MSG_SGNB_CHANGE_CONFIRM
+	X2_MSG_SGNB_CHANGE_REFUSE
+)
+
+//X2Procedure defines EN-DC SgNB procedures of X2AP.
+type X2Procedure int
+
+const (
+	X2_PROC_UNKNOWN X2Procedure = iota
+	X2_PROC_SGNB_ADDITION
+	X2_PROC_SGNB_MODIFICATION
+	X2_PROC_SGNB_RELEASE
+	X2_PROC_SGNB_CHANGE
+)
+
+//CauseGroup defines the groups of X2AP Cause IE (3GPP TS 36.423 section 9.2.6).
+type CauseGroup int
+
+const (
+	CAUSE_GROUP_UNKNOWN CauseGroup = iota
+	CAUSE_GROUP_RADIO_NETWORK
+	CAUSE_GROUP_TRANSPORT
+	CAUSE_GROUP_PROTOCOL
+	CAUSE_GROUP_MISC
+)
+
+//CauseInfo is a holder for a parsed X2AP Cause IE value.
+//Group is CAUSE_GROUP_UNKNOWN and Value is empty, if the cause text could not be parsed.
+//The original cause text is always preserved in Raw.
+type CauseInfo struct {
+	Group CauseGroup
+	Value string //Cause value within the group, for example "handover-desirable-for-radio-reasons".
+	Raw   string
+}
+
+var x2MessageNames = [...]string{
+	"UNKNOWN",
+	"SgNBAdditionRequest",
+	"SgNBAdditionRequestAcknowledge",
+	"SgNBAdditionRequestReject",
+	"SgNBReconfigurationComplete",
+	"SgNBModificationRequest",
+	"SgNBModificationRequestAcknowledge",
+	"SgNBModificationRequestReject",
+	"SgNBModificationRequired",
+	"SgNBModificationConfirm",
+	"SgNBModificationRefuse",
+	"SgNBReleaseRequest",
+	"SgNBReleaseRequestAcknowledge",
+	"SgNBReleaseRequestReject",
+	"SgNBReleaseRequired",
+	"SgNBReleaseConfirm",
+	"SgNBChangeRequired",
+	"SgNBChangeConfirm",
+	"SgNBChangeRefuse",
+}
+
+var xerCauseRegexp = regexp.MustCompile(`^<\s*([A-Za-z-]+)\s*>\s*<\s*([A-Za-z0-9-]+)\s*/>\s*</\s*[A-Za-z-]+\s*>$`)
+
+//String returns X2 message type as the X2AP message name, for example "SgNBAdditionRequest".
+func (msg X2MessageType) String() string {
+	if msg < X2_MSG_UNKNOWN || msg > X2_MSG_SGNB_CHANGE_REFUSE {
+		return x2MessageNames[X2_MSG_UNKNOWN]
+	}
+	return x2MessageNames[msg]
+}
+
+//Procedure returns the SgNB procedure the X2 message belongs to.
+func (msg X2MessageType) Procedure() X2Procedure {
+	switch {
+	case msg >= X2_MSG_SGNB_ADDITION_REQUEST && msg <= X2_MSG_SGNB_RECONFIGURATION_COMPLETE:
+		return X2_PROC_SGNB_ADDITION
+	case msg >= X2_MSG_SGNB_MODIFICATION_REQUEST && msg <= X2_MSG_SGNB_MODIFICATION_REFUSE:
+		return X2_PROC_SGNB_MODIFICATION
+	case msg >= X2_MSG_SGNB_RELEASE_REQUEST && msg <= X2_MSG_SGNB_RELEASE_CONFIRM:
+		return X2_PROC_SGNB_RELEASE
+	case msg >= X2_MSG_SGNB_CHANGE_REQUIRED && msg <= X2_MSG_SGNB_CHANGE_REFUSE:
+		return X2_PROC_SGNB_CHANGE
+	}
+	return X2_PROC_UNKNOWN
+}
+
+//ParseX2MessageType converts an X2AP message name to X2MessageType. Matching ignores case,
+//underscores, hyphens and spaces, hence both "SgNBAdditionRequest" and "SGNB_ADDITION_REQUEST"
+//are accepted. X2_MSG_UNKNOWN is returned for unknown message names.
+func ParseX2MessageType(str string) X2MessageType {
+	normalized := normalizeName(str)
+	for msg := X2_MSG_SGNB_ADDITION_REQUEST; msg <= X2_MSG_SGNB_CHANGE_REFUSE; msg++ {
+		if normalizeName(x2MessageNames[msg]) == normalized {
+			return msg
+		}
+	}
+	return X2_MSG_UNKNOWN
+}
+
+//String returns SgNB procedure as a string.
+func (proc X2Procedure) String() string {
+	procs := [...]string{"UNKNOWN", "SGNB_ADDITION", "SGNB_MODIFICATION", "SGNB_RELEASE", "SGNB_CHANGE"}
+	if proc < X2_PROC_UNKNOWN || proc > X2_PROC_SGNB_CHANGE {
+		return procs[X2_PROC_UNKNOWN]
+	}
+	return procs[proc]
+}
+
+//String returns cause group as the X2AP Cause IE choice name, for example "radioNetwork".
+func (group CauseGroup) String() string {
+	groups := [...]string{"unknown", "radioNetwork", "transport", "protocol", "misc"}
+	if group < CAUSE_GROUP_UNKNOWN || group > CAUSE_GROUP_MISC {
+		return groups[CAUSE_GROUP_UNKNOWN]
+	}
+	return groups[group]
+}
+
+//ParseCause parses an X2AP Cause IE text. Accepted forms are
+//<group><separator><value>, where separator is one of ':', '/', '.', '=' or a space,
+//and an ASN.1 XER form <group><value/></group>. For example "radioNetwork:cell-not-available".
+//Group names are matched ignoring case, underscores and hyphens.
+func ParseCause(str string) CauseInfo {
+	info := CauseInfo{Raw: str}
+	var groupStr, valueStr string
+	trimmed := strings.TrimSpace(str)
+
+	if matches := xerCauseRegexp.FindStringSubmatch(trimmed); matches != nil {
+		groupStr, valueStr = matches[1], matches[2]
+	} else if i := strings.IndexAny(trimmed, ":/.= "); i > 0 {
+		groupStr, valueStr = trimmed[:i], strings.TrimSpace(trimmed[i+1:])
+	} else {
+		return info
+	}
+
+	normalized := normalizeName(groupStr)
+	for group := CAUSE_GROUP_RADIO_NETWORK; group <= CAUSE_GROUP_MISC; group++ {
+		if normalizeName(group.String()) == normalized && valueStr != "" {
+			info.Group = group
+			info.Value = valueStr
+			break
+		}
+	}
+	return info
+}
+
+//Timestamp returns the time when UE-NIB detected UE's last X2 message. Zero time is
+//returned, if Event does not have the form documented in UeState.Event.
+func (state UeState) Timestamp() time.Time {
+	ts, _ := parseUeStateEvent(state.Event)
+	return ts
+}
+
+//Message returns type of UE's last X2 message. X2_MSG_UNKNOWN is returned, if the message
+//in Event could not be parsed. The original message name can be read with MessageName().
+func (state UeState) Message() X2MessageType {
+	_, msgName := parseUeStateEvent(state.Event)
+	return ParseX2MessageType(msgName)
+}
+
+//MessageName returns the X2 message name part of Event as it is written to UE-NIB. The whole
+//Event is returned, if it does not have the form documented in UeState.Event.
+func (state UeState) MessageName() string {
+	_, msgName := parseUeStateEvent(state.Event)
+	return msgName
+}
+
+//CauseInfo returns parsed Cause of UE's last X2 message. Empty group and value are returned,
+//if there is no cause or the cause text could not be parsed.
+func (state UeState) CauseInfo() CauseInfo {
+	return ParseCause(state.Cause)
+}
+
+//parseUeStateEvent splits a UE state event string of the form documented in UeState.Event
+//to a timestamp and a message name. If the event does not have the form, zero time and the
+//event string as such are returned.
+func parseUeStateEvent(event string) (time.Time, string) {
+	fields := strings.Split(event, " ")
+	if len(fields) != 2 || fields[1] == "" {
+		return time.Time{}, event
+	}
+	if ts, ok := parseUnixTimestamp(fields[0]); ok {
+		return ts, fields[1]
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, fields[0]); err == nil {
+		return ts, fields[1]
+	}
+	return time.Time{}, event
+}
+
+//parseUnixTimestamp parses Unix time given as seconds with an optional fraction of up to
+//nine digits.
+func parseUnixTimestamp(str string) (time.Time, bool) {
+	secs, frac := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		secs, frac = str[:i], str[i+1:]
+		if !isDigits(frac, 1, 9) {
+			return time.Time{}, false
+		}
+	}
+	if !isDigits(secs, 1, 19) {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	nsec, _ := strconv.ParseInt((frac + "000000000")[:9], 10, 64)
+	return time.Unix(sec, nsec).UTC(), true
+}
+
+func normalizeName(str string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(str))
+}
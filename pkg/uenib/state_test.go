@@ -0,0 +1,114 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestUeStateTimestampAndMessage(t *testing.T) {
+	expTs := time.Date(2020, 5, 12, 10, 11, 12, 123456000, time.UTC)
+	testCases := []struct {
+		event string
+		ts    time.Time
+		msg   uenib.X2MessageType
+		name  string
+	}{
+		{"2020-05-12T10:11:12.123456Z SgNBAdditionRequest", expTs,
+			uenib.X2_MSG_SGNB_ADDITION_REQUEST, "SgNBAdditionRequest"},
+		{"1589278272.123456 SGNB_MODIFICATION_REQUIRED", expTs,
+			uenib.X2_MSG_SGNB_MODIFICATION_REQUIRED, "SGNB_MODIFICATION_REQUIRED"},
+		{"1589278272 SgNBReconfigurationComplete", time.Date(2020, 5, 12, 10, 11, 12, 0, time.UTC),
+			uenib.X2_MSG_SGNB_RECONFIGURATION_COMPLETE, "SgNBReconfigurationComplete"},
+	}
+	for _, tc := range testCases {
+		state := uenib.UeState{Event: tc.event}
+		assert.Equal(t, true, tc.ts.Equal(state.Timestamp()), tc.event)
+		assert.Equal(t, tc.msg, state.Message(), tc.event)
+		assert.Equal(t, tc.name, state.MessageName(), tc.event)
+	}
+}
+
+func TestUeStateWithUnknownEventFormatIsPreserved(t *testing.T) {
+	state := uenib.UeState{Event: "yesterday SomeNewX2Message"}
+	assert.Equal(t, true, state.Timestamp().IsZero())
+	assert.Equal(t, uenib.X2_MSG_UNKNOWN, state.Message())
+	assert.Equal(t, "yesterday SomeNewX2Message", state.MessageName())
+	assert.Equal(t, "yesterday SomeNewX2Message", state.Event)
+}
+
+func TestUeStateWithEventNotInDocumentedFormatIsPreserved(t *testing.T) {
+	events := []string{"12", "SgNBReleaseConfirm", "1589278272123456;sgnb-release-confirm",
+		"SgNBChangeRequired 1589278272", "1589278272  SgNBChangeRequired", "1589278272.1234567890 SgNBChangeRequired",
+		"2020-05-12 10:11:12 SgNBChangeRequired"}
+	for _, event := range events {
+		state := uenib.UeState{Event: event}
+		assert.Equal(t, true, state.Timestamp().IsZero(), event)
+		assert.Equal(t, event, state.MessageName(), event)
+	}
+	assert.Equal(t, uenib.X2_MSG_SGNB_RELEASE_CONFIRM, uenib.UeState{Event: "SgNBReleaseConfirm"}.Message())
+}
+
+func TestUeStateWithEmptyEvent(t *testing.T) {
+	state := uenib.UeState{}
+	assert.Equal(t, true, state.Timestamp().IsZero())
+	assert.Equal(t, uenib.X2_MSG_UNKNOWN, state.Message())
+	assert.Equal(t, "", state.MessageName())
+}
+
+func TestX2MessageTypeStringAndProcedure(t *testing.T) {
+	assert.Equal(t, "SgNBModificationRefuse", uenib.X2_MSG_SGNB_MODIFICATION_REFUSE.String())
+	assert.Equal(t, "UNKNOWN", uenib.X2MessageType(100).String())
+	assert.Equal(t, uenib.X2_PROC_SGNB_ADDITION, uenib.X2_MSG_SGNB_RECONFIGURATION_COMPLETE.Procedure())
+	assert.Equal(t, uenib.X2_PROC_SGNB_MODIFICATION, uenib.X2_MSG_SGNB_MODIFICATION_CONFIRM.Procedure())
+	assert.Equal(t, uenib.X2_PROC_SGNB_RELEASE, uenib.X2_MSG_SGNB_RELEASE_REQUIRED.Procedure())
+	assert.Equal(t, uenib.X2_PROC_SGNB_CHANGE, uenib.X2_MSG_SGNB_CHANGE_REFUSE.Procedure())
+	assert.Equal(t, uenib.X2_PROC_UNKNOWN, uenib.X2_MSG_UNKNOWN.Procedure())
+	assert.Equal(t, "SGNB_CHANGE", uenib.X2_PROC_SGNB_CHANGE.String())
+}
+
+func TestParseX2MessageTypeRoundTrip(t *testing.T) {
+	for msg := uenib.X2_MSG_SGNB_ADDITION_REQUEST; msg <= uenib.X2_MSG_SGNB_CHANGE_REFUSE; msg++ {
+		assert.Equal(t, msg, uenib.ParseX2MessageType(msg.String()))
+	}
+}
+
+func TestUeStateCauseInfo(t *testing.T) {
+	testCases := []struct {
+		cause string
+		group uenib.CauseGroup
+		value string
+	}{
+		{"radioNetwork:handover-desirable-for-radio-reasons", uenib.CAUSE_GROUP_RADIO_NETWORK,
+			"handover-desirable-for-radio-reasons"},
+		{"RADIO_NETWORK/cell-not-available", uenib.CAUSE_GROUP_RADIO_NETWORK, "cell-not-available"},
+		{"transport=transport-resource-unavailable", uenib.CAUSE_GROUP_TRANSPORT,
+			"transport-resource-unavailable"},
+		{"protocol 2", uenib.CAUSE_GROUP_PROTOCOL, "2"},
+		{"<misc><om-intervention/></misc>", uenib.CAUSE_GROUP_MISC, "om-intervention"},
+	}
+	for _, tc := range testCases {
+		state := uenib.UeState{Cause: tc.cause}
+		assert.Equal(t, uenib.CauseInfo{Group: tc.group, Value: tc.value, Raw: tc.cause}, state.CauseInfo())
+	}
+}
+
+func TestUeStateCauseInfoWithUnknownCauseFormatIsPreserved(t *testing.T) {
+	for _, cause := range []string{"", "somecause", "unknowngroup:value", "misc:"} {
+		state := uenib.UeState{Cause: cause}
+		assert.Equal(t, uenib.CauseInfo{Raw: cause}, state.CauseInfo())
+	}
+}
+
+func TestCauseGroupString(t *testing.T) {
+	assert.Equal(t, "radioNetwork", uenib.CAUSE_GROUP_RADIO_NETWORK.String())
+	assert.Equal(t, "unknown", uenib.CauseGroup(-1).String())
+}
@@ -0,0 +1,74 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+//NewTunnelEndpoint creates a tunnel endpoint from an IP address string and a TEID.
+//The address string can contain an IPv4 dotted decimal ("192.0.2.1"), an IPv6
+//("2001:db8::68") or a dual address where IPv4 and IPv6 addresses are separated by '+'
+//character. An error is returned, if the address string is not valid.
+func NewTunnelEndpoint(addr string, teid uint32) (TunnelEndpoint, error) {
+	if err := validateTransportAddress(addr); err != nil {
+		return TunnelEndpoint{}, err
+	}
+	return TunnelEndpoint{
+		Address: []byte(addr),
+		Teid:    []byte(strconv.FormatUint(uint64(teid), 10)),
+	}, nil
+}
+
+//IsEmpty returns true if the tunnel endpoint has neither an address nor a TEID.
+func (tep TunnelEndpoint) IsEmpty() bool {
+	return len(tep.Address) == 0 && len(tep.Teid) == 0
+}
+
+//AddressString returns the transport layer address of the tunnel endpoint as an IPv4,
+//an IPv6 or a dual address string, see NewTunnelEndpoint(). An error is returned, if the
+//address is not a valid address string.
+func (tep TunnelEndpoint) AddressString() (string, error) {
+	addr := string(tep.Address)
+	if err := validateTransportAddress(addr); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
+//TeidValue returns the TEID of the tunnel endpoint. An error is returned, if the TEID is
+//not a decimal 32-bit unsigned integer string.
+func (tep TunnelEndpoint) TeidValue() (uint32, error) {
+	teid, err := strconv.ParseUint(string(tep.Teid), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid TEID '%s'", tep.Teid)
+	}
+	return uint32(teid), nil
+}
+
+//Helper function to print TunnelEndpoint.
+func (tep TunnelEndpoint) String() string {
+	return fmt.Sprintf("TunnelEndpoint:[Address:%s,Teid:%s]", tep.Address, tep.Teid)
+}
+
+func validateTransportAddress(addr string) error {
+	if addrs := strings.Split(addr, "+"); len(addrs) == 2 {
+		if net.ParseIP(addrs[0]) == nil || net.ParseIP(addrs[1]) == nil ||
+			strings.Contains(addrs[0], ":") || !strings.Contains(addrs[1], ":") {
+			return fmt.Errorf("invalid dual transport layer address '%s'", addr)
+		}
+		return nil
+	}
+	if net.ParseIP(addr) == nil {
+		return fmt.Errorf("invalid transport layer address '%s'", addr)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewTunnelEndpointSuccess(t *testing.T) {
+	tests := []struct {
+		addr    string
+		teid    uint32
+		expTeid string
+	}{
+		{"10.20.30.40", 1999, "1999"},
+		{"2001:db8::68", 1, "1"},
+		{"10.20.30.40+2001:db8::68", 0xffffffff, "4294967295"},
+	}
+	for _, test := range tests {
+		tep, err := uenib.NewTunnelEndpoint(test.addr, test.teid)
+		assert.Nil(t, err)
+		assert.Equal(t, []byte(test.addr), tep.Address)
+		assert.Equal(t, []byte(test.expTeid), tep.Teid)
+
+		addr, err := tep.AddressString()
+		assert.Nil(t, err)
+		assert.Equal(t, test.addr, addr)
+		teid, err := tep.TeidValue()
+		assert.Nil(t, err)
+		assert.Equal(t, test.teid, teid)
+	}
+}
+
+func TestNewTunnelEndpointFailure(t *testing.T) {
+	for _, addr := range []string{"", "10.20.30", "2001:db8::68+10.20.30.40", "10.20.30.40+10.20.30.41", "a+b+c"} {
+		_, err := uenib.NewTunnelEndpoint(addr, 1)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestTunnelEndpointStoredByWriter(t *testing.T) {
+	tep := uenib.TunnelEndpoint{Address: []byte("10.20.30.40"), Teid: []byte("1999")}
+	addr, err := tep.AddressString()
+	assert.Nil(t, err)
+	assert.Equal(t, "10.20.30.40", addr)
+	teid, err := tep.TeidValue()
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1999), teid)
+}
+
+func TestTunnelEndpointReturnsErrorIfInvalidFormat(t *testing.T) {
+	tep := uenib.TunnelEndpoint{Address: []byte{10, 20, 30, 40}, Teid: []byte{0, 0, 0x07, 0xcf}}
+	_, err := tep.AddressString()
+	assert.EqualError(t, err, "invalid transport layer address '\n\x14\x1e('")
+	_, err = tep.TeidValue()
+	assert.NotNil(t, err)
+
+	tep = uenib.TunnelEndpoint{Address: []byte("10.20.30.40"), Teid: []byte("4294967296")}
+	_, err = tep.TeidValue()
+	assert.EqualError(t, err, "invalid TEID '4294967296'")
+}
+
+func TestTunnelEndpointString(t *testing.T) {
+	tep, err := uenib.NewTunnelEndpoint("10.20.30.40", 1999)
+	assert.Nil(t, err)
+	assert.Equal(t, "TunnelEndpoint:[Address:10.20.30.40,Teid:1999]", tep.String())
+	assert.False(t, tep.IsEmpty())
+	assert.True(t, uenib.TunnelEndpoint{}.IsEmpty())
+}
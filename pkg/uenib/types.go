@@ -16,25 +16,43 @@ import (
 //ENb is not in use at the moment, because RIC is unaware of eNBs.
 //GNbUeX2ApID and ENbUeX2ApID are optional but either one or both must be set.
 type UeID struct {
-	GNb         string //Mandatory. Contains GNb RanName form of: <Antenna-Type>:<3 MCC digits>-<3 MNC digits>-<Node ID>
-	ENb         string //Not used at the moment, because RIC is unaware of eNBs.
-	GNbUeX2ApID string //Optional. Either GNbUeX2ApID or ENbUeX2ApID must be set.
-	ENbUeX2ApID string //Optional. Either ENbUeX2ApID or GNbUeX2ApID must be set.
+	GNb         string `json:"gNb"`           //Mandatory. Contains GNb RanName form of: <Antenna-Type>:<3 MCC digits>-<3 MNC digits>-<Node ID>
+	ENb         string `json:"eNb,omitempty"` //Not used at the moment, because RIC is unaware of eNBs.
+	GNbUeX2ApID string `json:"gNbUeX2ApId"`   //Optional. Either GNbUeX2ApID or ENbUeX2ApID must be set.
+	ENbUeX2ApID string `json:"eNbUeX2ApId"`   //Optional. Either ENbUeX2ApID or GNbUeX2ApID must be set.
 }
 
 //ErabID type is a type used to identify a bearer (E-RAB) of an UE.
 type ErabID uint32
 
+//BearerType defines EN-DC bearer option of an E-RAB.
+type BearerType int
+
+const (
+	BEARER_TYPE_UNKNOWN BearerType = iota
+	BEARER_TYPE_MCG
+	BEARER_TYPE_SCG
+	BEARER_TYPE_SPLIT
+)
+
 //Bearer type is a holder for a User equipment (UE) Bearer level information.
+//S1DLGtpTE and X2UGtpTE are optional and they are empty, if the bearer does not have
+//such a tunnel endpoint in SgNB (for example S1DLGtpTE in case of an MCG bearer).
 type Bearer struct {
-	ErabID    ErabID
-	DrbID     uint32
-	ArpPL     uint32
-	Qci       uint32
-	S1ULGtpTE TunnelEndpoint
+	ErabID     ErabID         `json:"erabId"`
+	DrbID      uint32         `json:"drbId"`
+	ArpPL      uint32         `json:"arpPriorityLevel"`
+	Qci        uint32         `json:"qci"`
+	BearerType BearerType     `json:"bearerType"`            //EN-DC bearer option: MCG, SCG or split bearer.
+	S1ULGtpTE  TunnelEndpoint `json:"s1ulGtpTunnelEndpoint"` //S1 uplink GTP tunnel endpoint in S-GW.
+	S1DLGtpTE  TunnelEndpoint `json:"s1dlGtpTunnelEndpoint"` //SgNB's S1 downlink GTP tunnel endpoint.
+	X2UGtpTE   TunnelEndpoint `json:"x2uGtpTunnelEndpoint"`  //SgNB's X2-U GTP tunnel endpoint of a split bearer.
 }
 
-//TunnelEndpoint is a holder for a GTP tunnel endpoint.
+//TunnelEndpoint is a holder for a GTP tunnel endpoint in the text form, which UE-NIB
+//writer stores. Address is a transport layer address string: an IPv4 dotted decimal
+//("192.0.2.1"), an IPv6 ("2001:db8::68") or IPv4 and IPv6 addresses separated by '+'
+//character. Teid is a GTP TEID as a decimal string ("1999").
 type TunnelEndpoint struct {
 	Address []byte
 	Teid    []byte
@@ -42,14 +60,75 @@ type TunnelEndpoint struct {
 
 //Cell type is a holder for a User equipment (UE) Radio resource information.
 type Cell struct {
-	Pci     uint32 //Physical cell ID
-	SsbFreq uint32 //Frequency of the SSB to be used for the serving cell.
+	Pci         uint32           `json:"pci"`                   //Physical cell ID
+	SsbFreq     uint32           `json:"ssbFreq"`               //Frequency of the SSB to be used for the serving cell as an NR-ARFCN.
+	NrCgi       NrCgi            `json:"nrCgi"`                 //NR Cell Global Identifier. Empty if not known.
+	Measurement *CellMeasurement `json:"measurement,omitempty"` //Latest measurement results of the cell. Nil if not known.
+}
+
+//NrCgi is a holder for an NR Cell Global Identifier (NR-CGI).
+type NrCgi struct {
+	Mcc      string //Mobile Country Code, 3 digits.
+	Mnc      string //Mobile Network Code, 2 or 3 digits.
+	NrCellID uint64 //36 bit NR Cell Identity (NCI).
+}
+
+//CellMeasurement is a holder for the latest SS-RSRP, SS-RSRQ and SS-SINR measurement results
+//of a serving cell reported by a UE. Values are reported values defined in 3GPP TS 38.133
+//section 10.1, use helper functions to convert them to dBm and dB.
+type CellMeasurement struct {
+	Rsrp uint32 `json:"rsrp"` //Reported SS-RSRP, 0-127.
+	Rsrq uint32 `json:"rsrq"` //Reported SS-RSRQ, 0-127.
+	Sinr uint32 `json:"sinr"` //Reported SS-SINR, 0-127.
+}
+
+//SCellIndex type is a type used to identify a secondary cell (SCell) of an UE.
+type SCellIndex uint32
+
+//SCell type is a holder for a User equipment (UE) secondary cell (SCell) information.
+type SCell struct {
+	SCellIndex SCellIndex `json:"sCellIndex"`
+	Cell
+}
+
+//ServingCells type is a holder for User equipment (UE) serving cells in secondary node:
+//a Primary Cell in secondary Node (PSCell) and secondary cells (SCells) used in carrier
+//aggregation.
+type ServingCells struct {
+	PsCell Cell    `json:"psCell"`
+	SCells []SCell `json:"sCells"`
 }
 
 //UeState is a holder for a UE state.
+//Event is a string of a timestamp and UE's last X2 message what UE-NIB has detected, separated
+//by a space: <timestamp> <message>. Timestamp is Unix time in seconds with an optional fraction
+//of up to nine digits, for example "1582638562.133", or an RFC 3339 time. Message is the X2AP
+//message name, for example "SgNBAdditionRequest".
+//Use Timestamp(), Message() and CauseInfo() helper functions to get parsed values of the Event
+//and Cause strings.
 type UeState struct {
-	Event string //A string of a timestamp and UE's last X2 message what UE-NIB has detected.
-	Cause string //X2 message's Cause IE value what UE-NIB has lastly detected.
+	Event string `json:"event"` //A string of a timestamp and UE's last X2 message what UE-NIB has detected.
+	Cause string `json:"cause"` //X2 message's Cause IE value what UE-NIB has lastly detected.
+}
+
+//String returns bearer type as a string.
+func (bearerType BearerType) String() string {
+	bearerTypes := [...]string{"UNKNOWN", "MCG", "SCG", "SPLIT"}
+	if bearerType < BEARER_TYPE_UNKNOWN || bearerType > BEARER_TYPE_SPLIT {
+		return bearerTypes[BEARER_TYPE_UNKNOWN]
+	}
+	return bearerTypes[bearerType]
+}
+
+//ParseBearerType converts a bearer type string (for example "SPLIT") to BearerType.
+//An error is returned, if the string is not a known bearer type.
+func ParseBearerType(str string) (BearerType, error) {
+	for bearerType := BEARER_TYPE_MCG; bearerType <= BEARER_TYPE_SPLIT; bearerType++ {
+		if bearerType.String() == str {
+			return bearerType, nil
+		}
+	}
+	return BEARER_TYPE_UNKNOWN, fmt.Errorf("unknown bearer type '%s'", str)
 }
 
 //Helper function to print UeID.
@@ -0,0 +1,48 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+//Ue is a holder for a User equipment (UE) record of UE-NIB.
+//UeID has both GNbUeX2ApID and ENbUeX2ApID set. LastUpdated is the time when the UE-NIB writer
+//updated the UE last time. It is zero, if the writer does not store it.
+type Ue struct {
+	UeID        UeID      `json:"ueId"`
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+//UeSnapshot is a holder for all the data of a UE read consistently at one UE version.
+//Version is zero, if the UE-NIB writer does not store it. State is nil, if UE has no state,
+//and ServingCells is nil, if UE has no PSCell.
+type UeSnapshot struct {
+	Ue           Ue            `json:"ue"`
+	Version      uint64        `json:"version"`
+	State        *UeState      `json:"state,omitempty"`
+	ServingCells *ServingCells `json:"servingCells,omitempty"`
+	Bearers      []Bearer      `json:"bearers"`
+}
+
+//FormatLastUpdated formats a UE last-updated time as the UE-NIB writer stores it: Unix time in
+//milliseconds as a decimal string.
+func FormatLastUpdated(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+}
+
+//ParseLastUpdated parses a UE last-updated time string of the form FormatLastUpdated() returns.
+func ParseLastUpdated(str string) (time.Time, error) {
+	msecs, err := strconv.ParseInt(str, 10, 64)
+	if err != nil || !isDigits(str, 1, 19) {
+		return time.Time{}, fmt.Errorf("invalid last updated time '%s'", str)
+	}
+	return time.Unix(msecs/1000, msecs%1000*int64(time.Millisecond)).UTC(), nil
+}
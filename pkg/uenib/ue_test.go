@@ -0,0 +1,32 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenib_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFormatAndParseLastUpdated(t *testing.T) {
+	ts := time.Date(2020, 5, 12, 10, 11, 12, 123000000, time.UTC)
+	assert.Equal(t, "1589278272123", uenib.FormatLastUpdated(ts))
+	parsed, err := uenib.ParseLastUpdated(uenib.FormatLastUpdated(ts))
+	assert.Nil(t, err)
+	assert.True(t, ts.Equal(parsed))
+}
+
+func TestParseLastUpdatedFailsIfInvalid(t *testing.T) {
+	_, err := uenib.ParseLastUpdated("yesterday")
+	assert.EqualError(t, err, "invalid last updated time 'yesterday'")
+	for _, str := range []string{"1589278272.123", "-1", "+1", ""} {
+		_, err = uenib.ParseLastUpdated(str)
+		assert.NotNil(t, err, str)
+	}
+}
@@ -0,0 +1,248 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package uenibbreaker implements a circuit breaker around the UE-NIB database backend.
+//
+//Backend wraps a uenibreader.DbBackend. After a number of consecutive backend failures the
+//circuit opens and the calls fail fast with ErrCircuitOpen instead of waiting for the database
+//timeouts. After a while the circuit half-opens and lets one trial call through: the circuit
+//closes if the trial succeeds and opens again if it fails.
+//
+//The callbacks can be used to expose the circuit breaker state in the metrics of package
+//uenibmetrics, as in the example below.
+//
+//If a Cache is configured, the successful reads are cached. In the stale allowed mode the reads
+//rejected by the open circuit are served from the cache, if all the read keys are found in it:
+//
+//	db := uenibbreaker.NewBackend(sdl.NewSyncStorage(), uenibbreaker.Config{
+//		Cache:        uenibbreaker.NewMemoryCache(),
+//		StaleAllowed: true,
+//		OnStateChange: func(from, to uenibbreaker.State) {
+//			registry.ObserveCircuitBreakerStateChange(from.String(), to.String())
+//		},
+//		OnReject: registry.ObserveCircuitBreakerRejection,
+//	})
+//	reader := uenibreader.NewReaderWithDbBackend(db)
+//
+//Reader returns ErrCircuitOpen as the cause of a database backend error, so it can be tested
+//with errors.Is(err, uenibbreaker.ErrCircuitOpen). Reader does not retry the rejected calls even
+//if a retry policy is set, so the calls fail fast while the circuit is open.
+package uenibbreaker
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"sync"
+	"time"
+)
+
+//ErrCircuitOpen is returned by Backend for a call rejected by the open circuit. It implements
+//uenibreader.FailFastError, so Reader does not retry the rejected calls.
+var ErrCircuitOpen error = circuitOpenError{}
+
+type circuitOpenError struct{}
+
+func (circuitOpenError) Error() string {
+	return "UE-NIB database circuit breaker is open"
+}
+
+//FailFast implements uenibreader.FailFastError interface.
+func (circuitOpenError) FailFast() bool {
+	return true
+}
+
+//State is the state of the circuit breaker.
+type State int
+
+const (
+	//Calls are passed to the database backend.
+	STATE_CLOSED State = iota
+	//Calls are rejected.
+	STATE_OPEN
+	//One trial call is passed to the database backend, other calls are rejected.
+	STATE_HALF_OPEN
+)
+
+//String returns the state as a string.
+func (state State) String() string {
+	stateStrMap := [...]string{
+		"CLOSED",
+		"OPEN",
+		"HALF_OPEN",
+	}
+	if state < STATE_CLOSED || state > STATE_HALF_OPEN {
+		return "UNKNOWN"
+	}
+	return stateStrMap[state]
+}
+
+//Default values of Config.
+const (
+	DefaultFailureThreshold = 5
+	DefaultOpenTimeout      = 5 * time.Second
+)
+
+//Config configures Backend. Zero values of FailureThreshold and OpenTimeout mean the defaults.
+type Config struct {
+	FailureThreshold int           //Number of consecutive failures, which opens the circuit.
+	OpenTimeout      time.Duration //Time after which an open circuit half-opens.
+	Cache            Cache         //Cache of the successful reads, optional.
+	StaleAllowed     bool          //Serve the rejected reads from the cache.
+	//OnStateChange is called, if not nil, when the state of the circuit changes.
+	OnStateChange func(from State, to State)
+	//OnReject is called, if not nil, when a call is rejected. Parameter stale is true, if the
+	//call was served from the cache.
+	OnReject func(stale bool)
+}
+
+//Backend is a uenibreader.DbBackend, which passes the calls to another backend through a
+//circuit breaker.
+//NOTE: Use NewBackend() function to create a Backend instance.
+type Backend struct {
+	db       uenibreader.DbBackend
+	config   Config
+	now      func() time.Time
+	mutex    sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+//NewBackend creates a new Backend, which passes the calls to the given database backend.
+func NewBackend(db uenibreader.DbBackend, config Config) *Backend {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultFailureThreshold
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = DefaultOpenTimeout
+	}
+	return &Backend{db: db, config: config, now: time.Now}
+}
+
+//State returns the current state of the circuit.
+func (b *Backend) State() State {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state
+}
+
+//Get implements uenibreader.DbBackend interface.
+func (b *Backend) Get(ns string, keys []string) (map[string]interface{}, error) {
+	if !b.allow() {
+		if b.config.StaleAllowed && b.config.Cache != nil {
+			if kvMap, ok := b.config.Cache.Get(ns, keys); ok {
+				b.reject(true)
+				return kvMap, nil
+			}
+		}
+		b.reject(false)
+		return nil, ErrCircuitOpen
+	}
+	kvMap, err := b.db.Get(ns, keys)
+	b.done(err)
+	if err == nil && b.config.Cache != nil {
+		b.config.Cache.Put(ns, kvMap)
+	}
+	return kvMap, err
+}
+
+//GetAll implements uenibreader.DbBackend interface.
+func (b *Backend) GetAll(ns string) ([]string, error) {
+	if !b.allow() {
+		if b.config.StaleAllowed && b.config.Cache != nil {
+			if keys, ok := b.config.Cache.GetKeys(ns); ok {
+				b.reject(true)
+				return keys, nil
+			}
+		}
+		b.reject(false)
+		return nil, ErrCircuitOpen
+	}
+	keys, err := b.db.GetAll(ns)
+	b.done(err)
+	if err == nil && b.config.Cache != nil {
+		b.config.Cache.PutKeys(ns, keys)
+	}
+	return keys, err
+}
+
+//SubscribeChannel implements uenibreader.DbBackend interface.
+func (b *Backend) SubscribeChannel(ns string, cb func(string, ...string), channels ...string) error {
+	if !b.allow() {
+		b.reject(false)
+		return ErrCircuitOpen
+	}
+	err := b.db.SubscribeChannel(ns, cb, channels...)
+	b.done(err)
+	return err
+}
+
+//Close implements uenibreader.DbBackend interface. The call is passed to the database backend
+//regardless of the state of the circuit.
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+//allow returns true if a call can be passed to the database backend.
+func (b *Backend) allow() bool {
+	b.mutex.Lock()
+	from := b.state
+	allowed := true
+	switch b.state {
+	case STATE_OPEN:
+		if b.now().Sub(b.openedAt) < b.config.OpenTimeout {
+			allowed = false
+			break
+		}
+		b.state = STATE_HALF_OPEN
+		b.trial = true
+	case STATE_HALF_OPEN:
+		if b.trial {
+			allowed = false
+			break
+		}
+		b.trial = true
+	}
+	to := b.state
+	b.mutex.Unlock()
+	b.stateChanged(from, to)
+	return allowed
+}
+
+//done records the result of a call passed to the database backend.
+func (b *Backend) done(err error) {
+	b.mutex.Lock()
+	from := b.state
+	if err == nil {
+		b.failures = 0
+		b.state = STATE_CLOSED
+	} else {
+		b.failures++
+		if b.state == STATE_HALF_OPEN || b.failures >= b.config.FailureThreshold {
+			b.state = STATE_OPEN
+			b.openedAt = b.now()
+		}
+	}
+	if from == STATE_HALF_OPEN {
+		b.trial = false
+	}
+	to := b.state
+	b.mutex.Unlock()
+	b.stateChanged(from, to)
+}
+
+func (b *Backend) reject(stale bool) {
+	if b.config.OnReject != nil {
+		b.config.OnReject(stale)
+	}
+}
+
+func (b *Backend) stateChanged(from State, to State) {
+	if from != to && b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, to)
+	}
+}
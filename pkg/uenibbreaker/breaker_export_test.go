@@ -0,0 +1,17 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibbreaker
+
+import (
+	"time"
+)
+
+//SetClock replaces the clock of the Backend for unit tests.
+func (b *Backend) SetClock(now func() time.Time) {
+	b.now = now
+}
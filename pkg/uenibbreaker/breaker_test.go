@@ -0,0 +1,184 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibbreaker_test
+
+import (
+	"errors"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibbreaker"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const testNs = "uenib/somegnb"
+
+//flakyDb fails the calls while down is set.
+type flakyDb struct {
+	*uenibmem.Db
+	down  bool
+	calls int
+}
+
+func (db *flakyDb) Get(ns string, keys []string) (map[string]interface{}, error) {
+	db.calls++
+	if db.down {
+		return nil, errors.New("Some DB Backend Error")
+	}
+	return db.Db.Get(ns, keys)
+}
+
+func (db *flakyDb) GetAll(ns string) ([]string, error) {
+	db.calls++
+	if db.down {
+		return nil, errors.New("Some DB Backend Error")
+	}
+	return db.Db.GetAll(ns)
+}
+
+type transition struct {
+	from uenibbreaker.State
+	to   uenibbreaker.State
+}
+
+type testBreaker struct {
+	db          *flakyDb
+	backend     *uenibbreaker.Backend
+	now         time.Time
+	transitions []transition
+	rejections  []bool
+}
+
+func setup(config uenibbreaker.Config) *testBreaker {
+	tb := &testBreaker{db: &flakyDb{Db: uenibmem.NewDb()}, now: time.Unix(1000, 0)}
+	tb.db.Set(testNs, "1,UE_PSCELL_PCI", "10", "1,UE_PSCELL_FREQ", "632628")
+	config.FailureThreshold = 2
+	config.OpenTimeout = time.Second
+	config.OnStateChange = func(from, to uenibbreaker.State) {
+		tb.transitions = append(tb.transitions, transition{from, to})
+	}
+	config.OnReject = func(stale bool) {
+		tb.rejections = append(tb.rejections, stale)
+	}
+	tb.backend = uenibbreaker.NewBackend(tb.db, config)
+	tb.backend.SetClock(func() time.Time { return tb.now })
+	return tb
+}
+
+func (tb *testBreaker) get() error {
+	_, err := tb.backend.Get(testNs, []string{"1,UE_PSCELL_PCI"})
+	return err
+}
+
+func TestCircuitOpensAfterConsecutiveFailuresAndFailsFast(t *testing.T) {
+	tb := setup(uenibbreaker.Config{})
+	assert.Nil(t, tb.get())
+	tb.db.down = true
+	assert.NotNil(t, tb.get())
+	assert.Equal(t, uenibbreaker.STATE_CLOSED, tb.backend.State())
+	assert.NotNil(t, tb.get())
+	assert.Equal(t, uenibbreaker.STATE_OPEN, tb.backend.State())
+
+	assert.Equal(t, uenibbreaker.ErrCircuitOpen, tb.get())
+	_, err := tb.backend.GetAll(testNs)
+	assert.Equal(t, uenibbreaker.ErrCircuitOpen, err)
+	assert.Equal(t, 3, tb.db.calls)
+	assert.Equal(t, []transition{{uenibbreaker.STATE_CLOSED, uenibbreaker.STATE_OPEN}}, tb.transitions)
+	assert.Equal(t, []bool{false, false}, tb.rejections)
+}
+
+func TestCircuitHalfOpensAndClosesAfterSuccessfulTrial(t *testing.T) {
+	tb := setup(uenibbreaker.Config{})
+	tb.db.down = true
+	tb.get()
+	tb.get()
+	tb.now = tb.now.Add(time.Second)
+	assert.NotNil(t, tb.get())
+	assert.Equal(t, uenibbreaker.STATE_OPEN, tb.backend.State())
+
+	tb.now = tb.now.Add(time.Second)
+	tb.db.down = false
+	assert.Nil(t, tb.get())
+	assert.Equal(t, uenibbreaker.STATE_CLOSED, tb.backend.State())
+	assert.Equal(t, []transition{
+		{uenibbreaker.STATE_CLOSED, uenibbreaker.STATE_OPEN},
+		{uenibbreaker.STATE_OPEN, uenibbreaker.STATE_HALF_OPEN},
+		{uenibbreaker.STATE_HALF_OPEN, uenibbreaker.STATE_OPEN},
+		{uenibbreaker.STATE_OPEN, uenibbreaker.STATE_HALF_OPEN},
+		{uenibbreaker.STATE_HALF_OPEN, uenibbreaker.STATE_CLOSED},
+	}, tb.transitions)
+}
+
+func TestCircuitServesStaleDataFromCache(t *testing.T) {
+	tb := setup(uenibbreaker.Config{Cache: uenibbreaker.NewMemoryCache(), StaleAllowed: true})
+	reader := uenibreader.NewReaderWithDbBackend(tb.backend)
+	ueID := &uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"}
+	_, err := reader.GetPsCell(ueID)
+	assert.Nil(t, err)
+	tb.db.down = true
+	tb.get()
+	tb.get()
+	assert.Equal(t, uenibbreaker.STATE_OPEN, tb.backend.State())
+
+	cell, err := reader.GetPsCell(ueID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(10), cell.Pci)
+	_, err = reader.GetBearers(ueID)
+	assert.True(t, uenibreader.IsBackendError(err))
+	assert.True(t, errors.Is(err, uenibbreaker.ErrCircuitOpen))
+	assert.Equal(t, []bool{true, false}, tb.rejections)
+}
+
+func TestReaderDoesNotRetryCallsRejectedByOpenCircuit(t *testing.T) {
+	tb := setup(uenibbreaker.Config{})
+	reader := uenibreader.NewReaderWithDbBackend(tb.backend)
+	var retries int
+	policy := uenibreader.DefaultRetryPolicy()
+	policy.OnRetry = func(method string, attempt int, err error) { retries++ }
+	reader.SetRetryPolicy(policy)
+	tb.db.down = true
+	ueID := &uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"}
+	//The second attempt opens the circuit and the third one is rejected without retrying.
+	_, err := reader.GetPsCell(ueID)
+	assert.True(t, errors.Is(err, uenibbreaker.ErrCircuitOpen))
+	assert.Equal(t, uenibbreaker.STATE_OPEN, tb.backend.State())
+	assert.Equal(t, 3, uenibreader.Attempts(err))
+	assert.Equal(t, 2, retries)
+
+	calls := tb.db.calls
+	_, err = reader.GetPsCell(ueID)
+	assert.True(t, errors.Is(err, uenibbreaker.ErrCircuitOpen))
+	assert.Equal(t, 1, uenibreader.Attempts(err))
+	assert.Equal(t, 2, retries)
+	assert.Equal(t, calls, tb.db.calls)
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := uenibbreaker.NewMemoryCache()
+	cache.Put(testNs, map[string]interface{}{"a": "1", "b": nil})
+	kvMap, ok := cache.Get(testNs, []string{"a", "b"})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"a": "1", "b": nil}, kvMap)
+	_, ok = cache.Get(testNs, []string{"a", "c"})
+	assert.False(t, ok)
+
+	_, ok = cache.GetKeys(testNs)
+	assert.False(t, ok)
+	cache.PutKeys(testNs, []string{"a"})
+	keys, ok := cache.GetKeys(testNs)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a"}, keys)
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "CLOSED", uenibbreaker.STATE_CLOSED.String())
+	assert.Equal(t, "HALF_OPEN", uenibbreaker.STATE_HALF_OPEN.String())
+	assert.Equal(t, "UNKNOWN", uenibbreaker.State(5).String())
+}
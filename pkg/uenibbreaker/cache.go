@@ -0,0 +1,86 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibbreaker
+
+import (
+	"sync"
+)
+
+//Cache keeps the results of the successful database reads for serving them when the database
+//backend is unavailable. The cached values may be stale.
+type Cache interface {
+	//Put caches the values read from the namespace. A nil value means that the key was not found.
+	Put(ns string, kvMap map[string]interface{})
+	//Get returns the cached values of the keys. It returns false, if any of the keys is not cached.
+	Get(ns string, keys []string) (map[string]interface{}, bool)
+	//PutKeys caches the keys of the namespace.
+	PutKeys(ns string, keys []string)
+	//GetKeys returns the cached keys of the namespace. It returns false, if they are not cached.
+	GetKeys(ns string) ([]string, bool)
+}
+
+//MemoryCache is an in-memory Cache. It keeps the last read value of every key, so its size
+//grows with the number of keys read.
+//NOTE: Use NewMemoryCache() function to create a MemoryCache instance.
+type MemoryCache struct {
+	mutex  sync.Mutex
+	values map[string]map[string]interface{}
+	keys   map[string][]string
+}
+
+//NewMemoryCache creates a new empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		values: make(map[string]map[string]interface{}),
+		keys:   make(map[string][]string),
+	}
+}
+
+//Put implements Cache interface.
+func (c *MemoryCache) Put(ns string, kvMap map[string]interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	values := c.values[ns]
+	if values == nil {
+		values = make(map[string]interface{}, len(kvMap))
+		c.values[ns] = values
+	}
+	for key, val := range kvMap {
+		values[key] = val
+	}
+}
+
+//Get implements Cache interface.
+func (c *MemoryCache) Get(ns string, keys []string) (map[string]interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	kvMap := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		val, ok := c.values[ns][key]
+		if !ok {
+			return nil, false
+		}
+		kvMap[key] = val
+	}
+	return kvMap, true
+}
+
+//PutKeys implements Cache interface.
+func (c *MemoryCache) PutKeys(ns string, keys []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.keys[ns] = append([]string(nil), keys...)
+}
+
+//GetKeys implements Cache interface.
+func (c *MemoryCache) GetKeys(ns string) ([]string, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	keys, ok := c.keys[ns]
+	return append([]string(nil), keys...), ok
+}
@@ -0,0 +1,522 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package uenibcheck implements a consistency checker of a gNB's UE-NIB namespace.
+//
+//Checker verifies the invariants of the UE-NIB key schema:
+//   - Every E-RAB listed in UE's E-RAB IDs has the DRB ID, QoS and S1-U uplink tunnel endpoint keys.
+//   - Every SCell listed in UE's SCell indexes has the PCI and SSB frequency keys.
+//   - Every state history entry listed in UE's state history sequence numbers has the event key.
+//   - PSCell PCI and SSB frequency keys exist as a pair, and cell measurement keys as a triple.
+//   - UE map keys from MeNB UE X2AP ID to SgNB UE X2AP ID and back are mutual inverses.
+//   - There are no E-RAB, SCell or state history keys for IDs not listed in the UE's lists,
+//     and no keys not belonging to the key schema.
+//
+//The violations are reported as findings. A finding has a repair, if it can be fixed without
+//losing valid data: orphaned keys and incomplete E-RABs, cells and state history entries are
+//removed, and a missing UE map key is added as the inverse of the existing one.
+package uenibcheck
+
+import (
+	"fmt"
+	"github.com/nokia/ue-nib-library/internal"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//FindingType is the type of a consistency finding.
+type FindingType int
+
+const (
+	//FINDING_ORPHANED is a key, which is not referred by the key owning it. For example a key of
+	//an E-RAB, which is not listed in UE's E-RAB IDs.
+	FINDING_ORPHANED FindingType = iota + 1
+	//FINDING_DANGLING is a reference without the keys it refers to. For example an E-RAB listed
+	//in UE's E-RAB IDs, which misses some mandatory key, or a UE map key without the inverse key.
+	FINDING_DANGLING
+	//FINDING_MALFORMED is a key, which does not belong to the key schema, or a key having a value
+	//which cannot be parsed.
+	FINDING_MALFORMED
+)
+
+var findingTypeNames = map[FindingType]string{
+	FINDING_ORPHANED:  "ORPHANED",
+	FINDING_DANGLING:  "DANGLING",
+	FINDING_MALFORMED: "MALFORMED",
+}
+
+//String returns finding type as a string.
+func (findingType FindingType) String() string {
+	if name, ok := findingTypeNames[findingType]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+//MarshalJSON encodes the finding type as a JSON string.
+func (findingType FindingType) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(findingType.String())), nil
+}
+
+//Finding is a consistency violation found by the Checker.
+type Finding struct {
+	Type        FindingType `json:"type"`
+	ENbUeX2ApID string      `json:"eNbUeX2ApId,omitempty"` //UE of the keys, if known.
+	Keys        []string    `json:"keys"`                  //Keys the finding concerns.
+	Description string      `json:"description"`
+	Repair      *Repair     `json:"repair,omitempty"` //Nil, if the finding cannot be repaired.
+}
+
+//Repair defines the database modifications needed to repair a finding.
+type Repair struct {
+	Remove []string          `json:"remove,omitempty"`
+	Set    map[string]string `json:"set,omitempty"`
+}
+
+//Backend is the database interface needed by the Checker. SDL SyncStorage and uenibmem Db
+//implement it.
+type Backend interface {
+	Get(ns string, keys []string) (map[string]interface{}, error)
+	GetAll(ns string) ([]string, error)
+	Set(ns string, pairs ...interface{}) error
+	Remove(ns string, keys []string) error
+}
+
+//Checker checks and repairs the consistency of UE-NIB namespaces.
+//NOTE: Use NewChecker() function to create a Checker instance.
+type Checker struct {
+	db Backend
+}
+
+//NewChecker creates a new Checker of the given database.
+func NewChecker(db Backend) *Checker {
+	return &Checker{db: db}
+}
+
+//Check scans a gNB's UE-NIB namespace and returns the findings ordered by UE.
+//The namespace should not be modified during the check, otherwise the changes in progress
+//may be reported as findings.
+func (checker *Checker) Check(gNb string) ([]Finding, error) {
+	if len(gNb) == 0 {
+		return nil, fmt.Errorf("missing GNb")
+	}
+	ns := internal.GetUeNibNs(gNb)
+	keys, err := checker.db.GetAll(ns)
+	if err != nil {
+		return nil, fmt.Errorf("namespace '%s' key read failure: %s", ns, err.Error())
+	}
+	values := make(map[string]string, len(keys))
+	if len(keys) > 0 {
+		kvMap, err := checker.db.Get(ns, keys)
+		if err != nil {
+			return nil, fmt.Errorf("namespace '%s' value read failure: %s", ns, err.Error())
+		}
+		for key, val := range kvMap {
+			switch v := val.(type) {
+			case string:
+				values[key] = v
+			case []byte:
+				values[key] = string(v)
+			}
+		}
+	}
+	return newScan(values).check(), nil
+}
+
+//Repair applies the repairs of the findings to a gNB's UE-NIB namespace and returns the number
+//of the repaired findings. Findings without a repair are skipped. Keys are removed before
+//setting any keys.
+func (checker *Checker) Repair(gNb string, findings []Finding) (int, error) {
+	if len(gNb) == 0 {
+		return 0, fmt.Errorf("missing GNb")
+	}
+	var repaired int
+	removeSet := make(map[string]bool)
+	setMap := make(map[string]string)
+	for _, finding := range findings {
+		if finding.Repair == nil {
+			continue
+		}
+		repaired++
+		for _, key := range finding.Repair.Remove {
+			removeSet[key] = true
+		}
+		for key, val := range finding.Repair.Set {
+			setMap[key] = val
+		}
+	}
+	ns := internal.GetUeNibNs(gNb)
+	if len(removeSet) > 0 {
+		if err := checker.db.Remove(ns, sortedKeys(removeSet)); err != nil {
+			return 0, fmt.Errorf("namespace '%s' remove failure: %s", ns, err.Error())
+		}
+	}
+	if len(setMap) > 0 {
+		var pairs []interface{}
+		for _, key := range sortedStringMapKeys(setMap) {
+			pairs = append(pairs, key, setMap[key])
+		}
+		if err := checker.db.Set(ns, pairs...); err != nil {
+			return 0, fmt.Errorf("namespace '%s' write failure: %s", ns, err.Error())
+		}
+	}
+	return repaired, nil
+}
+
+//scan is the state of a single namespace check.
+type scan struct {
+	values    map[string]string
+	ueKeys    map[string][]string //ENbUeX2ApID to UE's keys
+	gNbMap    map[string]string   //GNbUeX2ApID to its UE map key
+	other     []string
+	explained map[string]bool
+	findings  []Finding
+}
+
+func newScan(values map[string]string) *scan {
+	s := &scan{
+		values:    values,
+		ueKeys:    make(map[string][]string),
+		gNbMap:    make(map[string]string),
+		explained: make(map[string]bool),
+	}
+	for _, key := range sortedStringMapKeys(values) {
+		if gNbUeX2ApID, ok := internal.ParseDbKeyUeMapGNbToENbUeX2ApID(key); ok {
+			s.gNbMap[gNbUeX2ApID] = key
+		} else if eNbUeX2ApID, ok := internal.ParseDbKeyENbUeX2ApID(key); ok {
+			s.ueKeys[eNbUeX2ApID] = append(s.ueKeys[eNbUeX2ApID], key)
+		} else {
+			s.other = append(s.other, key)
+		}
+	}
+	return s
+}
+
+func (s *scan) check() []Finding {
+	ues := make([]string, 0, len(s.ueKeys))
+	for eNbUeX2ApID := range s.ueKeys {
+		ues = append(ues, eNbUeX2ApID)
+	}
+	sort.Slice(ues, func(i, j int) bool { return internal.LessX2ApID(ues[i], ues[j]) })
+	for _, eNbUeX2ApID := range ues {
+		s.checkUe(&uenib.UeID{ENbUeX2ApID: eNbUeX2ApID})
+	}
+
+	gNbUeX2ApIDs := make([]string, 0, len(s.gNbMap))
+	for gNbUeX2ApID := range s.gNbMap {
+		gNbUeX2ApIDs = append(gNbUeX2ApIDs, gNbUeX2ApID)
+	}
+	sort.Slice(gNbUeX2ApIDs, func(i, j int) bool { return internal.LessX2ApID(gNbUeX2ApIDs[i], gNbUeX2ApIDs[j]) })
+	for _, gNbUeX2ApID := range gNbUeX2ApIDs {
+		s.checkGNbUeMap(gNbUeX2ApID)
+	}
+
+	for _, key := range s.other {
+		s.add(Finding{Type: FINDING_MALFORMED, Keys: []string{key},
+			Description: "key does not belong to any UE"})
+	}
+	return s.findings
+}
+
+func (s *scan) checkUe(id *uenib.UeID) {
+	s.explain(internal.DbKeyUeStateEvent(id), internal.DbKeyUeStateCause(id), internal.DbKeyUeLastUpdated(id),
+		internal.DbKeyUeVersion(id))
+	s.checkENbUeMap(id)
+	s.checkPsCell(id)
+
+	erabsChecked, _ := s.checkList(id, internal.DbKeyUeErabIDs(id), "E-RAB",
+		func(n uint64) []string { return internal.GetErabAllDbKeys(id, uenib.ErabID(n)) },
+		func(n uint64) []string { return mandatoryErabKeys(id, uenib.ErabID(n)) })
+
+	sCellsChecked, sCellIndexes := s.checkList(id, internal.DbKeyUeSCellIndexes(id), "SCell",
+		func(n uint64) []string { return internal.GetSCellAllDbKeys(id, uenib.SCellIndex(n)) },
+		func(n uint64) []string {
+			return []string{internal.DbKeySCellPci(id, uenib.SCellIndex(n)), internal.DbKeySCellSsbFreq(id, uenib.SCellIndex(n))}
+		})
+	for _, n := range sCellIndexes {
+		sCellIndex := uenib.SCellIndex(n)
+		s.checkMeasurement(id, fmt.Sprintf("SCell %d", sCellIndex), internal.DbKeySCellRsrp(id, sCellIndex),
+			internal.DbKeySCellRsrq(id, sCellIndex), internal.DbKeySCellSinr(id, sCellIndex))
+	}
+
+	historyChecked, _ := s.checkList(id, internal.DbKeyUeStateHistorySeqs(id), "state history entry",
+		func(n uint64) []string { return stateHistoryKeys(id, n) },
+		func(n uint64) []string { return []string{internal.DbKeyUeStateHistoryEvent(id, n)} })
+
+	s.checkUnexplainedUeKeys(id, erabsChecked, sCellsChecked, historyChecked)
+}
+
+//checkENbUeMap checks that UE's map key to GNbUeX2ApID has the inverse key.
+func (s *scan) checkENbUeMap(id *uenib.UeID) {
+	key := internal.DbKeyUeMapENbToGNbUeX2ApID(id)
+	s.explain(key)
+	gNbUeX2ApID, ok := s.values[key]
+	if !ok {
+		return
+	}
+	if !isX2ApID(gNbUeX2ApID) {
+		s.add(Finding{Type: FINDING_MALFORMED, ENbUeX2ApID: id.ENbUeX2ApID, Keys: []string{key},
+			Description: fmt.Sprintf("UE map value '%s' is not a GNbUeX2ApID", gNbUeX2ApID),
+			Repair:      &Repair{Remove: []string{key}}})
+		return
+	}
+	inverseKey := internal.DbKeyUeMapGNbToENbUeX2ApID(&uenib.UeID{GNbUeX2ApID: gNbUeX2ApID})
+	eNbUeX2ApID, ok := s.values[inverseKey]
+	if !ok {
+		s.add(Finding{Type: FINDING_DANGLING, ENbUeX2ApID: id.ENbUeX2ApID, Keys: []string{key},
+			Description: fmt.Sprintf("UE map to GNbUeX2ApID %s has no inverse", gNbUeX2ApID),
+			Repair:      &Repair{Set: map[string]string{inverseKey: id.ENbUeX2ApID}}})
+	} else if eNbUeX2ApID != id.ENbUeX2ApID {
+		s.add(Finding{Type: FINDING_DANGLING, ENbUeX2ApID: id.ENbUeX2ApID, Keys: []string{key, inverseKey},
+			Description: fmt.Sprintf("UE map to GNbUeX2ApID %s maps back to ENbUeX2ApID %s", gNbUeX2ApID, eNbUeX2ApID)})
+	}
+}
+
+//checkGNbUeMap checks that a map key from GNbUeX2ApID to ENbUeX2ApID has the inverse key.
+//Mismatching inverse keys are reported by checkENbUeMap().
+func (s *scan) checkGNbUeMap(gNbUeX2ApID string) {
+	key := s.gNbMap[gNbUeX2ApID]
+	eNbUeX2ApID := s.values[key]
+	if !isX2ApID(eNbUeX2ApID) {
+		s.add(Finding{Type: FINDING_MALFORMED, Keys: []string{key},
+			Description: fmt.Sprintf("UE map value '%s' is not an ENbUeX2ApID", eNbUeX2ApID),
+			Repair:      &Repair{Remove: []string{key}}})
+		return
+	}
+	inverseKey := internal.DbKeyUeMapENbToGNbUeX2ApID(&uenib.UeID{ENbUeX2ApID: eNbUeX2ApID})
+	if val, ok := s.values[inverseKey]; !ok {
+		s.add(Finding{Type: FINDING_DANGLING, ENbUeX2ApID: eNbUeX2ApID, Keys: []string{key},
+			Description: fmt.Sprintf("UE map from GNbUeX2ApID %s has no inverse", gNbUeX2ApID),
+			Repair:      &Repair{Set: map[string]string{inverseKey: gNbUeX2ApID}}})
+	} else if val != gNbUeX2ApID && isX2ApID(val) {
+		s.add(Finding{Type: FINDING_DANGLING, ENbUeX2ApID: eNbUeX2ApID, Keys: []string{key, inverseKey},
+			Description: fmt.Sprintf("UE map from GNbUeX2ApID %s maps back to GNbUeX2ApID %s", gNbUeX2ApID, val)})
+	}
+}
+
+//checkPsCell checks that PSCell's PCI and SSB frequency exist as a pair, and the other PSCell
+//keys exist only with them.
+func (s *scan) checkPsCell(id *uenib.UeID) {
+	allKeys := internal.GetPsCellAllDbKeys(id)
+	s.explain(allKeys...)
+	present := s.present(allKeys)
+	if len(present) == 0 {
+		return
+	}
+	missing := s.missing([]string{internal.DbKeyPsCellPci(id), internal.DbKeyPsCellSsbFreq(id)})
+	switch len(missing) {
+	case 0:
+		s.checkMeasurement(id, "PSCell", internal.DbKeyPsCellRsrp(id), internal.DbKeyPsCellRsrq(id),
+			internal.DbKeyPsCellSinr(id))
+	case 1:
+		s.add(Finding{Type: FINDING_DANGLING, ENbUeX2ApID: id.ENbUeX2ApID, Keys: missing,
+			Description: "PSCell misses mandatory keys", Repair: &Repair{Remove: present}})
+	default:
+		s.add(Finding{Type: FINDING_ORPHANED, ENbUeX2ApID: id.ENbUeX2ApID, Keys: present,
+			Description: "PSCell keys without PCI and SSB frequency", Repair: &Repair{Remove: present}})
+	}
+}
+
+//checkMeasurement checks that cell's measurement keys exist as a triple. The Reader ignores
+//an incomplete measurement.
+func (s *scan) checkMeasurement(id *uenib.UeID, cell string, rsrpKey string, rsrqKey string, sinrKey string) {
+	keys := []string{rsrpKey, rsrqKey, sinrKey}
+	if missing := s.missing(keys); len(missing) > 0 && len(missing) < len(keys) {
+		s.add(Finding{Type: FINDING_DANGLING, ENbUeX2ApID: id.ENbUeX2ApID, Keys: missing,
+			Description: cell + " measurement is incomplete", Repair: &Repair{Remove: s.present(keys)}})
+	}
+}
+
+//checkList checks a list key of UE's E-RABs, SCells or state history entries, and that each
+//listed item has its mandatory keys. It returns false, if the list is malformed and the item
+//keys cannot be checked, and the listed items having all the mandatory keys.
+func (s *scan) checkList(id *uenib.UeID, listKey string, itemName string,
+	allKeys func(uint64) []string, mandatoryKeys func(uint64) []string) (bool, []uint64) {
+	s.explain(listKey)
+	str, ok := s.values[listKey]
+	if !ok || len(str) == 0 {
+		return true, nil
+	}
+	items, err := parseIDList(str)
+	if err != nil {
+		s.add(Finding{Type: FINDING_MALFORMED, ENbUeX2ApID: id.ENbUeX2ApID, Keys: []string{listKey},
+			Description: fmt.Sprintf("%s list '%s' cannot be parsed", itemName, str)})
+		return false, nil
+	}
+
+	var complete []uint64
+	var incomplete []Finding
+	for _, item := range items {
+		keys := allKeys(item)
+		s.explain(keys...)
+		if missing := s.missing(mandatoryKeys(item)); len(missing) > 0 {
+			incomplete = append(incomplete, Finding{Type: FINDING_DANGLING, ENbUeX2ApID: id.ENbUeX2ApID,
+				Keys: missing, Description: fmt.Sprintf("%s %d misses mandatory keys", itemName, item),
+				Repair: &Repair{Remove: s.present(keys)}})
+		} else {
+			complete = append(complete, item)
+		}
+	}
+	//All repairs of the list set the same list of the complete items.
+	for _, finding := range incomplete {
+		if len(complete) == 0 {
+			finding.Repair.Remove = append(finding.Repair.Remove, listKey)
+		} else {
+			finding.Repair.Set = map[string]string{listKey: formatIDList(complete)}
+		}
+		s.add(finding)
+	}
+	return true, complete
+}
+
+//checkUnexplainedUeKeys reports UE's keys not checked by the other checks. Keys of unlisted
+//E-RABs, SCells and state history entries are orphaned, other keys are not in the key schema.
+func (s *scan) checkUnexplainedUeKeys(id *uenib.UeID, erabsChecked bool, sCellsChecked bool, historyChecked bool) {
+	var orphanedItems []string
+	orphaned := make(map[string][]string)
+	for _, key := range s.ueKeys[id.ENbUeX2ApID] {
+		if s.explained[key] {
+			continue
+		}
+		var item string
+		var checked bool
+		if n, ok := parseItemID(key); ok {
+			if contains(internal.GetErabAllDbKeys(id, uenib.ErabID(n)), key) {
+				item, checked = fmt.Sprintf("E-RAB %d", n), erabsChecked
+			} else if contains(internal.GetSCellAllDbKeys(id, uenib.SCellIndex(n)), key) {
+				item, checked = fmt.Sprintf("SCell %d", n), sCellsChecked
+			} else if contains(stateHistoryKeys(id, n), key) {
+				item, checked = fmt.Sprintf("state history entry %d", n), historyChecked
+			}
+		}
+		if len(item) == 0 {
+			s.add(Finding{Type: FINDING_MALFORMED, ENbUeX2ApID: id.ENbUeX2ApID, Keys: []string{key},
+				Description: "key does not belong to the key schema"})
+			continue
+		}
+		if !checked {
+			continue
+		}
+		if _, ok := orphaned[item]; !ok {
+			orphanedItems = append(orphanedItems, item)
+		}
+		orphaned[item] = append(orphaned[item], key)
+	}
+	for _, item := range orphanedItems {
+		s.add(Finding{Type: FINDING_ORPHANED, ENbUeX2ApID: id.ENbUeX2ApID, Keys: orphaned[item],
+			Description: item + " is not listed", Repair: &Repair{Remove: orphaned[item]}})
+	}
+}
+
+func (s *scan) add(finding Finding) {
+	s.findings = append(s.findings, finding)
+}
+
+func (s *scan) explain(keys ...string) {
+	for _, key := range keys {
+		s.explained[key] = true
+	}
+}
+
+func (s *scan) present(keys []string) []string {
+	var present []string
+	for _, key := range keys {
+		if _, ok := s.values[key]; ok {
+			present = append(present, key)
+		}
+	}
+	return present
+}
+
+func (s *scan) missing(keys []string) []string {
+	var missing []string
+	for _, key := range keys {
+		if _, ok := s.values[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+//mandatoryErabKeys returns the E-RAB keys, which the Reader requires for a bearer.
+func mandatoryErabKeys(id *uenib.UeID, erabID uenib.ErabID) []string {
+	return []string{
+		internal.DbKeyErabDrbID(id, erabID),
+		internal.DbKeyErabS1UlGtpTendpAddr(id, erabID),
+		internal.DbKeyErabS1UlGtpTendpTeid(id, erabID),
+		internal.DbKeyErabQosArpPL(id, erabID),
+		internal.DbKeyErabQosQci(id, erabID),
+	}
+}
+
+func stateHistoryKeys(id *uenib.UeID, seq uint64) []string {
+	return []string{internal.DbKeyUeStateHistoryEvent(id, seq), internal.DbKeyUeStateHistoryCause(id, seq)}
+}
+
+//parseItemID returns the E-RAB ID, SCell index or state history sequence number of a key of form:
+//<ENbUeX2ApID>,<ID>,<name>.
+func parseItemID(key string) (uint64, bool) {
+	fields := strings.Split(key, ",")
+	if len(fields) != 3 {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(fields[1], 10, 32)
+	return n, err == nil
+}
+
+func isX2ApID(str string) bool {
+	return len(str) > 0 && !strings.Contains(str, ",")
+}
+
+func parseIDList(str string) ([]uint64, error) {
+	var ids []uint64
+	for _, field := range strings.Split(str, ",") {
+		id, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func formatIDList(ids []uint64) string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = strconv.FormatUint(id, 10)
+	}
+	return strings.Join(strs, ",")
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStringMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,224 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibcheck_test
+
+import (
+	"errors"
+	"github.com/nokia/ue-nib-library/pkg/uenibcheck"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testNs = "uenib/somegnb"
+
+type failingDb struct {
+	*uenibmem.Db
+}
+
+func (db failingDb) Remove(ns string, keys []string) error {
+	return errors.New("Some DB Backend Error")
+}
+
+//setConsistentUe sets a UE with all kinds of keys, which are consistent.
+func setConsistentUe(db *uenibmem.Db) {
+	db.Set(testNs,
+		"1,UEMAP_GNBUEX2APID", "11",
+		"11,UEMAP_ENBUEX2APID", "1",
+		"1,UE_STATE_EVENT", "SgNBAdditionRequest",
+		"1,UE_STATE_CAUSE", "",
+		"1,UE_STATE_HISTORY_SEQS", "7",
+		"1,7,UE_STATE_HISTORY_EVENT", "SgNBAdditionRequest",
+		"1,UE_PSCELL_PCI", "10",
+		"1,UE_PSCELL_FREQ", "632628",
+		"1,UE_PSCELL_RSRP", "50",
+		"1,UE_PSCELL_RSRQ", "60",
+		"1,UE_PSCELL_SINR", "70",
+		"1,UE_SCELL_INDEXES", "1",
+		"1,1,UE_SCELL_PCI", "20",
+		"1,1,UE_SCELL_FREQ", "632628",
+		"1,UE_ERAB_IDS", "5",
+		"1,5,UE_ERAB_DRB_ID", "1",
+		"1,5,UE_ERAB_S1_UL_GTP_TUNNEL_ADDR", "\x0a\x00\x00\x01",
+		"1,5,UE_ERAB_S1_UL_GTP_TUNNEL_TEID", "\x00\x00\x00\x01",
+		"1,5,UE_ERAB_QOS_ARP_PL", "1",
+		"1,5,UE_ERAB_QOS_QCI", "9",
+		"1,5,UE_ERAB_BEARER_TYPE", "SPLIT")
+}
+
+func check(t *testing.T, db *uenibmem.Db) []uenibcheck.Finding {
+	findings, err := uenibcheck.NewChecker(db).Check("somegnb")
+	assert.Nil(t, err)
+	return findings
+}
+
+func TestCheckConsistentNamespace(t *testing.T) {
+	db := uenibmem.NewDb()
+	setConsistentUe(db)
+	assert.Empty(t, check(t, db))
+	assert.Empty(t, check(t, uenibmem.NewDb()))
+}
+
+func TestCheckReturnsErrorIfNoGNb(t *testing.T) {
+	_, err := uenibcheck.NewChecker(uenibmem.NewDb()).Check("")
+	assert.EqualError(t, err, "missing GNb")
+}
+
+func TestCheckFindsIncompleteErabAndRepairs(t *testing.T) {
+	db := uenibmem.NewDb()
+	setConsistentUe(db)
+	db.Set(testNs, "1,UE_ERAB_IDS", "5,6", "1,6,UE_ERAB_DRB_ID", "2")
+	findings := check(t, db)
+	assert.Equal(t, []uenibcheck.Finding{{
+		Type:        uenibcheck.FINDING_DANGLING,
+		ENbUeX2ApID: "1",
+		Keys: []string{"1,6,UE_ERAB_S1_UL_GTP_TUNNEL_ADDR", "1,6,UE_ERAB_S1_UL_GTP_TUNNEL_TEID",
+			"1,6,UE_ERAB_QOS_ARP_PL", "1,6,UE_ERAB_QOS_QCI"},
+		Description: "E-RAB 6 misses mandatory keys",
+		Repair: &uenibcheck.Repair{
+			Remove: []string{"1,6,UE_ERAB_DRB_ID"},
+			Set:    map[string]string{"1,UE_ERAB_IDS": "5"},
+		},
+	}}, findings)
+
+	repaired, err := uenibcheck.NewChecker(db).Repair("somegnb", findings)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, repaired)
+	assert.Empty(t, check(t, db))
+}
+
+func TestCheckFindsOrphanedKeysAndRepairs(t *testing.T) {
+	db := uenibmem.NewDb()
+	setConsistentUe(db)
+	db.Set(testNs, "1,6,UE_ERAB_DRB_ID", "2", "1,6,UE_ERAB_QOS_QCI", "9", "1,2,UE_SCELL_PCI", "1",
+		"1,8,UE_STATE_HISTORY_CAUSE", "x")
+	db.Remove(testNs, []string{"1,UE_PSCELL_PCI", "1,UE_PSCELL_FREQ"})
+	findings := check(t, db)
+	assert.Equal(t, 4, len(findings))
+	assert.Equal(t, uenibcheck.Finding{
+		Type: uenibcheck.FINDING_ORPHANED, ENbUeX2ApID: "1",
+		Keys:        []string{"1,UE_PSCELL_RSRP", "1,UE_PSCELL_RSRQ", "1,UE_PSCELL_SINR"},
+		Description: "PSCell keys without PCI and SSB frequency",
+		Repair:      &uenibcheck.Repair{Remove: []string{"1,UE_PSCELL_RSRP", "1,UE_PSCELL_RSRQ", "1,UE_PSCELL_SINR"}},
+	}, findings[0])
+	assert.Equal(t, uenibcheck.Finding{
+		Type: uenibcheck.FINDING_ORPHANED, ENbUeX2ApID: "1",
+		Keys:        []string{"1,2,UE_SCELL_PCI"},
+		Description: "SCell 2 is not listed",
+		Repair:      &uenibcheck.Repair{Remove: []string{"1,2,UE_SCELL_PCI"}},
+	}, findings[1])
+	assert.Equal(t, uenibcheck.Finding{
+		Type: uenibcheck.FINDING_ORPHANED, ENbUeX2ApID: "1",
+		Keys:        []string{"1,6,UE_ERAB_DRB_ID", "1,6,UE_ERAB_QOS_QCI"},
+		Description: "E-RAB 6 is not listed",
+		Repair:      &uenibcheck.Repair{Remove: []string{"1,6,UE_ERAB_DRB_ID", "1,6,UE_ERAB_QOS_QCI"}},
+	}, findings[2])
+	assert.Equal(t, "state history entry 8 is not listed", findings[3].Description)
+
+	repaired, err := uenibcheck.NewChecker(db).Repair("somegnb", findings)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, repaired)
+	assert.Empty(t, check(t, db))
+}
+
+func TestCheckFindsIncompletePsCellAndMeasurement(t *testing.T) {
+	db := uenibmem.NewDb()
+	setConsistentUe(db)
+	db.Remove(testNs, []string{"1,UE_PSCELL_FREQ"})
+	db.Set(testNs, "1,1,UE_SCELL_RSRP", "50")
+	findings := check(t, db)
+	assert.Equal(t, 2, len(findings))
+	assert.Equal(t, uenibcheck.Finding{
+		Type: uenibcheck.FINDING_DANGLING, ENbUeX2ApID: "1",
+		Keys:        []string{"1,UE_PSCELL_FREQ"},
+		Description: "PSCell misses mandatory keys",
+		Repair: &uenibcheck.Repair{Remove: []string{"1,UE_PSCELL_PCI", "1,UE_PSCELL_RSRP",
+			"1,UE_PSCELL_RSRQ", "1,UE_PSCELL_SINR"}},
+	}, findings[0])
+	assert.Equal(t, uenibcheck.Finding{
+		Type: uenibcheck.FINDING_DANGLING, ENbUeX2ApID: "1",
+		Keys:        []string{"1,1,UE_SCELL_RSRQ", "1,1,UE_SCELL_SINR"},
+		Description: "SCell 1 measurement is incomplete",
+		Repair:      &uenibcheck.Repair{Remove: []string{"1,1,UE_SCELL_RSRP"}},
+	}, findings[1])
+}
+
+func TestCheckFindsUeMapWithoutInverseAndRepairs(t *testing.T) {
+	db := uenibmem.NewDb()
+	setConsistentUe(db)
+	db.Remove(testNs, []string{"11,UEMAP_ENBUEX2APID"})
+	db.Set(testNs, "22,UEMAP_ENBUEX2APID", "2")
+	findings := check(t, db)
+	assert.Equal(t, []uenibcheck.Finding{{
+		Type: uenibcheck.FINDING_DANGLING, ENbUeX2ApID: "1",
+		Keys:        []string{"1,UEMAP_GNBUEX2APID"},
+		Description: "UE map to GNbUeX2ApID 11 has no inverse",
+		Repair:      &uenibcheck.Repair{Set: map[string]string{"11,UEMAP_ENBUEX2APID": "1"}},
+	}, {
+		Type: uenibcheck.FINDING_DANGLING, ENbUeX2ApID: "2",
+		Keys:        []string{"22,UEMAP_ENBUEX2APID"},
+		Description: "UE map from GNbUeX2ApID 22 has no inverse",
+		Repair:      &uenibcheck.Repair{Set: map[string]string{"2,UEMAP_GNBUEX2APID": "22"}},
+	}}, findings)
+
+	repaired, err := uenibcheck.NewChecker(db).Repair("somegnb", findings)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, repaired)
+	assert.Empty(t, check(t, db))
+}
+
+func TestCheckFindsMismatchingUeMaps(t *testing.T) {
+	db := uenibmem.NewDb()
+	setConsistentUe(db)
+	db.Set(testNs, "11,UEMAP_ENBUEX2APID", "2", "2,UEMAP_GNBUEX2APID", "11")
+	findings := check(t, db)
+	assert.Equal(t, 1, len(findings))
+	assert.Equal(t, uenibcheck.FINDING_DANGLING, findings[0].Type)
+	assert.Equal(t, "UE map to GNbUeX2ApID 11 maps back to ENbUeX2ApID 2", findings[0].Description)
+	assert.Nil(t, findings[0].Repair)
+}
+
+func TestCheckFindsMalformedKeysAndValues(t *testing.T) {
+	db := uenibmem.NewDb()
+	setConsistentUe(db)
+	db.Set(testNs, "1,UE_SCELL_INDEXES", "1,x", "1,UE_FOO", "x", "FOO", "x", "1,9,UE_SCELL_PCI", "1")
+	findings := check(t, db)
+	assert.Equal(t, []uenibcheck.Finding{{
+		Type: uenibcheck.FINDING_MALFORMED, ENbUeX2ApID: "1",
+		Keys:        []string{"1,UE_SCELL_INDEXES"},
+		Description: "SCell list '1,x' cannot be parsed",
+	}, {
+		Type: uenibcheck.FINDING_MALFORMED, ENbUeX2ApID: "1",
+		Keys:        []string{"1,UE_FOO"},
+		Description: "key does not belong to the key schema",
+	}, {
+		Type:        uenibcheck.FINDING_MALFORMED,
+		Keys:        []string{"FOO"},
+		Description: "key does not belong to any UE",
+	}}, findings)
+
+	repaired, err := uenibcheck.NewChecker(db).Repair("somegnb", findings)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, repaired)
+}
+
+func TestRepairReturnsErrorIfDbBackendFailure(t *testing.T) {
+	db := uenibmem.NewDb()
+	setConsistentUe(db)
+	db.Set(testNs, "1,6,UE_ERAB_DRB_ID", "2")
+	findings := check(t, db)
+	_, err := uenibcheck.NewChecker(failingDb{db}).Repair("somegnb", findings)
+	assert.EqualError(t, err, "namespace 'uenib/somegnb' remove failure: Some DB Backend Error")
+}
+
+func TestFindingTypeString(t *testing.T) {
+	assert.Equal(t, "ORPHANED", uenibcheck.FINDING_ORPHANED.String())
+	assert.Equal(t, "DANGLING", uenibcheck.FINDING_DANGLING.String())
+	assert.Equal(t, "MALFORMED", uenibcheck.FINDING_MALFORMED.String())
+	assert.Equal(t, "UNKNOWN", uenibcheck.FindingType(0).String())
+}
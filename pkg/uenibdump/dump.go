@@ -0,0 +1,272 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package uenibdump implements dumping a gNB's UE-NIB namespace to a file and restoring it to
+//a database, for example to attach the UE-NIB data of a lab to a bug report and to reproduce
+//the issue with a local database or with the in-memory database of the uenibmem package.
+//
+//The dump file is a JSON document of form:
+//
+//	{
+//	  "format": "uenib-dump",
+//	  "version": 1,
+//	  "gNb": "<gNb>",
+//	  "created": "<RFC 3339 time>",
+//	  "ues": [
+//	    {"eNbUeX2ApId": "<ENbUeX2ApID>", "entries": [{"key": "<key>", "value": "<value>"}, ...]},
+//	    ...
+//	  ],
+//	  "otherEntries": [{"key": "<key>", "value": "<value>"}, ...]
+//	}
+//
+//UE's entries are all the keys of the UE, including its UE map keys. Keys, which do not belong
+//to any UE, are in otherEntries. Values, which are not valid UTF-8, like transport layer
+//addresses, have an additional "encoding": "base64" field and the value is base64 encoded.
+package uenibdump
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/nokia/ue-nib-library/internal"
+	"io"
+	"io/ioutil"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+//FormatVersion is the version of the dump file format written by this package.
+//Read() fails, if the file has a newer version.
+const FormatVersion = 1
+
+const formatName = "uenib-dump"
+
+const base64Encoding = "base64"
+
+//Dump is the content of a gNB's UE-NIB namespace.
+type Dump struct {
+	Version      int       `json:"version"`
+	GNb          string    `json:"gNb"`
+	Created      time.Time `json:"created"`
+	Ues          []UeDump  `json:"ues"`
+	OtherEntries []Entry   `json:"otherEntries,omitempty"`
+}
+
+//UeDump is the content of UE's keys.
+type UeDump struct {
+	ENbUeX2ApID string  `json:"eNbUeX2ApId"`
+	Entries     []Entry `json:"entries"`
+}
+
+//Entry is a database key and its value.
+type Entry struct {
+	Key   string
+	Value string
+}
+
+//Source is the database interface needed to dump a namespace. SDL SyncStorage and uenibmem Db
+//implement it.
+type Source interface {
+	Get(ns string, keys []string) (map[string]interface{}, error)
+	GetAll(ns string) ([]string, error)
+}
+
+//Target is the database interface needed to restore a namespace. SDL SyncStorage and uenibmem
+//Db implement it.
+type Target interface {
+	Set(ns string, pairs ...interface{}) error
+	RemoveAll(ns string) error
+}
+
+//DumpGNb reads all the keys of a gNB's UE-NIB namespace. UEs and their entries are sorted,
+//so that the dumps of the same data are equal. Keys removed during the dump are skipped.
+func DumpGNb(source Source, gNb string) (*Dump, error) {
+	if len(gNb) == 0 {
+		return nil, fmt.Errorf("missing GNb")
+	}
+	ns := internal.GetUeNibNs(gNb)
+	keys, err := source.GetAll(ns)
+	if err != nil {
+		return nil, fmt.Errorf("namespace '%s' key read failure: %s", ns, err.Error())
+	}
+	dump := &Dump{Version: FormatVersion, GNb: gNb, Created: time.Now().UTC(), Ues: []UeDump{}}
+	if len(keys) == 0 {
+		return dump, nil
+	}
+	kvMap, err := source.Get(ns, keys)
+	if err != nil {
+		return nil, fmt.Errorf("namespace '%s' value read failure: %s", ns, err.Error())
+	}
+
+	ueEntries := make(map[string][]Entry)
+	for _, key := range keys {
+		val, ok, err := toStringValue(kvMap[key])
+		if err != nil {
+			return nil, fmt.Errorf("key '%s' %s", key, err.Error())
+		}
+		if !ok {
+			continue
+		}
+		entry := Entry{Key: key, Value: val}
+		if eNbUeX2ApID, ok := ueOfKey(key, val); ok {
+			ueEntries[eNbUeX2ApID] = append(ueEntries[eNbUeX2ApID], entry)
+		} else {
+			dump.OtherEntries = append(dump.OtherEntries, entry)
+		}
+	}
+	for eNbUeX2ApID, entries := range ueEntries {
+		sortEntries(entries)
+		dump.Ues = append(dump.Ues, UeDump{ENbUeX2ApID: eNbUeX2ApID, Entries: entries})
+	}
+	sort.Slice(dump.Ues, func(i, j int) bool {
+		return internal.LessX2ApID(dump.Ues[i].ENbUeX2ApID, dump.Ues[j].ENbUeX2ApID)
+	})
+	sortEntries(dump.OtherEntries)
+	return dump, nil
+}
+
+//Restore writes the dumped keys to a gNB's UE-NIB namespace. The keys existing in the namespace
+//before the restore are removed, so that the namespace content equals the dump after the restore.
+//Parameter gNb is the gNB the dump is restored to. If it is empty, the dump is restored to the
+//gNB it was taken from.
+func Restore(target Target, dump *Dump, gNb string) error {
+	if len(gNb) == 0 {
+		gNb = dump.GNb
+	}
+	if len(gNb) == 0 {
+		return fmt.Errorf("missing GNb")
+	}
+	var pairs []interface{}
+	for _, entry := range dump.Entries() {
+		if len(entry.Key) == 0 {
+			return fmt.Errorf("dump has an entry without a key")
+		}
+		pairs = append(pairs, entry.Key, entry.Value)
+	}
+	ns := internal.GetUeNibNs(gNb)
+	if err := target.RemoveAll(ns); err != nil {
+		return fmt.Errorf("namespace '%s' remove failure: %s", ns, err.Error())
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+	if err := target.Set(ns, pairs...); err != nil {
+		return fmt.Errorf("namespace '%s' write failure: %s", ns, err.Error())
+	}
+	return nil
+}
+
+//Entries returns all the entries of the dump.
+func (dump *Dump) Entries() []Entry {
+	var entries []Entry
+	for _, ue := range dump.Ues {
+		entries = append(entries, ue.Entries...)
+	}
+	return append(entries, dump.OtherEntries...)
+}
+
+//Write writes the dump as a dump file.
+func (dump *Dump) Write(w io.Writer) error {
+	file := struct {
+		Format string `json:"format"`
+		*Dump
+	}{formatName, dump}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(file)
+}
+
+//Read reads a dump file. An error is returned, if the file is not a dump file or it has a newer
+//format version than FormatVersion.
+func Read(r io.Reader) (*Dump, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var header struct {
+		Format  string `json:"format"`
+		Version int    `json:"version"`
+	}
+	if err = json.Unmarshal(data, &header); err != nil || header.Format != formatName {
+		return nil, fmt.Errorf("not a UE-NIB dump file")
+	}
+	if header.Version < 1 || header.Version > FormatVersion {
+		return nil, fmt.Errorf("unsupported UE-NIB dump file version %d", header.Version)
+	}
+	dump := &Dump{}
+	if err = json.Unmarshal(data, dump); err != nil {
+		return nil, fmt.Errorf("UE-NIB dump file decode failure: %s", err.Error())
+	}
+	return dump, nil
+}
+
+//MarshalJSON encodes the entry as a JSON object. The value is base64 encoded, if it is not
+//valid UTF-8.
+func (entry Entry) MarshalJSON() ([]byte, error) {
+	e := entryJSON{Key: entry.Key, Value: entry.Value}
+	if !utf8.ValidString(entry.Value) {
+		e.Value = base64.StdEncoding.EncodeToString([]byte(entry.Value))
+		e.Encoding = base64Encoding
+	}
+	return json.Marshal(e)
+}
+
+//UnmarshalJSON decodes the entry from a JSON object.
+func (entry *Entry) UnmarshalJSON(data []byte) error {
+	var e entryJSON
+	if err := json.Unmarshal(data, &e); err != nil {
+		return err
+	}
+	switch e.Encoding {
+	case "":
+		*entry = Entry{Key: e.Key, Value: e.Value}
+	case base64Encoding:
+		val, err := base64.StdEncoding.DecodeString(e.Value)
+		if err != nil {
+			return fmt.Errorf("key '%s' value decode failure: %s", e.Key, err.Error())
+		}
+		*entry = Entry{Key: e.Key, Value: string(val)}
+	default:
+		return fmt.Errorf("key '%s' has unknown value encoding '%s'", e.Key, e.Encoding)
+	}
+	return nil
+}
+
+type entryJSON struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+//ueOfKey returns ENbUeX2ApID of the UE the key belongs to. The UE map key from GNbUeX2ApID
+//belongs to the UE identified by its value.
+func ueOfKey(key string, val string) (string, bool) {
+	if _, ok := internal.ParseDbKeyUeMapGNbToENbUeX2ApID(key); ok {
+		return val, len(val) > 0
+	}
+	return internal.ParseDbKeyENbUeX2ApID(key)
+}
+
+//toStringValue converts a database value to a string. False is returned, if the value is nil.
+func toStringValue(val interface{}) (string, bool, error) {
+	switch v := val.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	case []byte:
+		return string(v), true, nil
+	}
+	return "", false, fmt.Errorf("value type %T is not supported", val)
+}
+
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+}
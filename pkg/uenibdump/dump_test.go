@@ -0,0 +1,158 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibdump_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibdump"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type failingDb struct {
+	*uenibmem.Db
+}
+
+func (db failingDb) GetAll(ns string) ([]string, error) {
+	return nil, errors.New("Some DB Backend Error")
+}
+
+func (db failingDb) Set(ns string, pairs ...interface{}) error {
+	return errors.New("Some DB Backend Error")
+}
+
+func newTestDb() *uenibmem.Db {
+	db := uenibmem.NewDb()
+	db.Set("uenib/somegnb",
+		"10,UE_STATE_EVENT", "SgNBAdditionRequest",
+		"10,UEMAP_GNBUEX2APID", "100",
+		"100,UEMAP_ENBUEX2APID", "10",
+		"10,UE_ERAB_IDS", "5",
+		"10,5,UE_ERAB_S1_UL_GTP_TUNNEL_ADDR", "\x0a\x00\x00\xff",
+		"10,5,UE_ERAB_S1_UL_GTP_TUNNEL_TEID", "\x00\x00\x00\x01",
+		"9,UE_STATE_EVENT", "SgNBReleaseRequest",
+		"9,UEMAP_GNBUEX2APID", "99",
+		"SOME_GNB_KEY", "x")
+	db.Set("uenib/othergnb", "1,UE_STATE_EVENT", "SgNBAdditionRequest")
+	return db
+}
+
+func TestDumpGNbGroupsKeysByUe(t *testing.T) {
+	dump, err := uenibdump.DumpGNb(newTestDb(), "somegnb")
+	assert.Nil(t, err)
+	assert.Equal(t, uenibdump.FormatVersion, dump.Version)
+	assert.Equal(t, "somegnb", dump.GNb)
+	assert.False(t, dump.Created.IsZero())
+	assert.Equal(t, []uenibdump.UeDump{
+		{ENbUeX2ApID: "9", Entries: []uenibdump.Entry{
+			{Key: "9,UEMAP_GNBUEX2APID", Value: "99"},
+			{Key: "9,UE_STATE_EVENT", Value: "SgNBReleaseRequest"},
+		}},
+		{ENbUeX2ApID: "10", Entries: []uenibdump.Entry{
+			{Key: "10,5,UE_ERAB_S1_UL_GTP_TUNNEL_ADDR", Value: "\x0a\x00\x00\xff"},
+			{Key: "10,5,UE_ERAB_S1_UL_GTP_TUNNEL_TEID", Value: "\x00\x00\x00\x01"},
+			{Key: "10,UEMAP_GNBUEX2APID", Value: "100"},
+			{Key: "10,UE_ERAB_IDS", Value: "5"},
+			{Key: "10,UE_STATE_EVENT", Value: "SgNBAdditionRequest"},
+			{Key: "100,UEMAP_ENBUEX2APID", Value: "10"},
+		}},
+	}, dump.Ues)
+	assert.Equal(t, []uenibdump.Entry{{Key: "SOME_GNB_KEY", Value: "x"}}, dump.OtherEntries)
+}
+
+func TestDumpGNbReturnsEmptyDumpIfNoKeys(t *testing.T) {
+	dump, err := uenibdump.DumpGNb(uenibmem.NewDb(), "somegnb")
+	assert.Nil(t, err)
+	assert.Empty(t, dump.Ues)
+	assert.Empty(t, dump.Entries())
+}
+
+func TestDumpGNbReturnsErrorIfNoGNb(t *testing.T) {
+	_, err := uenibdump.DumpGNb(newTestDb(), "")
+	assert.EqualError(t, err, "missing GNb")
+}
+
+func TestDumpGNbReturnsErrorIfDbBackendFailure(t *testing.T) {
+	_, err := uenibdump.DumpGNb(failingDb{uenibmem.NewDb()}, "somegnb")
+	assert.EqualError(t, err, "namespace 'uenib/somegnb' key read failure: Some DB Backend Error")
+}
+
+func TestWriteAndReadRoundTrip(t *testing.T) {
+	dump, _ := uenibdump.DumpGNb(newTestDb(), "somegnb")
+	buf := &bytes.Buffer{}
+	err := dump.Write(buf)
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), `"format": "uenib-dump"`)
+	assert.Contains(t, buf.String(), `"value": "CgAA/w==",`)
+	assert.Contains(t, buf.String(), `"encoding": "base64"`)
+	readDump, err := uenibdump.Read(buf)
+	assert.Nil(t, err)
+	assert.True(t, dump.Created.Equal(readDump.Created))
+	readDump.Created = dump.Created
+	assert.Equal(t, dump, readDump)
+}
+
+func TestReadReturnsErrorIfNotDumpFile(t *testing.T) {
+	_, err := uenibdump.Read(strings.NewReader(`{"foo": 1}`))
+	assert.EqualError(t, err, "not a UE-NIB dump file")
+	_, err = uenibdump.Read(strings.NewReader(`foo`))
+	assert.EqualError(t, err, "not a UE-NIB dump file")
+}
+
+func TestReadReturnsErrorIfNewerVersion(t *testing.T) {
+	_, err := uenibdump.Read(strings.NewReader(`{"format": "uenib-dump", "version": 2, "ues": {}}`))
+	assert.EqualError(t, err, "unsupported UE-NIB dump file version 2")
+}
+
+func TestReadReturnsErrorIfUnknownValueEncoding(t *testing.T) {
+	_, err := uenibdump.Read(strings.NewReader(`{"format": "uenib-dump", "version": 1, "gNb": "somegnb",
+		"ues": [{"eNbUeX2ApId": "1", "entries": [{"key": "1,UE_STATE_EVENT", "value": "x", "encoding": "hex"}]}]}`))
+	assert.EqualError(t, err, "UE-NIB dump file decode failure: key '1,UE_STATE_EVENT' has unknown value encoding 'hex'")
+}
+
+func TestRestoreReplacesNamespaceContent(t *testing.T) {
+	source := newTestDb()
+	dump, _ := uenibdump.DumpGNb(source, "somegnb")
+	target := uenibmem.NewDb()
+	target.Set("uenib/somegnb", "1,UE_STATE_EVENT", "SgNBAdditionRequest")
+	err := uenibdump.Restore(target, dump, "")
+	assert.Nil(t, err)
+	sourceKeys, _ := source.GetAll("uenib/somegnb")
+	targetKeys, _ := target.GetAll("uenib/somegnb")
+	assert.Equal(t, sourceKeys, targetKeys)
+	assert.Equal(t, []string{"uenib/somegnb"}, target.Namespaces())
+}
+
+func TestRestoreWithRenamedGNb(t *testing.T) {
+	dump, _ := uenibdump.DumpGNb(newTestDb(), "somegnb")
+	target := uenibmem.NewDb()
+	err := uenibdump.Restore(target, dump, "labgnb")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"uenib/labgnb"}, target.Namespaces())
+
+	reader := uenibreader.NewReaderWithDbBackend(target)
+	teid, err := reader.GetErabS1ULGtpTETeid(&uenib.UeID{GNb: "labgnb", GNbUeX2ApID: "100"}, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 1}, teid)
+}
+
+func TestRestoreReturnsErrorIfDbBackendFailure(t *testing.T) {
+	dump, _ := uenibdump.DumpGNb(newTestDb(), "somegnb")
+	err := uenibdump.Restore(failingDb{uenibmem.NewDb()}, dump, "")
+	assert.EqualError(t, err, "namespace 'uenib/somegnb' write failure: Some DB Backend Error")
+}
+
+func TestRestoreReturnsErrorIfNoGNb(t *testing.T) {
+	err := uenibdump.Restore(uenibmem.NewDb(), &uenibdump.Dump{}, "")
+	assert.EqualError(t, err, "missing GNb")
+}
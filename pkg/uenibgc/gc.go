@@ -0,0 +1,490 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package uenibgc implements a garbage collector of orphaned UE-NIB keys.
+//
+//The UE-NIB writer removes the keys of a UE, when it publishes the UE's _REMOVE event, and the
+//keys of all the UEs, when it publishes GNB_ALL_UES_REMOVE event. If the removal is lost, the
+//keys stay in the database. Collector finds and removes such keys in a gNB's namespace:
+//   - Keys of an unmapped UE, which has no UE map key from MeNB UE X2AP ID to SgNB UE X2AP ID.
+//     Reader does not list such a UE, see uenibreader.Reader.GetUeIDs(). The UE map key from
+//     SgNB UE X2AP ID to the UE's MeNB UE X2AP ID is removed with the UE's keys.
+//   - Keys of a stale UE, which has not been updated within Policy.MaxAge according to the
+//     UE's last-updated time. Both UE map keys are removed with the UE's keys.
+//   - Keys of an unlisted E-RAB of a mapped UE, which is not listed in the UE's E-RAB IDs.
+//
+//The writer may be adding a UE or an E-RAB while the namespace is scanned, so unmapped UEs and
+//unlisted E-RABs are removed only after a grace period, see Policy.GracePeriod. The writer may
+//also update a UE after the scan, so the UE's map keys, last-updated time, version and E-RAB IDs
+//are read again before each batch of removed keys. If they have changed, the rest of the UE's
+//garbage keys are skipped until the next collection.
+//
+//A collection can be run in dry-run mode, which reports the keys without removing them, and
+//the removals can be rate limited not to load the database:
+//
+//	collector := uenibgc.NewCollector(sdl.NewSyncStorage(), uenibgc.Config{
+//		Policy:        uenibgc.Policy{MaxAge: time.Hour, GracePeriod: time.Minute},
+//		KeysPerSecond: 1000,
+//	})
+//	report, err := collector.Collect(ctx, gNb)
+package uenibgc
+
+import (
+	"context"
+	"fmt"
+	"github.com/nokia/ue-nib-library/internal"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Reason is the reason why keys are removed.
+type Reason int
+
+const (
+	//REASON_UNMAPPED_UE is a UE without UE map key from MeNB UE X2AP ID to SgNB UE X2AP ID.
+	REASON_UNMAPPED_UE Reason = iota + 1
+	//REASON_STALE_UE is a UE, which has not been updated within Policy.MaxAge.
+	REASON_STALE_UE
+	//REASON_UNLISTED_ERAB is an E-RAB, which is not listed in the UE's E-RAB IDs.
+	REASON_UNLISTED_ERAB
+)
+
+var reasonNames = map[Reason]string{
+	REASON_UNMAPPED_UE:   "UNMAPPED_UE",
+	REASON_STALE_UE:      "STALE_UE",
+	REASON_UNLISTED_ERAB: "UNLISTED_ERAB",
+}
+
+//String returns reason as a string.
+func (reason Reason) String() string {
+	if name, ok := reasonNames[reason]; ok {
+		return name
+	}
+	return "UNKNOWN"
+}
+
+//MarshalJSON encodes the reason as a JSON string.
+func (reason Reason) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(reason.String())), nil
+}
+
+//Policy defines when the keys are garbage.
+type Policy struct {
+	//MaxAge is the time after which a UE not updated by the writer is stale. Zero disables the
+	//removal of stale UEs. UEs without the last-updated time are never stale.
+	MaxAge time.Duration
+	//GracePeriod is the time for which a UE must have been unmapped, or an E-RAB unlisted,
+	//before its keys are removed. The time is counted from the UE's last-updated time or, if
+	//the UE has no last-updated time, from the earlier collection of the same Collector, which
+	//found the keys first. Zero value means DefaultGracePeriod and NoGracePeriod removes the keys
+	//when they are found, which may remove the keys of a UE, which the writer is adding.
+	GracePeriod time.Duration
+}
+
+//NoGracePeriod is the Policy.GracePeriod, which removes unmapped UEs and unlisted E-RABs when
+//they are found.
+const NoGracePeriod time.Duration = -1
+
+//Default values of Config.
+const (
+	DefaultBatchSize   = 100
+	DefaultGracePeriod = time.Minute
+)
+
+//Config configures Collector. Zero values of Policy.GracePeriod and BatchSize mean the defaults.
+type Config struct {
+	Policy        Policy
+	DryRun        bool //Report the keys without removing them.
+	KeysPerSecond int  //Maximum rate of the removed keys, zero for no limit.
+	BatchSize     int  //Maximum number of keys removed in one database call.
+}
+
+//Removal is a set of keys removed together.
+type Removal struct {
+	Reason      Reason   `json:"reason"`
+	ENbUeX2ApID string   `json:"eNbUeX2ApId"`
+	ErabID      uint64   `json:"erabId,omitempty"` //E-RAB of REASON_UNLISTED_ERAB.
+	Keys        []string `json:"keys"`
+}
+
+//Report is the result of a collection.
+type Report struct {
+	GNb         string    `json:"gNb"`
+	DryRun      bool      `json:"dryRun"`
+	Removed     []Removal `json:"removed"` //Removals ordered by UE, in dry-run mode the keys to be removed.
+	RemovedKeys int       `json:"removedKeys"`
+	PendingKeys int       `json:"pendingKeys"` //Garbage keys within the grace period.
+	SkippedKeys int       `json:"skippedKeys"` //Garbage keys of UEs updated after the scan.
+}
+
+//Backend is the database interface needed by the Collector. SDL SyncStorage and uenibmem Db
+//implement it.
+type Backend interface {
+	Get(ns string, keys []string) (map[string]interface{}, error)
+	GetAll(ns string) ([]string, error)
+	Remove(ns string, keys []string) error
+}
+
+//Collector removes orphaned keys of UE-NIB namespaces. Collector is safe for concurrent use.
+//NOTE: Use NewCollector() function to create a Collector instance.
+type Collector struct {
+	db     Backend
+	config Config
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+	mutex  sync.Mutex
+	seen   map[string]map[string]time.Time //Namespace to garbage keys to time when found first.
+}
+
+//NewCollector creates a new Collector of the given database.
+func NewCollector(db Backend, config Config) *Collector {
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.Policy.GracePeriod == 0 {
+		config.Policy.GracePeriod = DefaultGracePeriod
+	}
+	return &Collector{
+		db:     db,
+		config: config,
+		now:    time.Now,
+		sleep:  sleep,
+		seen:   make(map[string]map[string]time.Time),
+	}
+}
+
+//Collect scans a gNB's UE-NIB namespace and removes the garbage keys according to the policy.
+//The report lists the removals done before an error, or before the context is done.
+func (collector *Collector) Collect(ctx context.Context, gNb string) (*Report, error) {
+	if len(gNb) == 0 {
+		return nil, fmt.Errorf("missing GNb")
+	}
+	ns := internal.GetUeNibNs(gNb)
+	keys, err := collector.db.GetAll(ns)
+	if err != nil {
+		return nil, fmt.Errorf("namespace '%s' key read failure: %s", ns, err.Error())
+	}
+	s := newScan(keys)
+	if len(s.valueKeys) > 0 {
+		kvMap, err := collector.db.Get(ns, s.valueKeys)
+		if err != nil {
+			return nil, fmt.Errorf("namespace '%s' value read failure: %s", ns, err.Error())
+		}
+		s.setValues(kvMap)
+	}
+	removals, pending := collector.selectRemovals(ns, s.garbage(collector.config.Policy.MaxAge, collector.now()))
+
+	report := &Report{GNb: gNb, DryRun: collector.config.DryRun, Removed: []Removal{}, PendingKeys: pending}
+	if collector.config.DryRun {
+		for _, c := range removals {
+			report.add(c.Removal)
+		}
+		return report, nil
+	}
+	return report, collector.remove(ctx, ns, removals, report)
+}
+
+//selectRemovals returns the garbage candidates, which are not within the grace period, and the
+//number of the keys within it. It records the time when the keys have been found first.
+func (collector *Collector) selectRemovals(ns string, candidates []candidate) ([]candidate, int) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	now := collector.now()
+	gracePeriod := collector.config.Policy.GracePeriod
+	seen := make(map[string]time.Time)
+	var removals []candidate
+	var pending int
+	for _, c := range candidates {
+		foundAt := now
+		for _, key := range c.Keys {
+			if t, ok := collector.seen[ns][key]; ok && t.Before(foundAt) {
+				foundAt = t
+			}
+		}
+		if c.Reason != REASON_STALE_UE && !c.lastUpdated.IsZero() && c.lastUpdated.Before(foundAt) {
+			foundAt = c.lastUpdated
+		}
+		if c.Reason == REASON_STALE_UE || now.Sub(foundAt) >= gracePeriod {
+			removals = append(removals, c)
+			if !collector.config.DryRun {
+				continue
+			}
+		} else {
+			pending += len(c.Keys)
+		}
+		for _, key := range c.Keys {
+			seen[key] = foundAt
+		}
+	}
+	//Keys, which are no more garbage or which are removed now, are forgotten.
+	collector.seen[ns] = seen
+	return removals, pending
+}
+
+func (collector *Collector) remove(ctx context.Context, ns string, removals []candidate, report *Report) error {
+	var wait time.Duration
+	for _, c := range removals {
+		removed := c.Removal
+		removed.Keys = nil
+		guard := make(map[string]string, len(c.guard))
+		for key, val := range c.guard {
+			guard[key] = val
+		}
+		for start := 0; start < len(c.Keys); start += collector.config.BatchSize {
+			end := start + collector.config.BatchSize
+			if end > len(c.Keys) {
+				end = len(c.Keys)
+			}
+			batch := c.Keys[start:end]
+			if err := collector.sleep(ctx, wait); err != nil {
+				report.add(removed)
+				return err
+			}
+			unchanged, err := collector.unchanged(ns, c.guardKeys, guard)
+			if err != nil {
+				report.add(removed)
+				return err
+			}
+			if !unchanged {
+				report.SkippedKeys += len(c.Keys) - start
+				break
+			}
+			if err := collector.db.Remove(ns, batch); err != nil {
+				report.add(removed)
+				return fmt.Errorf("namespace '%s' remove failure: %s", ns, err.Error())
+			}
+			removed.Keys = append(removed.Keys, batch...)
+			//The removed keys are expected to be missing, when the next batch is checked.
+			for _, key := range batch {
+				delete(guard, key)
+			}
+			if collector.config.KeysPerSecond > 0 {
+				wait = time.Duration(len(batch)) * time.Second / time.Duration(collector.config.KeysPerSecond)
+			}
+		}
+		report.add(removed)
+	}
+	return nil
+}
+
+//unchanged reads the given keys again and returns true, if they have the given values. The keys
+//missing from the values are expected to be missing from the database.
+func (collector *Collector) unchanged(ns string, keys []string, values map[string]string) (bool, error) {
+	kvMap, err := collector.db.Get(ns, keys)
+	if err != nil {
+		return false, fmt.Errorf("namespace '%s' value read failure: %s", ns, err.Error())
+	}
+	for _, key := range keys {
+		val, ok := stringValue(kvMap[key])
+		if expected, exists := values[key]; ok != exists || val != expected {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (report *Report) add(removal Removal) {
+	if len(removal.Keys) > 0 {
+		report.Removed = append(report.Removed, removal)
+		report.RemovedKeys += len(removal.Keys)
+	}
+}
+
+//sleep waits for the given time or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil || d <= 0 {
+		return err
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//candidate is a removal of garbage keys found by a scan.
+type candidate struct {
+	Removal
+	lastUpdated time.Time         //UE's last-updated time, zero if not known.
+	guardKeys   []string          //UE's keys, which must not change before the removal.
+	guard       map[string]string //Values of the existing guard keys when scanned.
+}
+
+//scan is the state of a single namespace scan.
+type scan struct {
+	ueKeys    map[string][]string //ENbUeX2ApID to UE's keys, including UE map keys to it.
+	gNbMap    map[string]string   //UE map key from GNbUeX2ApID to ENbUeX2ApID, if known.
+	valueKeys []string            //Keys, which values are needed.
+	values    map[string]string
+}
+
+func newScan(keys []string) *scan {
+	s := &scan{
+		ueKeys: make(map[string][]string),
+		gNbMap: make(map[string]string),
+		values: make(map[string]string),
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := internal.ParseDbKeyUeMapGNbToENbUeX2ApID(key); ok {
+			s.valueKeys = append(s.valueKeys, key)
+		} else if eNbUeX2ApID, ok := internal.ParseDbKeyENbUeX2ApID(key); ok {
+			s.ueKeys[eNbUeX2ApID] = append(s.ueKeys[eNbUeX2ApID], key)
+			id := &uenib.UeID{ENbUeX2ApID: eNbUeX2ApID}
+			if contains(guardKeys(id), key) {
+				s.valueKeys = append(s.valueKeys, key)
+			}
+		}
+	}
+	return s
+}
+
+func (s *scan) setValues(kvMap map[string]interface{}) {
+	for key, val := range kvMap {
+		if v, ok := stringValue(val); ok {
+			s.values[key] = v
+		}
+	}
+	//UE map keys from GNbUeX2ApID are owned by the UE they map to.
+	for _, key := range s.valueKeys {
+		if _, ok := internal.ParseDbKeyUeMapGNbToENbUeX2ApID(key); !ok {
+			continue
+		}
+		if eNbUeX2ApID, ok := s.values[key]; ok && len(eNbUeX2ApID) > 0 && !strings.Contains(eNbUeX2ApID, ",") {
+			s.ueKeys[eNbUeX2ApID] = append(s.ueKeys[eNbUeX2ApID], key)
+			s.gNbMap[eNbUeX2ApID] = key
+		}
+	}
+}
+
+//garbage returns the garbage candidates ordered by UE.
+func (s *scan) garbage(maxAge time.Duration, now time.Time) []candidate {
+	ues := make([]string, 0, len(s.ueKeys))
+	for eNbUeX2ApID := range s.ueKeys {
+		ues = append(ues, eNbUeX2ApID)
+	}
+	sort.Slice(ues, func(i, j int) bool { return internal.LessX2ApID(ues[i], ues[j]) })
+
+	var candidates []candidate
+	for _, eNbUeX2ApID := range ues {
+		id := &uenib.UeID{ENbUeX2ApID: eNbUeX2ApID}
+		keys := s.ueKeys[eNbUeX2ApID]
+		sort.Strings(keys)
+		//Invalid last-updated time is ignored, it is reported by uenibcheck.
+		lastUpdated, _ := uenib.ParseLastUpdated(s.values[internal.DbKeyUeLastUpdated(id)])
+		mapKey := internal.DbKeyUeMapENbToGNbUeX2ApID(id)
+		var ueCandidates []candidate
+		if !contains(keys, mapKey) {
+			ueCandidates = []candidate{{Removal: Removal{Reason: REASON_UNMAPPED_UE,
+				ENbUeX2ApID: eNbUeX2ApID, Keys: keys}, lastUpdated: lastUpdated}}
+		} else if maxAge > 0 && !lastUpdated.IsZero() && now.Sub(lastUpdated) > maxAge {
+			ueCandidates = []candidate{{Removal: Removal{Reason: REASON_STALE_UE,
+				ENbUeX2ApID: eNbUeX2ApID, Keys: keys}, lastUpdated: lastUpdated}}
+		} else {
+			ueCandidates = s.unlistedErabs(id, keys)
+		}
+		for i := range ueCandidates {
+			s.setGuard(&ueCandidates[i], id)
+		}
+		candidates = append(candidates, ueCandidates...)
+	}
+	return candidates
+}
+
+//setGuard sets the keys of the UE, which tell whether the writer has updated the UE after the
+//scan, and their scanned values.
+func (s *scan) setGuard(c *candidate, id *uenib.UeID) {
+	c.guardKeys = guardKeys(id)
+	if gNbMapKey, ok := s.gNbMap[id.ENbUeX2ApID]; ok {
+		c.guardKeys = append(c.guardKeys, gNbMapKey)
+	}
+	c.guard = make(map[string]string)
+	for _, key := range c.guardKeys {
+		if val, ok := s.values[key]; ok {
+			c.guard[key] = val
+		}
+	}
+}
+
+//guardKeys returns the keys of a UE, which change when the writer maps, updates or removes the UE.
+func guardKeys(id *uenib.UeID) []string {
+	return []string{internal.DbKeyUeMapENbToGNbUeX2ApID(id), internal.DbKeyUeLastUpdated(id),
+		internal.DbKeyUeVersion(id), internal.DbKeyUeErabIDs(id)}
+}
+
+func stringValue(val interface{}) (string, bool) {
+	switch v := val.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
+//unlistedErabs returns the keys of a mapped UE's E-RABs, which are not listed in the UE's
+//E-RAB IDs. Nothing is returned, if the E-RAB IDs cannot be parsed.
+func (s *scan) unlistedErabs(id *uenib.UeID, keys []string) []candidate {
+	listed := make(map[uint64]bool)
+	if str := s.values[internal.DbKeyUeErabIDs(id)]; len(str) > 0 {
+		for _, field := range strings.Split(str, ",") {
+			erabID, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return nil
+			}
+			listed[erabID] = true
+		}
+	}
+	var erabIDs []uint64
+	unlisted := make(map[uint64][]string)
+	for _, key := range keys {
+		erabID, ok := parseErabID(id, key)
+		if !ok || listed[erabID] {
+			continue
+		}
+		if _, ok := unlisted[erabID]; !ok {
+			erabIDs = append(erabIDs, erabID)
+		}
+		unlisted[erabID] = append(unlisted[erabID], key)
+	}
+	sort.Slice(erabIDs, func(i, j int) bool { return erabIDs[i] < erabIDs[j] })
+	var candidates []candidate
+	for _, erabID := range erabIDs {
+		candidates = append(candidates, candidate{Removal: Removal{Reason: REASON_UNLISTED_ERAB,
+			ENbUeX2ApID: id.ENbUeX2ApID, ErabID: erabID, Keys: unlisted[erabID]}})
+	}
+	return candidates
+}
+
+//parseErabID returns the E-RAB ID of a UE's E-RAB key of form: <ENbUeX2ApID>,<ErabID>,<name>.
+func parseErabID(id *uenib.UeID, key string) (uint64, bool) {
+	fields := strings.Split(key, ",")
+	if len(fields) != 3 {
+		return 0, false
+	}
+	erabID, err := strconv.ParseUint(fields[1], 10, 32)
+	if err != nil || !contains(internal.GetErabAllDbKeys(id, uenib.ErabID(erabID)), key) {
+		return 0, false
+	}
+	return erabID, true
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
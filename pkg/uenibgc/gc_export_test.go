@@ -0,0 +1,23 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibgc
+
+import (
+	"context"
+	"time"
+)
+
+//SetClock replaces the clock of the Collector for unit tests.
+func (collector *Collector) SetClock(now func() time.Time) {
+	collector.now = now
+}
+
+//SetSleep replaces the rate limiting sleep of the Collector for unit tests.
+func (collector *Collector) SetSleep(sleep func(ctx context.Context, d time.Duration) error) {
+	collector.sleep = sleep
+}
@@ -0,0 +1,234 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibgc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibgc"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const testNs = "uenib/somegnb"
+
+var testNow = time.Unix(1600000000, 0)
+
+//writingDb calls the write function, which simulates the UE-NIB writer, before each Get.
+type writingDb struct {
+	*uenibmem.Db
+	gets  int
+	write func(db *uenibmem.Db, get int)
+}
+
+func (db *writingDb) Get(ns string, keys []string) (map[string]interface{}, error) {
+	db.gets++
+	db.write(db.Db, db.gets)
+	return db.Db.Get(ns, keys)
+}
+
+type failingDb struct {
+	*uenibmem.Db
+}
+
+func (db failingDb) Remove(ns string, keys []string) error {
+	return errors.New("Some DB Backend Error")
+}
+
+//setupDb sets a live UE 1 with an unlisted E-RAB 6, a stale UE 2, an unmapped UE 3 and a live
+//UE 4 without the last-updated time.
+func setupDb() *uenibmem.Db {
+	db := uenibmem.NewDb()
+	db.Set(testNs,
+		"1,UEMAP_GNBUEX2APID", "11",
+		"11,UEMAP_ENBUEX2APID", "1",
+		"1,UE_LAST_UPDATED", uenib.FormatLastUpdated(testNow.Add(-time.Second)),
+		"1,UE_ERAB_IDS", "5",
+		"1,5,UE_ERAB_DRB_ID", "1",
+		"1,6,UE_ERAB_DRB_ID", "2",
+		"1,6,UE_ERAB_QOS_QCI", "9",
+		"2,UEMAP_GNBUEX2APID", "12",
+		"12,UEMAP_ENBUEX2APID", "2",
+		"2,UE_LAST_UPDATED", uenib.FormatLastUpdated(testNow.Add(-2*time.Hour)),
+		"2,UE_PSCELL_PCI", "10",
+		"13,UEMAP_ENBUEX2APID", "3",
+		"3,UE_PSCELL_PCI", "10",
+		"4,UEMAP_GNBUEX2APID", "14",
+		"14,UEMAP_ENBUEX2APID", "4",
+		"4,UE_PSCELL_PCI", "10",
+		"SOME_OTHER_KEY", "1")
+	return db
+}
+
+func newCollector(db uenibgc.Backend, config uenibgc.Config) *uenibgc.Collector {
+	collector := uenibgc.NewCollector(db, config)
+	collector.SetClock(func() time.Time { return testNow })
+	return collector
+}
+
+func TestCollectRemovesOrphanedKeys(t *testing.T) {
+	db := setupDb()
+	collector := newCollector(db, uenibgc.Config{Policy: uenibgc.Policy{MaxAge: time.Hour, GracePeriod: uenibgc.NoGracePeriod}})
+
+	report, err := collector.Collect(context.Background(), "somegnb")
+	assert.Nil(t, err)
+	assert.Equal(t, &uenibgc.Report{
+		GNb: "somegnb",
+		Removed: []uenibgc.Removal{
+			{Reason: uenibgc.REASON_UNLISTED_ERAB, ENbUeX2ApID: "1", ErabID: 6,
+				Keys: []string{"1,6,UE_ERAB_DRB_ID", "1,6,UE_ERAB_QOS_QCI"}},
+			{Reason: uenibgc.REASON_STALE_UE, ENbUeX2ApID: "2",
+				Keys: []string{"12,UEMAP_ENBUEX2APID", "2,UEMAP_GNBUEX2APID", "2,UE_LAST_UPDATED", "2,UE_PSCELL_PCI"}},
+			{Reason: uenibgc.REASON_UNMAPPED_UE, ENbUeX2ApID: "3",
+				Keys: []string{"13,UEMAP_ENBUEX2APID", "3,UE_PSCELL_PCI"}},
+		},
+		RemovedKeys: 8,
+	}, report)
+	keys, _ := db.GetAll(testNs)
+	assert.Equal(t, []string{"1,5,UE_ERAB_DRB_ID", "1,UEMAP_GNBUEX2APID", "1,UE_ERAB_IDS", "1,UE_LAST_UPDATED",
+		"11,UEMAP_ENBUEX2APID", "14,UEMAP_ENBUEX2APID", "4,UEMAP_GNBUEX2APID", "4,UE_PSCELL_PCI",
+		"SOME_OTHER_KEY"}, keys)
+}
+
+func TestCollectInDryRunModeDoesNotRemoveKeys(t *testing.T) {
+	db := setupDb()
+	collector := newCollector(db, uenibgc.Config{Policy: uenibgc.Policy{MaxAge: time.Hour, GracePeriod: uenibgc.NoGracePeriod}, DryRun: true})
+	keys, _ := db.GetAll(testNs)
+
+	report, err := collector.Collect(context.Background(), "somegnb")
+	assert.Nil(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 3, len(report.Removed))
+	assert.Equal(t, 8, report.RemovedKeys)
+	keysAfter, _ := db.GetAll(testNs)
+	assert.Equal(t, keys, keysAfter)
+}
+
+func TestCollectRemovesUnmappedUesAndUnlistedErabsAfterDefaultGracePeriod(t *testing.T) {
+	db := setupDb()
+	collector := newCollector(db, uenibgc.Config{})
+	now := testNow
+	collector.SetClock(func() time.Time { return now })
+
+	report, err := collector.Collect(context.Background(), "somegnb")
+	assert.Nil(t, err)
+	assert.Empty(t, report.Removed)
+	assert.Equal(t, 4, report.PendingKeys)
+
+	now = now.Add(uenibgc.DefaultGracePeriod)
+	report, err = collector.Collect(context.Background(), "somegnb")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(report.Removed))
+	assert.Equal(t, uenibgc.REASON_UNLISTED_ERAB, report.Removed[0].Reason)
+	assert.Equal(t, uenibgc.REASON_UNMAPPED_UE, report.Removed[1].Reason)
+	assert.Equal(t, 0, report.PendingKeys)
+}
+
+func TestCollectCountsGracePeriodFromLastUpdatedTime(t *testing.T) {
+	db := uenibmem.NewDb()
+	db.Set(testNs, "3,UE_PSCELL_PCI", "10", "3,UE_LAST_UPDATED", uenib.FormatLastUpdated(testNow.Add(-time.Hour)))
+	collector := newCollector(db, uenibgc.Config{Policy: uenibgc.Policy{GracePeriod: time.Minute}})
+
+	report, err := collector.Collect(context.Background(), "somegnb")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, report.RemovedKeys)
+}
+
+func TestCollectRateLimitsRemovals(t *testing.T) {
+	db := setupDb()
+	collector := newCollector(db, uenibgc.Config{Policy: uenibgc.Policy{MaxAge: time.Hour, GracePeriod: uenibgc.NoGracePeriod}, KeysPerSecond: 2, BatchSize: 3})
+	var sleeps []time.Duration
+	collector.SetSleep(func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	})
+
+	report, err := collector.Collect(context.Background(), "somegnb")
+	assert.Nil(t, err)
+	assert.Equal(t, 8, report.RemovedKeys)
+	assert.Equal(t, []time.Duration{0, time.Second, 1500 * time.Millisecond, 500 * time.Millisecond}, sleeps)
+}
+
+func TestCollectStopsWhenContextIsDone(t *testing.T) {
+	db := setupDb()
+	collector := newCollector(db, uenibgc.Config{Policy: uenibgc.Policy{MaxAge: time.Hour, GracePeriod: uenibgc.NoGracePeriod}, KeysPerSecond: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	collector.SetSleep(func(ctx context.Context, d time.Duration) error {
+		if d > 0 {
+			cancel()
+		}
+		return ctx.Err()
+	})
+
+	report, err := collector.Collect(ctx, "somegnb")
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, len(report.Removed))
+	assert.Equal(t, 2, report.RemovedKeys)
+}
+
+func TestCollectReturnsErrorIfRemoveFails(t *testing.T) {
+	collector := newCollector(failingDb{setupDb()}, uenibgc.Config{Policy: uenibgc.Policy{GracePeriod: uenibgc.NoGracePeriod}})
+	report, err := collector.Collect(context.Background(), "somegnb")
+	assert.EqualError(t, err, "namespace 'uenib/somegnb' remove failure: Some DB Backend Error")
+	assert.Empty(t, report.Removed)
+}
+
+func TestCollectSkipsUeUpdatedAfterScan(t *testing.T) {
+	db := &writingDb{Db: setupDb()}
+	db.write = func(db *uenibmem.Db, get int) {
+		//Writer maps UE 3 after the scan.
+		if get == 2 {
+			db.Set(testNs, "3,UEMAP_GNBUEX2APID", "13")
+		}
+	}
+	collector := newCollector(db, uenibgc.Config{Policy: uenibgc.Policy{GracePeriod: uenibgc.NoGracePeriod}})
+
+	report, err := collector.Collect(context.Background(), "somegnb")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(report.Removed))
+	assert.Equal(t, uenibgc.REASON_UNLISTED_ERAB, report.Removed[0].Reason)
+	assert.Equal(t, 2, report.SkippedKeys)
+	keys, _ := db.GetAll(testNs)
+	assert.Contains(t, keys, "3,UE_PSCELL_PCI")
+	assert.Contains(t, keys, "13,UEMAP_ENBUEX2APID")
+}
+
+func TestCollectChecksUeBeforeEachBatch(t *testing.T) {
+	db := &writingDb{Db: setupDb()}
+	db.write = func(db *uenibmem.Db, get int) {
+		//Writer updates stale UE 2 after its first batch is removed.
+		if get == 4 {
+			db.Set(testNs, "2,UE_LAST_UPDATED", uenib.FormatLastUpdated(testNow))
+		}
+	}
+	collector := newCollector(db, uenibgc.Config{Policy: uenibgc.Policy{MaxAge: time.Hour,
+		GracePeriod: uenibgc.NoGracePeriod}, BatchSize: 2})
+
+	report, err := collector.Collect(context.Background(), "somegnb")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(report.Removed))
+	assert.Equal(t, []string{"12,UEMAP_ENBUEX2APID", "2,UEMAP_GNBUEX2APID"}, report.Removed[1].Keys)
+	assert.Equal(t, 2, report.SkippedKeys)
+	assert.Equal(t, 6, report.RemovedKeys)
+}
+
+func TestCollectReturnsErrorIfNoGNb(t *testing.T) {
+	_, err := newCollector(uenibmem.NewDb(), uenibgc.Config{}).Collect(context.Background(), "")
+	assert.EqualError(t, err, "missing GNb")
+}
+
+func TestReportJSON(t *testing.T) {
+	data, err := json.Marshal(uenibgc.Removal{Reason: uenibgc.REASON_STALE_UE, ENbUeX2ApID: "2", Keys: []string{"2,UE_PSCELL_PCI"}})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"reason":"STALE_UE","eNbUeX2ApId":"2","keys":["2,UE_PSCELL_PCI"]}`, string(data))
+	assert.Equal(t, "UNKNOWN", uenibgc.Reason(0).String())
+}
@@ -0,0 +1,208 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package uenibmem implements an in-memory database backend, which can be used instead of the SDL
+//database to store and read UE-NIB data, for example in tests or to reproduce an issue locally.
+//Its methods follow the SDL SyncStorage API, so it can be given to the uenibreader package
+//NewReaderWithDbBackend() function.
+package uenibmem
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+//Db is an in-memory database. Values are stored as strings, as the SDL database returns them.
+//Channel subscription callbacks are called synchronously by the publishing call.
+//NOTE: Use NewDb() function to create a Db instance.
+type Db struct {
+	mutex       sync.Mutex
+	namespaces  map[string]map[string]string
+	subscribers map[string][]subscriber
+}
+
+type subscriber struct {
+	cb       func(string, ...string)
+	channels map[string]bool
+}
+
+//NewDb creates a new empty in-memory database.
+func NewDb() *Db {
+	return &Db{
+		namespaces:  make(map[string]map[string]string),
+		subscribers: make(map[string][]subscriber),
+	}
+}
+
+//Get returns the values of the given keys. A key, which is not found, has a nil value.
+func (db *Db) Get(ns string, keys []string) (map[string]interface{}, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	kvMap := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		if val, ok := db.namespaces[ns][key]; ok {
+			kvMap[key] = val
+		} else {
+			kvMap[key] = nil
+		}
+	}
+	return kvMap, nil
+}
+
+//GetAll returns all the keys of a namespace in sorted order.
+func (db *Db) GetAll(ns string) ([]string, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	keys := make([]string, 0, len(db.namespaces[ns]))
+	for key := range db.namespaces[ns] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+//Set sets the values of keys given as key-value pairs. Keys must be strings and values either
+//strings or byte slices.
+func (db *Db) Set(ns string, pairs ...interface{}) error {
+	kvMap, err := toKeyValueMap(pairs)
+	if err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.set(ns, kvMap)
+	return nil
+}
+
+//SetAndPublish sets the values of keys like Set() and then publishes the events given as
+//channel-event pairs to the subscribers of the namespace.
+func (db *Db) SetAndPublish(ns string, channelsAndEvents []string, pairs ...interface{}) error {
+	if len(channelsAndEvents)%2 != 0 {
+		return fmt.Errorf("channels and events must be given as pairs")
+	}
+	kvMap, err := toKeyValueMap(pairs)
+	if err != nil {
+		return err
+	}
+	db.mutex.Lock()
+	db.set(ns, kvMap)
+	db.mutex.Unlock()
+	db.publish(ns, channelsAndEvents)
+	return nil
+}
+
+//Remove removes the given keys.
+func (db *Db) Remove(ns string, keys []string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for _, key := range keys {
+		delete(db.namespaces[ns], key)
+	}
+	if len(db.namespaces[ns]) == 0 {
+		delete(db.namespaces, ns)
+	}
+	return nil
+}
+
+//RemoveAll removes all the keys of a namespace.
+func (db *Db) RemoveAll(ns string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	delete(db.namespaces, ns)
+	return nil
+}
+
+//Publish publishes the events given as channel-event pairs to the subscribers of the namespace
+//without modifying any keys.
+func (db *Db) Publish(ns string, channelsAndEvents ...string) error {
+	if len(channelsAndEvents)%2 != 0 {
+		return fmt.Errorf("channels and events must be given as pairs")
+	}
+	db.publish(ns, channelsAndEvents)
+	return nil
+}
+
+//SubscribeChannel subscribes the callback to the given channels of the namespace.
+func (db *Db) SubscribeChannel(ns string, cb func(string, ...string), channels ...string) error {
+	s := subscriber{cb: cb, channels: make(map[string]bool, len(channels))}
+	for _, channel := range channels {
+		s.channels[channel] = true
+	}
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.subscribers[ns] = append(db.subscribers[ns], s)
+	return nil
+}
+
+//Namespaces returns the namespaces having some keys in sorted order.
+func (db *Db) Namespaces() []string {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	namespaces := make([]string, 0, len(db.namespaces))
+	for ns := range db.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+//Close removes all the subscriptions. The stored data is kept.
+func (db *Db) Close() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.subscribers = make(map[string][]subscriber)
+	return nil
+}
+
+func (db *Db) set(ns string, kvMap map[string]string) {
+	if len(kvMap) == 0 {
+		return
+	}
+	if db.namespaces[ns] == nil {
+		db.namespaces[ns] = make(map[string]string, len(kvMap))
+	}
+	for key, val := range kvMap {
+		db.namespaces[ns][key] = val
+	}
+}
+
+//publish calls the subscriber callbacks outside of the lock, so that a callback can read the data.
+func (db *Db) publish(ns string, channelsAndEvents []string) {
+	db.mutex.Lock()
+	subscribers := append([]subscriber(nil), db.subscribers[ns]...)
+	db.mutex.Unlock()
+	for i := 0; i < len(channelsAndEvents); i += 2 {
+		for _, s := range subscribers {
+			if s.channels[channelsAndEvents[i]] {
+				s.cb(channelsAndEvents[i], channelsAndEvents[i+1])
+			}
+		}
+	}
+}
+
+func toKeyValueMap(pairs []interface{}) (map[string]string, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("keys and values must be given as pairs")
+	}
+	kvMap := make(map[string]string, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("key %v is not a string", pairs[i])
+		}
+		switch val := pairs[i+1].(type) {
+		case string:
+			kvMap[key] = val
+		case []byte:
+			kvMap[key] = string(val)
+		default:
+			return nil, fmt.Errorf("key '%s' value type %T is not supported", key, pairs[i+1])
+		}
+	}
+	return kvMap, nil
+}
@@ -0,0 +1,88 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibmem_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetAndGet(t *testing.T) {
+	db := uenibmem.NewDb()
+	err := db.Set("ns", "key1", "val1", "key2", []byte{0, 1})
+	assert.Nil(t, err)
+	kvMap, err := db.Get("ns", []string{"key1", "key2", "key3"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"key1": "val1", "key2": "\x00\x01", "key3": nil}, kvMap)
+}
+
+func TestSetReturnsErrorIfInvalidPairs(t *testing.T) {
+	db := uenibmem.NewDb()
+	assert.NotNil(t, db.Set("ns", "key1"))
+	assert.NotNil(t, db.Set("ns", 1, "val1"))
+	assert.NotNil(t, db.Set("ns", "key1", 1))
+}
+
+func TestGetAllReturnsSortedKeysOfNamespace(t *testing.T) {
+	db := uenibmem.NewDb()
+	db.Set("ns1", "b", "1", "a", "2")
+	db.Set("ns2", "c", "3")
+	keys, err := db.GetAll("ns1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+	keys, err = db.GetAll("ns3")
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+	assert.Equal(t, []string{"ns1", "ns2"}, db.Namespaces())
+}
+
+func TestRemoveAndRemoveAll(t *testing.T) {
+	db := uenibmem.NewDb()
+	db.Set("ns1", "a", "1", "b", "2")
+	db.Set("ns2", "c", "3")
+	assert.Nil(t, db.Remove("ns1", []string{"a"}))
+	keys, _ := db.GetAll("ns1")
+	assert.Equal(t, []string{"b"}, keys)
+	assert.Nil(t, db.RemoveAll("ns1"))
+	assert.Equal(t, []string{"ns2"}, db.Namespaces())
+}
+
+func TestSetAndPublishCallsSubscribersOfChannel(t *testing.T) {
+	db := uenibmem.NewDb()
+	var received []string
+	db.SubscribeChannel("ns", func(channel string, events ...string) {
+		kvMap, _ := db.Get("ns", []string{"key"})
+		received = append(received, channel, events[0], kvMap["key"].(string))
+	}, "ch1")
+	err := db.SetAndPublish("ns", []string{"ch1", "evt1", "ch2", "evt2"}, "key", "val")
+	assert.Nil(t, err)
+	err = db.Publish("other", "ch1", "evt3")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ch1", "evt1", "val"}, received)
+}
+
+func TestCloseRemovesSubscriptions(t *testing.T) {
+	db := uenibmem.NewDb()
+	called := false
+	db.SubscribeChannel("ns", func(string, ...string) { called = true }, "ch1")
+	assert.Nil(t, db.Close())
+	db.Publish("ns", "ch1", "evt1")
+	assert.False(t, called)
+}
+
+func TestReaderCanReadFromDb(t *testing.T) {
+	db := uenibmem.NewDb()
+	db.Set("uenib/somegnb", "1,UE_STATE_EVENT", "SgNBAdditionRequest", "1,UE_STATE_CAUSE", "")
+	reader := uenibreader.NewReaderWithDbBackend(db)
+	state, err := reader.GetState(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "SgNBAdditionRequest", state.Event)
+}
@@ -0,0 +1,286 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package uenibmetrics implements metrics of UE-NIB Reader queries and events.
+//
+//Reader wraps a uenibreader.Reader and reports every query and received event to a Collector.
+//Registry is a Collector, which exposes the metrics in the Prometheus text exposition format:
+//
+//	registry := uenibmetrics.NewRegistry()
+//	reader := uenibmetrics.NewReader(uenibreader.NewReader(), registry)
+//	http.Handle("/metrics", registry)
+//
+//Metrics are not collected, if the uenibreader.Reader is used without wrapping it, so the
+//metrics cost nothing when they are disabled.
+package uenibmetrics
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"strings"
+	"time"
+)
+
+//Query error types reported to Collector.
+const (
+	ErrorTypeBackend                = "backend"
+	ErrorTypeValueNotFound          = "value_not_found"
+	ErrorTypeValidation             = "validation"
+	ErrorTypeInternal               = "internal"
+	ErrorTypeConcurrentModification = "concurrent_modification"
+)
+
+//Collector receives the observations of Reader.
+type Collector interface {
+	//ObserveQuery is called after each query. Parameter errorType is empty for a successful
+	//query, otherwise it is one of the ErrorType constants.
+	ObserveQuery(method string, duration time.Duration, errorType string)
+	//ObserveEvent is called for each received event, which has been parsed successfully.
+	//Parameter eventType is the event type without the leading underscore, for example "ADD".
+	ObserveEvent(gNb string, eventCategory string, eventType string)
+	//ObserveEventParseFailure is called for each received event, which cannot be parsed.
+	ObserveEventParseFailure(gNb string, eventCategory string)
+}
+
+//Reader is a uenibreader.Reader, which reports its queries and received events to a Collector.
+//NOTE: Use NewReader() function to create a Reader instance.
+type Reader struct {
+	*uenibreader.Reader
+	collector Collector
+}
+
+//NewReader creates a new Reader, which reads UE-NIB with the given reader and reports to the
+//given collector.
+func NewReader(reader *uenibreader.Reader, collector Collector) *Reader {
+	return &Reader{Reader: reader, collector: collector}
+}
+
+//ErrorType returns the error type of a query error, or empty string if the error is nil.
+func ErrorType(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case uenibreader.IsValueNotFoundFailure(err):
+		return ErrorTypeValueNotFound
+	case uenibreader.IsValidationError(err):
+		return ErrorTypeValidation
+	case uenibreader.IsBackendError(err):
+		return ErrorTypeBackend
+	case uenibreader.IsConcurrentModificationError(err):
+		return ErrorTypeConcurrentModification
+	}
+	return ErrorTypeInternal
+}
+
+func (reader *Reader) observeQuery(method string, start time.Time, err error) {
+	reader.collector.ObserveQuery(method, time.Since(start), ErrorType(err))
+}
+
+//GetMeNbUEX2APID calls uenibreader.Reader.GetMeNbUEX2APID() and reports the query.
+func (reader *Reader) GetMeNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetMeNbUEX2APID(ueID)
+	reader.observeQuery("GetMeNbUEX2APID", start, err)
+	return ret, err
+}
+
+//GetSgNbUEX2APID calls uenibreader.Reader.GetSgNbUEX2APID() and reports the query.
+func (reader *Reader) GetSgNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetSgNbUEX2APID(ueID)
+	reader.observeQuery("GetSgNbUEX2APID", start, err)
+	return ret, err
+}
+
+//GetUeIDs calls uenibreader.Reader.GetUeIDs() and reports the query.
+func (reader *Reader) GetUeIDs(gNb string) ([]uenib.UeID, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetUeIDs(gNb)
+	reader.observeQuery("GetUeIDs", start, err)
+	return ret, err
+}
+
+//GetUe calls uenibreader.Reader.GetUe() and reports the query.
+func (reader *Reader) GetUe(ueID *uenib.UeID) (*uenib.Ue, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetUe(ueID)
+	reader.observeQuery("GetUe", start, err)
+	return ret, err
+}
+
+//GetUeSnapshot calls uenibreader.Reader.GetUeSnapshot() and reports the query.
+func (reader *Reader) GetUeSnapshot(ueID *uenib.UeID) (*uenib.UeSnapshot, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetUeSnapshot(ueID)
+	reader.observeQuery("GetUeSnapshot", start, err)
+	return ret, err
+}
+
+//ListStaleUes calls uenibreader.Reader.ListStaleUes() and reports the query.
+func (reader *Reader) ListStaleUes(gNb string, olderThan time.Duration) ([]uenib.Ue, error) {
+	start := time.Now()
+	ret, err := reader.Reader.ListStaleUes(gNb, olderThan)
+	reader.observeQuery("ListStaleUes", start, err)
+	return ret, err
+}
+
+//GetPsCell calls uenibreader.Reader.GetPsCell() and reports the query.
+func (reader *Reader) GetPsCell(ueID *uenib.UeID) (*uenib.Cell, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetPsCell(ueID)
+	reader.observeQuery("GetPsCell", start, err)
+	return ret, err
+}
+
+//GetServingCells calls uenibreader.Reader.GetServingCells() and reports the query.
+func (reader *Reader) GetServingCells(ueID *uenib.UeID) (*uenib.ServingCells, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetServingCells(ueID)
+	reader.observeQuery("GetServingCells", start, err)
+	return ret, err
+}
+
+//GetState calls uenibreader.Reader.GetState() and reports the query.
+func (reader *Reader) GetState(ueID *uenib.UeID) (*uenib.UeState, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetState(ueID)
+	reader.observeQuery("GetState", start, err)
+	return ret, err
+}
+
+//GetStateHistory calls uenibreader.Reader.GetStateHistory() and reports the query.
+func (reader *Reader) GetStateHistory(ueID *uenib.UeID, limit int) ([]uenib.UeState, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetStateHistory(ueID, limit)
+	reader.observeQuery("GetStateHistory", start, err)
+	return ret, err
+}
+
+//GetBearerIDs calls uenibreader.Reader.GetBearerIDs() and reports the query.
+func (reader *Reader) GetBearerIDs(ueID *uenib.UeID) ([]uenib.ErabID, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetBearerIDs(ueID)
+	reader.observeQuery("GetBearerIDs", start, err)
+	return ret, err
+}
+
+//GetBearers calls uenibreader.Reader.GetBearers() and reports the query.
+func (reader *Reader) GetBearers(ueID *uenib.UeID) ([]uenib.Bearer, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetBearers(ueID)
+	reader.observeQuery("GetBearers", start, err)
+	return ret, err
+}
+
+//GetErabS1ULGtpTE calls uenibreader.Reader.GetErabS1ULGtpTE() and reports the query.
+func (reader *Reader) GetErabS1ULGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetErabS1ULGtpTE(ueID, erabID)
+	reader.observeQuery("GetErabS1ULGtpTE", start, err)
+	return ret, err
+}
+
+//GetErabS1DLGtpTE calls uenibreader.Reader.GetErabS1DLGtpTE() and reports the query.
+func (reader *Reader) GetErabS1DLGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetErabS1DLGtpTE(ueID, erabID)
+	reader.observeQuery("GetErabS1DLGtpTE", start, err)
+	return ret, err
+}
+
+//GetErabX2UGtpTE calls uenibreader.Reader.GetErabX2UGtpTE() and reports the query.
+func (reader *Reader) GetErabX2UGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetErabX2UGtpTE(ueID, erabID)
+	reader.observeQuery("GetErabX2UGtpTE", start, err)
+	return ret, err
+}
+
+//GetErabBearerType calls uenibreader.Reader.GetErabBearerType() and reports the query.
+func (reader *Reader) GetErabBearerType(ueID *uenib.UeID, erabID uenib.ErabID) (uenib.BearerType, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetErabBearerType(ueID, erabID)
+	reader.observeQuery("GetErabBearerType", start, err)
+	return ret, err
+}
+
+//GetErabS1ULGtpTEAddr calls uenibreader.Reader.GetErabS1ULGtpTEAddr() and reports the query.
+func (reader *Reader) GetErabS1ULGtpTEAddr(ueID *uenib.UeID, erabID uenib.ErabID) ([]byte, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetErabS1ULGtpTEAddr(ueID, erabID)
+	reader.observeQuery("GetErabS1ULGtpTEAddr", start, err)
+	return ret, err
+}
+
+//GetErabS1ULGtpTETeid calls uenibreader.Reader.GetErabS1ULGtpTETeid() and reports the query.
+func (reader *Reader) GetErabS1ULGtpTETeid(ueID *uenib.UeID, erabID uenib.ErabID) ([]byte, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetErabS1ULGtpTETeid(ueID, erabID)
+	reader.observeQuery("GetErabS1ULGtpTETeid", start, err)
+	return ret, err
+}
+
+//GetErabQosArpPL calls uenibreader.Reader.GetErabQosArpPL() and reports the query.
+func (reader *Reader) GetErabQosArpPL(ueID *uenib.UeID, erabID uenib.ErabID) (uint32, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetErabQosArpPL(ueID, erabID)
+	reader.observeQuery("GetErabQosArpPL", start, err)
+	return ret, err
+}
+
+//GetErabQosQci calls uenibreader.Reader.GetErabQosQci() and reports the query.
+func (reader *Reader) GetErabQosQci(ueID *uenib.UeID, erabID uenib.ErabID) (uint32, error) {
+	start := time.Now()
+	ret, err := reader.Reader.GetErabQosQci(ueID, erabID)
+	reader.observeQuery("GetErabQosQci", start, err)
+	return ret, err
+}
+
+//SubscribeEvents calls uenibreader.Reader.SubscribeParsedEvents() with a callback, which reports
+//the received events before calling the given callback with the event strings.
+func (reader *Reader) SubscribeEvents(gNbs []string, eventCategories []uenibreader.EventCategory, callback uenibreader.EventCallback) error {
+	return reader.Reader.SubscribeParsedEvents(gNbs, eventCategories,
+		func(gNb string, eventCategory uenibreader.EventCategory, events []uenibreader.Event) {
+			reader.observeEvents(gNb, eventCategory, events)
+			evtStrs := make([]string, 0, len(events))
+			for _, evt := range events {
+				evtStrs = append(evtStrs, evt.Raw)
+			}
+			callback(gNb, eventCategory, evtStrs)
+		})
+}
+
+//SubscribeParsedEvents calls uenibreader.Reader.SubscribeParsedEvents() with a callback, which
+//reports the received events before calling the given callback.
+func (reader *Reader) SubscribeParsedEvents(gNbs []string, eventCategories []uenibreader.EventCategory, callback uenibreader.ParsedEventCallback) error {
+	return reader.Reader.SubscribeParsedEvents(gNbs, eventCategories,
+		func(gNb string, eventCategory uenibreader.EventCategory, events []uenibreader.Event) {
+			reader.observeEvents(gNb, eventCategory, events)
+			callback(gNb, eventCategory, events)
+		})
+}
+
+func (reader *Reader) observeEvents(gNb string, eventCategory uenibreader.EventCategory, events []uenibreader.Event) {
+	for _, evt := range events {
+		reader.observeEvent(gNb, eventCategory, evt)
+	}
+}
+
+func (reader *Reader) observeEvent(gNb string, eventCategory uenibreader.EventCategory, evt uenibreader.Event) {
+	if evt.Err != nil {
+		reader.collector.ObserveEventParseFailure(gNb, eventCategory.String())
+		return
+	}
+	var eventType string
+	switch parsed := evt.Parsed.(type) {
+	case uenibreader.DcEvent:
+		eventType = parsed.EventType.String()
+	case uenibreader.MobilityEvent:
+		eventType = parsed.EventType.String()
+	}
+	reader.collector.ObserveEvent(gNb, eventCategory.String(), strings.TrimPrefix(eventType, "_"))
+}
@@ -0,0 +1,192 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibmetrics_test
+
+import (
+	"bytes"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibmetrics"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type observation struct {
+	method    string
+	errorType string
+}
+
+type fakeCollector struct {
+	queries       []observation
+	events        [][3]string
+	parseFailures [][2]string
+}
+
+func (c *fakeCollector) ObserveQuery(method string, duration time.Duration, errorType string) {
+	c.queries = append(c.queries, observation{method, errorType})
+}
+
+func (c *fakeCollector) ObserveEvent(gNb string, eventCategory string, eventType string) {
+	c.events = append(c.events, [3]string{gNb, eventCategory, eventType})
+}
+
+func (c *fakeCollector) ObserveEventParseFailure(gNb string, eventCategory string) {
+	c.parseFailures = append(c.parseFailures, [2]string{gNb, eventCategory})
+}
+
+func setup() (*uenibmem.Db, *fakeCollector, *uenibmetrics.Reader) {
+	db := uenibmem.NewDb()
+	collector := &fakeCollector{}
+	return db, collector, uenibmetrics.NewReader(uenibreader.NewReaderWithDbBackend(db), collector)
+}
+
+func TestReaderReportsQueries(t *testing.T) {
+	db, collector, reader := setup()
+	db.Set("uenib/somegnb", "1,UE_PSCELL_PCI", "10", "1,UE_PSCELL_FREQ", "632628")
+
+	cell, err := reader.GetPsCell(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(10), cell.Pci)
+	_, err = reader.GetPsCell(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "2"})
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+	_, err = reader.GetState(&uenib.UeID{ENbUeX2ApID: "1"})
+	assert.True(t, uenibreader.IsValidationError(err))
+
+	assert.Equal(t, []observation{
+		{"GetPsCell", ""},
+		{"GetPsCell", uenibmetrics.ErrorTypeValueNotFound},
+		{"GetState", uenibmetrics.ErrorTypeValidation},
+	}, collector.queries)
+}
+
+func TestReaderReportsEvents(t *testing.T) {
+	db, collector, reader := setup()
+	var received []string
+	err := reader.SubscribeEvents([]string{"somegnb"},
+		[]uenibreader.EventCategory{uenibreader.DualConnectivity, uenibreader.Mobility},
+		func(gNb string, eventCategory uenibreader.EventCategory, events []string) {
+			received = append(received, events...)
+		})
+	assert.Nil(t, err)
+
+	db.Publish("uenib/somegnb", "somegnb_DUAL_CONNECTIVITY", "somegnb#2#1_ADD")
+	db.Publish("uenib/somegnb", "somegnb_DUAL_CONNECTIVITY", "GNB_ALL_UES_REMOVE")
+	db.Publish("uenib/somegnb", "somegnb_DUAL_CONNECTIVITY", "somegnb#2#1_x_S1UL_TUNNEL_ESTABLISH")
+	db.Publish("uenib/somegnb", "somegnb_MOBILITY", "somegnb#2#1_10#632628_PSCELL_CHANGE")
+
+	assert.Equal(t, 4, len(received))
+	assert.Equal(t, [][3]string{
+		{"somegnb", "DUAL_CONNECTIVITY", "ADD"},
+		{"somegnb", "DUAL_CONNECTIVITY", "GNB_ALL_UES_REMOVE"},
+		{"somegnb", "MOBILITY", "PSCELL_CHANGE"},
+	}, collector.events)
+	assert.Equal(t, [][2]string{{"somegnb", "DUAL_CONNECTIVITY"}}, collector.parseFailures)
+}
+
+func TestReaderReportsParsedEvents(t *testing.T) {
+	db, collector, reader := setup()
+	var received []uenibreader.Event
+	err := reader.SubscribeParsedEvents([]string{"somegnb"}, []uenibreader.EventCategory{uenibreader.DualConnectivity},
+		func(gNb string, eventCategory uenibreader.EventCategory, events []uenibreader.Event) {
+			received = append(received, events...)
+		})
+	assert.Nil(t, err)
+
+	db.Publish("uenib/somegnb", "somegnb_DUAL_CONNECTIVITY", "somegnb#2#1_ADD")
+	db.Publish("uenib/somegnb", "somegnb_DUAL_CONNECTIVITY", "somegnb#2#1_x_S1UL_TUNNEL_ESTABLISH")
+
+	assert.Equal(t, 2, len(received))
+	assert.Equal(t, uenibreader.DC_EVENT_ADD, received[0].Parsed.(uenibreader.DcEvent).EventType)
+	assert.NotNil(t, received[1].Err)
+	assert.Equal(t, [][3]string{{"somegnb", "DUAL_CONNECTIVITY", "ADD"}}, collector.events)
+	assert.Equal(t, [][2]string{{"somegnb", "DUAL_CONNECTIVITY"}}, collector.parseFailures)
+}
+
+func TestErrorType(t *testing.T) {
+	assert.Equal(t, "", uenibmetrics.ErrorType(nil))
+}
+
+func TestRegistryWritesMetrics(t *testing.T) {
+	registry := uenibmetrics.NewRegistryWithBuckets([]float64{0.1, 0.01})
+	registry.ObserveQuery("GetPsCell", 5*time.Millisecond, "")
+	registry.ObserveQuery("GetPsCell", 50*time.Millisecond, uenibmetrics.ErrorTypeBackend)
+	registry.ObserveQuery("GetBearers", time.Second, "")
+	registry.ObserveEvent("gnb\"1", "DUAL_CONNECTIVITY", "ADD")
+	registry.ObserveEvent("gnb\"1", "DUAL_CONNECTIVITY", "ADD")
+	registry.ObserveEventParseFailure("gnb2", "MOBILITY")
+
+	var buf bytes.Buffer
+	assert.Nil(t, registry.WriteMetrics(&buf))
+	assert.Equal(t, `# HELP uenib_reader_queries_total Number of UE-NIB Reader queries.
+# TYPE uenib_reader_queries_total counter
+uenib_reader_queries_total{method="GetBearers"} 1
+uenib_reader_queries_total{method="GetPsCell"} 2
+# HELP uenib_reader_query_duration_seconds Duration of UE-NIB Reader queries.
+# TYPE uenib_reader_query_duration_seconds histogram
+uenib_reader_query_duration_seconds_bucket{method="GetBearers",le="0.01"} 0
+uenib_reader_query_duration_seconds_bucket{method="GetBearers",le="0.1"} 0
+uenib_reader_query_duration_seconds_bucket{method="GetBearers",le="+Inf"} 1
+uenib_reader_query_duration_seconds_sum{method="GetBearers"} 1
+uenib_reader_query_duration_seconds_count{method="GetBearers"} 1
+uenib_reader_query_duration_seconds_bucket{method="GetPsCell",le="0.01"} 1
+uenib_reader_query_duration_seconds_bucket{method="GetPsCell",le="0.1"} 2
+uenib_reader_query_duration_seconds_bucket{method="GetPsCell",le="+Inf"} 2
+uenib_reader_query_duration_seconds_sum{method="GetPsCell"} 0.055
+uenib_reader_query_duration_seconds_count{method="GetPsCell"} 2
+# HELP uenib_reader_query_errors_total Number of failed UE-NIB Reader queries by error type.
+# TYPE uenib_reader_query_errors_total counter
+uenib_reader_query_errors_total{method="GetPsCell",type="backend"} 1
+# HELP uenib_reader_events_total Number of received UE-NIB events.
+# TYPE uenib_reader_events_total counter
+uenib_reader_events_total{gnb="gnb\"1",category="DUAL_CONNECTIVITY",event_type="ADD"} 2
+# HELP uenib_reader_event_parse_failures_total Number of received UE-NIB events failed to parse.
+# TYPE uenib_reader_event_parse_failures_total counter
+uenib_reader_event_parse_failures_total{gnb="gnb2",category="MOBILITY"} 1
+`, buf.String())
+}
+
+func TestRegistryServesMetrics(t *testing.T) {
+	registry := uenibmetrics.NewRegistry()
+	registry.ObserveEvent("gnb1", "MOBILITY", "SGNB_CHANGE")
+	rec := httptest.NewRecorder()
+	registry.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.True(t, strings.Contains(rec.Body.String(),
+		`uenib_reader_events_total{gnb="gnb1",category="MOBILITY",event_type="SGNB_CHANGE"} 1`))
+}
+
+func TestRegistryWritesCircuitBreakerMetrics(t *testing.T) {
+	registry := uenibmetrics.NewRegistry()
+	registry.ObserveCircuitBreakerStateChange("CLOSED", "OPEN")
+	registry.ObserveCircuitBreakerRejection(true)
+	registry.ObserveCircuitBreakerRejection(false)
+	registry.ObserveCircuitBreakerRejection(false)
+	registry.ObserveCircuitBreakerStateChange("OPEN", "HALF_OPEN")
+
+	var buf bytes.Buffer
+	assert.Nil(t, registry.WriteMetrics(&buf))
+	assert.True(t, strings.HasSuffix(buf.String(), `# HELP uenib_backend_circuit_breaker_state Current state of the database circuit breaker.
+# TYPE uenib_backend_circuit_breaker_state gauge
+uenib_backend_circuit_breaker_state{state="CLOSED"} 0
+uenib_backend_circuit_breaker_state{state="OPEN"} 0
+uenib_backend_circuit_breaker_state{state="HALF_OPEN"} 1
+# HELP uenib_backend_circuit_breaker_transitions_total Number of database circuit breaker state changes.
+# TYPE uenib_backend_circuit_breaker_transitions_total counter
+uenib_backend_circuit_breaker_transitions_total{from="CLOSED",to="OPEN"} 1
+uenib_backend_circuit_breaker_transitions_total{from="OPEN",to="HALF_OPEN"} 1
+# HELP uenib_backend_circuit_breaker_rejections_total Number of database calls rejected by the circuit breaker.
+# TYPE uenib_backend_circuit_breaker_rejections_total counter
+uenib_backend_circuit_breaker_rejections_total{stale="false"} 2
+uenib_backend_circuit_breaker_rejections_total{stale="true"} 1
+`))
+}
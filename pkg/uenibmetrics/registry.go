@@ -0,0 +1,273 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibmetrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//DefaultDurationBuckets are the upper bounds in seconds of the query duration histogram buckets.
+var DefaultDurationBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+//Registry is a Collector, which keeps the metrics in memory and exposes them in the Prometheus
+//text exposition format. Registry implements http.Handler, so it can be registered to an HTTP
+//server as the Prometheus scrape endpoint, for example to path /metrics.
+//NOTE: Use NewRegistry() function to create a Registry instance.
+type Registry struct {
+	mutex         sync.Mutex
+	buckets       []float64
+	queries       map[string]*histogram
+	queryErrors   map[[2]string]uint64
+	events        map[[3]string]uint64
+	parseFailures map[[2]string]uint64
+	breakerState  string
+	transitions   map[[2]string]uint64
+	rejections    map[string]uint64
+}
+
+type histogram struct {
+	counts []uint64 //Cumulative counts per bucket.
+	count  uint64
+	sum    float64
+}
+
+//NewRegistry creates a new Registry, which uses DefaultDurationBuckets for query durations.
+func NewRegistry() *Registry {
+	return NewRegistryWithBuckets(DefaultDurationBuckets)
+}
+
+//NewRegistryWithBuckets creates a new Registry, which uses the given upper bounds in seconds for
+//the query duration histogram buckets.
+func NewRegistryWithBuckets(buckets []float64) *Registry {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Registry{
+		buckets:       sorted,
+		queries:       make(map[string]*histogram),
+		queryErrors:   make(map[[2]string]uint64),
+		events:        make(map[[3]string]uint64),
+		parseFailures: make(map[[2]string]uint64),
+		transitions:   make(map[[2]string]uint64),
+		rejections:    make(map[string]uint64),
+	}
+}
+
+//ObserveQuery implements Collector interface.
+func (registry *Registry) ObserveQuery(method string, duration time.Duration, errorType string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	h, ok := registry.queries[method]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(registry.buckets))}
+		registry.queries[method] = h
+	}
+	seconds := duration.Seconds()
+	for i, upperBound := range registry.buckets {
+		if seconds <= upperBound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+	if len(errorType) > 0 {
+		registry.queryErrors[[2]string{method, errorType}]++
+	}
+}
+
+//ObserveEvent implements Collector interface.
+func (registry *Registry) ObserveEvent(gNb string, eventCategory string, eventType string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.events[[3]string{gNb, eventCategory, eventType}]++
+}
+
+//ObserveEventParseFailure implements Collector interface.
+func (registry *Registry) ObserveEventParseFailure(gNb string, eventCategory string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.parseFailures[[2]string{gNb, eventCategory}]++
+}
+
+//ObserveCircuitBreakerStateChange records a state change of the database circuit breaker, see
+//package uenibbreaker. The circuit breaker metrics are written only after the first observation.
+func (registry *Registry) ObserveCircuitBreakerStateChange(from string, to string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.breakerState = to
+	registry.transitions[[2]string{from, to}]++
+}
+
+//ObserveCircuitBreakerRejection records a database call rejected by the circuit breaker.
+//Parameter stale is true, if the call was served from the cache.
+func (registry *Registry) ObserveCircuitBreakerRejection(stale bool) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if registry.breakerState == "" {
+		registry.breakerState = "OPEN"
+	}
+	registry.rejections[strconv.FormatBool(stale)]++
+}
+
+//WriteMetrics writes the metrics in the Prometheus text exposition format.
+func (registry *Registry) WriteMetrics(w io.Writer) error {
+	var buf bytes.Buffer
+	registry.mutex.Lock()
+	registry.writeQueries(&buf)
+	registry.writeQueryErrors(&buf)
+	registry.writeEvents(&buf)
+	registry.writeParseFailures(&buf)
+	if registry.breakerState != "" {
+		registry.writeCircuitBreaker(&buf)
+	}
+	registry.mutex.Unlock()
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+//ServeHTTP implements http.Handler interface by writing the metrics as the response.
+func (registry *Registry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	registry.WriteMetrics(w)
+}
+
+func (registry *Registry) writeQueries(buf *bytes.Buffer) {
+	writeHeader(buf, "uenib_reader_queries_total", "counter", "Number of UE-NIB Reader queries.")
+	methods := make([]string, 0, len(registry.queries))
+	for method := range registry.queries {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		writeSample(buf, "uenib_reader_queries_total", labels("method", method), float64(registry.queries[method].count))
+	}
+
+	writeHeader(buf, "uenib_reader_query_duration_seconds", "histogram", "Duration of UE-NIB Reader queries.")
+	for _, method := range methods {
+		h := registry.queries[method]
+		for i, upperBound := range registry.buckets {
+			writeSample(buf, "uenib_reader_query_duration_seconds_bucket",
+				labels("method", method, "le", formatFloat(upperBound)), float64(h.counts[i]))
+		}
+		writeSample(buf, "uenib_reader_query_duration_seconds_bucket",
+			labels("method", method, "le", "+Inf"), float64(h.count))
+		writeSample(buf, "uenib_reader_query_duration_seconds_sum", labels("method", method), h.sum)
+		writeSample(buf, "uenib_reader_query_duration_seconds_count", labels("method", method), float64(h.count))
+	}
+}
+
+func (registry *Registry) writeQueryErrors(buf *bytes.Buffer) {
+	writeHeader(buf, "uenib_reader_query_errors_total", "counter", "Number of failed UE-NIB Reader queries by error type.")
+	var keys [][2]string
+	for key := range registry.queryErrors {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessLabels(keys[i][:], keys[j][:]) })
+	for _, key := range keys {
+		writeSample(buf, "uenib_reader_query_errors_total", labels("method", key[0], "type", key[1]),
+			float64(registry.queryErrors[key]))
+	}
+}
+
+func (registry *Registry) writeEvents(buf *bytes.Buffer) {
+	writeHeader(buf, "uenib_reader_events_total", "counter", "Number of received UE-NIB events.")
+	var keys [][3]string
+	for key := range registry.events {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessLabels(keys[i][:], keys[j][:]) })
+	for _, key := range keys {
+		writeSample(buf, "uenib_reader_events_total", labels("gnb", key[0], "category", key[1], "event_type", key[2]),
+			float64(registry.events[key]))
+	}
+}
+
+func (registry *Registry) writeParseFailures(buf *bytes.Buffer) {
+	writeHeader(buf, "uenib_reader_event_parse_failures_total", "counter", "Number of received UE-NIB events failed to parse.")
+	var keys [][2]string
+	for key := range registry.parseFailures {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessLabels(keys[i][:], keys[j][:]) })
+	for _, key := range keys {
+		writeSample(buf, "uenib_reader_event_parse_failures_total", labels("gnb", key[0], "category", key[1]),
+			float64(registry.parseFailures[key]))
+	}
+}
+
+func (registry *Registry) writeCircuitBreaker(buf *bytes.Buffer) {
+	writeHeader(buf, "uenib_backend_circuit_breaker_state", "gauge", "Current state of the database circuit breaker.")
+	for _, state := range []string{"CLOSED", "OPEN", "HALF_OPEN"} {
+		value := 0.0
+		if state == registry.breakerState {
+			value = 1
+		}
+		writeSample(buf, "uenib_backend_circuit_breaker_state", labels("state", state), value)
+	}
+
+	writeHeader(buf, "uenib_backend_circuit_breaker_transitions_total", "counter", "Number of database circuit breaker state changes.")
+	var keys [][2]string
+	for key := range registry.transitions {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return lessLabels(keys[i][:], keys[j][:]) })
+	for _, key := range keys {
+		writeSample(buf, "uenib_backend_circuit_breaker_transitions_total", labels("from", key[0], "to", key[1]),
+			float64(registry.transitions[key]))
+	}
+
+	writeHeader(buf, "uenib_backend_circuit_breaker_rejections_total", "counter", "Number of database calls rejected by the circuit breaker.")
+	for _, stale := range []string{"false", "true"} {
+		if count, ok := registry.rejections[stale]; ok {
+			writeSample(buf, "uenib_backend_circuit_breaker_rejections_total", labels("stale", stale), float64(count))
+		}
+	}
+}
+
+func writeHeader(buf *bytes.Buffer, name string, metricType string, help string) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+func writeSample(buf *bytes.Buffer, name string, labels string, value float64) {
+	fmt.Fprintf(buf, "%s{%s} %s\n", name, labels, formatFloat(value))
+}
+
+//labels formats label name and value pairs.
+func labels(namesAndValues ...string) string {
+	var pairs []string
+	for i := 0; i+1 < len(namesAndValues); i += 2 {
+		pairs = append(pairs, namesAndValues[i]+`="`+labelValueEscaper.Replace(namesAndValues[i+1])+`"`)
+	}
+	return strings.Join(pairs, ",")
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func lessLabels(a []string, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
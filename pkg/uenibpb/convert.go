@@ -0,0 +1,291 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//go:generate protoc --go_out=paths=source_relative:. uenib.proto
+
+//Package uenibpb provides protocol buffers types of the UE-NIB data types and converters
+//between them and the uenib and uenibreader package types. The types are generated from
+//the uenib.proto schema.
+package uenibpb
+
+import (
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"net"
+	"strings"
+)
+
+//FromUeID converts a UE ID to its protocol buffers form.
+func FromUeID(ueID uenib.UeID) *UeID {
+	return &UeID{
+		GNb:         ueID.GNb,
+		ENb:         ueID.ENb,
+		GNbUeX2ApId: ueID.GNbUeX2ApID,
+		ENbUeX2ApId: ueID.ENbUeX2ApID,
+	}
+}
+
+//ToUeID converts a UE ID from its protocol buffers form. Nil message results an empty UE ID.
+func ToUeID(m *UeID) uenib.UeID {
+	return uenib.UeID{
+		GNb:         m.GetGNb(),
+		ENb:         m.GetENb(),
+		GNbUeX2ApID: m.GetGNbUeX2ApId(),
+		ENbUeX2ApID: m.GetENbUeX2ApId(),
+	}
+}
+
+//FromTunnelEndpoint converts a tunnel endpoint to its protocol buffers form. Nil is returned
+//for an empty tunnel endpoint. An error is returned, if the address or TEID is not in the
+//form described in uenib.TunnelEndpoint.
+func FromTunnelEndpoint(tep uenib.TunnelEndpoint) (*TunnelEndpoint, error) {
+	if tep.IsEmpty() {
+		return nil, nil
+	}
+	addr, err := tep.AddressString()
+	if err != nil {
+		return nil, err
+	}
+	teid, err := tep.TeidValue()
+	if err != nil {
+		return nil, err
+	}
+	return &TunnelEndpoint{
+		Address: toTransportAddressBytes(addr),
+		Teid:    teid,
+	}, nil
+}
+
+//ToTunnelEndpoint converts a tunnel endpoint from its protocol buffers form. Nil message
+//results an empty tunnel endpoint. An error is returned, if the address is not 4, 16 or
+//20 bytes long.
+func ToTunnelEndpoint(m *TunnelEndpoint) (uenib.TunnelEndpoint, error) {
+	if m == nil {
+		return uenib.TunnelEndpoint{}, nil
+	}
+	addr, err := toTransportAddressString(m.GetAddress())
+	if err != nil {
+		return uenib.TunnelEndpoint{}, err
+	}
+	return uenib.NewTunnelEndpoint(addr, m.GetTeid())
+}
+
+//FromBearer converts a bearer to its protocol buffers form.
+//An error is returned, if some tunnel endpoint of the bearer has an invalid address or TEID.
+func FromBearer(bearer uenib.Bearer) (*Bearer, error) {
+	var err error
+	m := &Bearer{
+		ErabId:     uint32(bearer.ErabID),
+		DrbId:      bearer.DrbID,
+		ArpPl:      bearer.ArpPL,
+		Qci:        bearer.Qci,
+		BearerType: BearerType(bearer.BearerType),
+	}
+	if m.S1UlGtpTe, err = FromTunnelEndpoint(bearer.S1ULGtpTE); err != nil {
+		return nil, fmt.Errorf("E-RAB ID %d S1-U UL tunnel endpoint: %s", bearer.ErabID, err.Error())
+	}
+	if m.S1DlGtpTe, err = FromTunnelEndpoint(bearer.S1DLGtpTE); err != nil {
+		return nil, fmt.Errorf("E-RAB ID %d S1-U DL tunnel endpoint: %s", bearer.ErabID, err.Error())
+	}
+	if m.X2UGtpTe, err = FromTunnelEndpoint(bearer.X2UGtpTE); err != nil {
+		return nil, fmt.Errorf("E-RAB ID %d X2-U tunnel endpoint: %s", bearer.ErabID, err.Error())
+	}
+	return m, nil
+}
+
+//ToBearer converts a bearer from its protocol buffers form. Nil message results an
+//empty bearer. An error is returned, if some tunnel endpoint of the bearer has an invalid
+//address.
+func ToBearer(m *Bearer) (uenib.Bearer, error) {
+	var err error
+	bearer := uenib.Bearer{
+		ErabID:     uenib.ErabID(m.GetErabId()),
+		DrbID:      m.GetDrbId(),
+		ArpPL:      m.GetArpPl(),
+		Qci:        m.GetQci(),
+		BearerType: toBearerType(m.GetBearerType()),
+	}
+	if bearer.S1ULGtpTE, err = ToTunnelEndpoint(m.GetS1UlGtpTe()); err != nil {
+		return uenib.Bearer{}, fmt.Errorf("E-RAB ID %d S1-U UL tunnel endpoint: %s", bearer.ErabID, err.Error())
+	}
+	if bearer.S1DLGtpTE, err = ToTunnelEndpoint(m.GetS1DlGtpTe()); err != nil {
+		return uenib.Bearer{}, fmt.Errorf("E-RAB ID %d S1-U DL tunnel endpoint: %s", bearer.ErabID, err.Error())
+	}
+	if bearer.X2UGtpTE, err = ToTunnelEndpoint(m.GetX2UGtpTe()); err != nil {
+		return uenib.Bearer{}, fmt.Errorf("E-RAB ID %d X2-U tunnel endpoint: %s", bearer.ErabID, err.Error())
+	}
+	return bearer, nil
+}
+
+//FromCell converts a cell to its protocol buffers form.
+func FromCell(cell uenib.Cell) *Cell {
+	m := &Cell{
+		Pci:     cell.Pci,
+		SsbFreq: cell.SsbFreq,
+	}
+	if !cell.NrCgi.IsEmpty() {
+		m.NrCgi = &NrCgi{
+			Mcc:      cell.NrCgi.Mcc,
+			Mnc:      cell.NrCgi.Mnc,
+			NrCellId: cell.NrCgi.NrCellID,
+		}
+	}
+	if cell.Measurement != nil {
+		m.Measurement = &CellMeasurement{
+			Rsrp: cell.Measurement.Rsrp,
+			Rsrq: cell.Measurement.Rsrq,
+			Sinr: cell.Measurement.Sinr,
+		}
+	}
+	return m
+}
+
+//ToCell converts a cell from its protocol buffers form. Nil message results an empty cell.
+func ToCell(m *Cell) uenib.Cell {
+	cell := uenib.Cell{
+		Pci:     m.GetPci(),
+		SsbFreq: m.GetSsbFreq(),
+	}
+	if nrCgi := m.GetNrCgi(); nrCgi != nil {
+		cell.NrCgi = uenib.NrCgi{
+			Mcc:      nrCgi.GetMcc(),
+			Mnc:      nrCgi.GetMnc(),
+			NrCellID: nrCgi.GetNrCellId(),
+		}
+	}
+	if measurement := m.GetMeasurement(); measurement != nil {
+		cell.Measurement = &uenib.CellMeasurement{
+			Rsrp: measurement.GetRsrp(),
+			Rsrq: measurement.GetRsrq(),
+			Sinr: measurement.GetSinr(),
+		}
+	}
+	return cell
+}
+
+//FromUeState converts a UE state to its protocol buffers form.
+func FromUeState(state uenib.UeState) *UeState {
+	return &UeState{
+		Event: state.Event,
+		Cause: state.Cause,
+	}
+}
+
+//ToUeState converts a UE state from its protocol buffers form. Nil message results an
+//empty UE state.
+func ToUeState(m *UeState) uenib.UeState {
+	return uenib.UeState{
+		Event: m.GetEvent(),
+		Cause: m.GetCause(),
+	}
+}
+
+//FromDcEvent converts a dual connectivity event to its protocol buffers form.
+//Unset UE ID and PSCell are left unset in the protocol buffers message.
+func FromDcEvent(evt uenibreader.DcEvent) *DcEvent {
+	m := &DcEvent{
+		EventType: DcEventType(evt.EventType),
+		GNb:       evt.GNb,
+	}
+	if evt.UeID != (uenib.UeID{}) {
+		m.UeId = FromUeID(evt.UeID)
+	}
+	for _, t := range evt.S1ULGtpTunnels {
+		m.S1UlGtpTunnels = append(m.S1UlGtpTunnels, &DcEventTunnel{
+			Addr:      t.Addr,
+			Teid:      t.Teid,
+			ErabId:    uint32(t.ErabID),
+			HasErabId: t.HasErabID,
+		})
+	}
+	for _, b := range evt.BearerTypes {
+		m.BearerTypes = append(m.BearerTypes, &DcEventBearerType{
+			ErabId:     uint32(b.ErabID),
+			BearerType: BearerType(b.BearerType),
+		})
+	}
+	if evt.PsCell.Pci != 0 || evt.PsCell.SsbFreq != 0 || !evt.PsCell.NrCgi.IsEmpty() || evt.PsCell.Measurement != nil {
+		m.PsCell = FromCell(evt.PsCell)
+	}
+	for _, erabID := range evt.ErabIDs {
+		m.ErabIds = append(m.ErabIds, uint32(erabID))
+	}
+	return m
+}
+
+//ToDcEvent converts a dual connectivity event from its protocol buffers form. Nil message
+//results an event of DC_EVENT_UNKNOWN type, and so does an event type, which is not known to
+//this version. Unknown bearer types result BEARER_TYPE_UNKNOWN.
+func ToDcEvent(m *DcEvent) uenibreader.DcEvent {
+	evt := uenibreader.DcEvent{
+		EventType: toDcEventType(m.GetEventType()),
+		GNb:       m.GetGNb(),
+		UeID:      ToUeID(m.GetUeId()),
+		PsCell:    ToCell(m.GetPsCell()),
+	}
+	for _, t := range m.GetS1UlGtpTunnels() {
+		evt.S1ULGtpTunnels = append(evt.S1ULGtpTunnels, uenibreader.DcEventTunnel{
+			Addr:      t.GetAddr(),
+			Teid:      t.GetTeid(),
+			ErabID:    uenib.ErabID(t.GetErabId()),
+			HasErabID: t.GetHasErabId(),
+		})
+	}
+	for _, b := range m.GetBearerTypes() {
+		evt.BearerTypes = append(evt.BearerTypes, uenibreader.DcEventBearerType{
+			ErabID:     uenib.ErabID(b.GetErabId()),
+			BearerType: toBearerType(b.GetBearerType()),
+		})
+	}
+	for _, erabID := range m.GetErabIds() {
+		evt.ErabIDs = append(evt.ErabIDs, uenib.ErabID(erabID))
+	}
+	return evt
+}
+
+//toBearerType converts a bearer type from its protocol buffers form. Values, which are not
+//known to this version, result BEARER_TYPE_UNKNOWN.
+func toBearerType(bearerType BearerType) uenib.BearerType {
+	if _, ok := BearerType_name[int32(bearerType)]; !ok {
+		return uenib.BEARER_TYPE_UNKNOWN
+	}
+	return uenib.BearerType(bearerType)
+}
+
+//toDcEventType converts a dual connectivity event type from its protocol buffers form. Values,
+//which are not known to this version, result DC_EVENT_UNKNOWN.
+func toDcEventType(eventType DcEventType) uenibreader.DcEventType {
+	if _, ok := DcEventType_name[int32(eventType)]; !ok {
+		return uenibreader.DC_EVENT_UNKNOWN
+	}
+	return uenibreader.DcEventType(eventType)
+}
+
+//toTransportAddressBytes converts a valid address string of uenib.TunnelEndpoint to 4 (IPv4),
+//16 (IPv6) or 20 (IPv4 and IPv6) bytes.
+func toTransportAddressBytes(addr string) []byte {
+	if addrs := strings.Split(addr, "+"); len(addrs) == 2 {
+		return append(append([]byte{}, net.ParseIP(addrs[0]).To4()...), net.ParseIP(addrs[1]).To16()...)
+	}
+	if strings.Contains(addr, ":") {
+		return []byte(net.ParseIP(addr).To16())
+	}
+	return []byte(net.ParseIP(addr).To4())
+}
+
+//toTransportAddressString converts 4 (IPv4), 16 (IPv6) or 20 (IPv4 and IPv6) bytes to an
+//address string of uenib.TunnelEndpoint.
+func toTransportAddressString(addr []byte) (string, error) {
+	switch len(addr) {
+	case net.IPv4len, net.IPv6len:
+		return net.IP(addr).String(), nil
+	case net.IPv4len + net.IPv6len:
+		return net.IP(addr[:net.IPv4len]).String() + "+" + net.IP(addr[net.IPv4len:]).String(), nil
+	}
+	return "", fmt.Errorf("invalid transport layer address length %d", len(addr))
+}
@@ -0,0 +1,175 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibpb_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibpb"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var someUeID = uenib.UeID{
+	GNb:         "somegnb:310-410-b5c67788",
+	GNbUeX2ApID: "100",
+	ENbUeX2ApID: "200",
+}
+
+func TestUeIDConversion(t *testing.T) {
+	m := uenibpb.FromUeID(someUeID)
+	assert.Equal(t, "somegnb:310-410-b5c67788", m.GetGNb())
+	assert.Equal(t, "100", m.GetGNbUeX2ApId())
+	assert.Equal(t, "200", m.GetENbUeX2ApId())
+	assert.Equal(t, someUeID, uenibpb.ToUeID(m))
+	assert.Equal(t, uenib.UeID{}, uenibpb.ToUeID(nil))
+}
+
+func TestBearerConversion(t *testing.T) {
+	s1ul, _ := uenib.NewTunnelEndpoint("10.20.30.40+2001:db8::68", 5000)
+	x2u, _ := uenib.NewTunnelEndpoint("10.20.30.41", 6000)
+	bearer := uenib.Bearer{
+		ErabID:     5,
+		DrbID:      6,
+		ArpPL:      7,
+		Qci:        9,
+		BearerType: uenib.BEARER_TYPE_SPLIT,
+		S1ULGtpTE:  s1ul,
+		X2UGtpTE:   x2u,
+	}
+	m, err := uenibpb.FromBearer(bearer)
+	assert.Nil(t, err)
+	assert.Equal(t, uenibpb.BearerType_BEARER_TYPE_SPLIT, m.GetBearerType())
+	assert.Equal(t, uint32(5000), m.GetS1UlGtpTe().GetTeid())
+	assert.Equal(t, []byte{10, 20, 30, 41}, m.GetX2UGtpTe().GetAddress())
+	assert.Nil(t, m.GetS1DlGtpTe())
+	assert.Equal(t, []byte{10, 20, 30, 40, 0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x68},
+		m.GetS1UlGtpTe().GetAddress())
+	retBearer, err := uenibpb.ToBearer(m)
+	assert.Nil(t, err)
+	assert.Equal(t, bearer, retBearer)
+}
+
+func TestTunnelEndpointConversionStoredByWriter(t *testing.T) {
+	tep := uenib.TunnelEndpoint{Address: []byte("10.20.30.40"), Teid: []byte("1999")}
+	m, err := uenibpb.FromTunnelEndpoint(tep)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{10, 20, 30, 40}, m.GetAddress())
+	assert.Equal(t, uint32(1999), m.GetTeid())
+
+	retTep, err := uenibpb.ToTunnelEndpoint(m)
+	assert.Nil(t, err)
+	assert.Equal(t, tep, retTep)
+}
+
+func TestFromBearerReturnsErrorIfInvalidTeid(t *testing.T) {
+	bearer := uenib.Bearer{
+		ErabID:    5,
+		S1ULGtpTE: uenib.TunnelEndpoint{Address: []byte("10.20.30.40"), Teid: []byte{0, 0, 0x07, 0xcf}},
+	}
+	_, err := uenibpb.FromBearer(bearer)
+	assert.EqualError(t, err, "E-RAB ID 5 S1-U UL tunnel endpoint: invalid TEID '\x00\x00\a\xcf'")
+}
+
+func TestFromBearerReturnsErrorIfInvalidAddress(t *testing.T) {
+	bearer := uenib.Bearer{
+		ErabID:   5,
+		X2UGtpTE: uenib.TunnelEndpoint{Address: []byte("10.20.30"), Teid: []byte("1999")},
+	}
+	_, err := uenibpb.FromBearer(bearer)
+	assert.EqualError(t, err, "E-RAB ID 5 X2-U tunnel endpoint: invalid transport layer address '10.20.30'")
+}
+
+func TestToBearerReturnsErrorIfInvalidAddressLength(t *testing.T) {
+	m := &uenibpb.Bearer{ErabId: 5, S1DlGtpTe: &uenibpb.TunnelEndpoint{Address: []byte{1, 2, 3}, Teid: 1999}}
+	_, err := uenibpb.ToBearer(m)
+	assert.EqualError(t, err, "E-RAB ID 5 S1-U DL tunnel endpoint: invalid transport layer address length 3")
+}
+
+func TestCellConversion(t *testing.T) {
+	cell := uenib.Cell{
+		Pci:         500,
+		SsbFreq:     632628,
+		NrCgi:       uenib.NrCgi{Mcc: "310", Mnc: "410", NrCellID: 0xb5c677880},
+		Measurement: &uenib.CellMeasurement{Rsrp: 60, Rsrq: 70, Sinr: 80},
+	}
+	m := uenibpb.FromCell(cell)
+	assert.Equal(t, uint64(0xb5c677880), m.GetNrCgi().GetNrCellId())
+	assert.Equal(t, uint32(70), m.GetMeasurement().GetRsrq())
+	assert.Equal(t, cell, uenibpb.ToCell(m))
+
+	cell = uenib.Cell{Pci: 1, SsbFreq: 2}
+	m = uenibpb.FromCell(cell)
+	assert.Nil(t, m.GetNrCgi())
+	assert.Nil(t, m.GetMeasurement())
+	assert.Equal(t, cell, uenibpb.ToCell(m))
+}
+
+func TestUeStateConversion(t *testing.T) {
+	state := uenib.UeState{Event: "1582638562.133 SgNBAdditionRequest", Cause: "radioNetwork:unspecified"}
+	assert.Equal(t, state, uenibpb.ToUeState(uenibpb.FromUeState(state)))
+}
+
+func TestDcEventConversion(t *testing.T) {
+	evts := []uenibreader.DcEvent{
+		{
+			EventType: uenibreader.DC_EVENT_S1UL_TUNNEL_ESTABLISH,
+			UeID:      someUeID,
+			S1ULGtpTunnels: []uenibreader.DcEventTunnel{
+				{Addr: "10.20.30.40+2001:db8::68", Teid: 5000, ErabID: 5, HasErabID: true},
+			},
+		},
+		{
+			EventType: uenibreader.DC_EVENT_BEARER_TYPE_CHANGE,
+			UeID:      someUeID,
+			BearerTypes: []uenibreader.DcEventBearerType{
+				{ErabID: 5, BearerType: uenib.BEARER_TYPE_SCG},
+			},
+		},
+		{
+			EventType: uenibreader.DC_EVENT_PSCELL_CHANGE,
+			UeID:      someUeID,
+			PsCell:    uenib.Cell{Pci: 500, SsbFreq: 632628, NrCgi: uenib.NrCgi{Mcc: "310", Mnc: "410", NrCellID: 1}},
+		},
+		{
+			EventType: uenibreader.DC_EVENT_BEARER_MODIFY,
+			UeID:      someUeID,
+			ErabIDs:   []uenib.ErabID{5, 7},
+		},
+		{
+			EventType: uenibreader.DC_EVENT_GNB_ALL_UES_REMOVE,
+			GNb:       "somegnb:310-410-b5c67788",
+		},
+	}
+	for _, evt := range evts {
+		m := uenibpb.FromDcEvent(evt)
+		assert.Equal(t, uenibpb.DcEventType(evt.EventType), m.GetEventType())
+		assert.Equal(t, evt, uenibpb.ToDcEvent(m))
+	}
+	assert.Nil(t, uenibpb.FromDcEvent(evts[4]).GetUeId())
+	assert.Equal(t, uenibreader.DcEvent{}, uenibpb.ToDcEvent(nil))
+}
+
+func TestUnknownEnumValuesAreConvertedToUnknown(t *testing.T) {
+	evt := uenibpb.ToDcEvent(&uenibpb.DcEvent{
+		EventType:   uenibpb.DcEventType(100),
+		BearerTypes: []*uenibpb.DcEventBearerType{{ErabId: 5, BearerType: uenibpb.BearerType(-1)}},
+	})
+	assert.Equal(t, uenibreader.DC_EVENT_UNKNOWN, evt.EventType)
+	assert.Equal(t, "UNKNOWN", evt.EventType.String())
+	assert.Equal(t, uenib.BEARER_TYPE_UNKNOWN, evt.BearerTypes[0].BearerType)
+	bearer, err := uenibpb.ToBearer(&uenibpb.Bearer{ErabId: 5, BearerType: uenibpb.BearerType(4)})
+	assert.Nil(t, err)
+	assert.Equal(t, uenib.BEARER_TYPE_UNKNOWN, bearer.BearerType)
+}
+
+func TestEnumValuesMatch(t *testing.T) {
+	assert.Equal(t, "BEARER_TYPE_MCG", uenibpb.BearerType(uenib.BEARER_TYPE_MCG).String())
+	assert.Equal(t, "DC_EVENT_BEARER_MODIFY", uenibpb.DcEventType(uenibreader.DC_EVENT_BEARER_MODIFY).String())
+	assert.Equal(t, "DC_EVENT_GNB_ALL_UES_REMOVE", uenibpb.DcEventType(uenibreader.DC_EVENT_GNB_ALL_UES_REMOVE).String())
+}
@@ -8,6 +8,7 @@
 package uenibreader
 
 import (
+	"errors"
 	"fmt"
 	"github.com/nokia/ue-nib-library/pkg/uenib"
 )
@@ -18,112 +19,158 @@ type Error interface {
 	Temporary() bool //Returns true if an error is temporary and it is recommended to re-try failed operation
 }
 
-//A valueNotFoundFailure is UE-NIB private type for a circumstance when queried value is not found from database.
-type valueNotFoundFailure struct {
-	ueID      uenib.UeID //Identity of a user in question
-	name      string     //Parameter name
-	temporary bool       //Defines whether the error is temporary or not
-}
-
-//An validationError is UE-NIB private type to hold classification data of validation type of error.
-type validationError struct {
-	ueID      uenib.UeID //Identity of a user in question
-	err       string     //Error message
-	temporary bool       //Defines whether the error is temporary or not
-}
-
-//An internalError is UE-NIB private type to hold classification data of internal type of error.
-type internalError struct {
-	ueID      uenib.UeID //Identity of a user in question
-	err       string     //Error message
-	temporary bool       //Defines whether the error is temporary or not
-}
-
-//A backendError is UE-NIB private type to hold classification data of database backend error
-//type of error. Backend errors are temporal by their nature. UE-NIB API user is adviced to try
-//again failed UE-NIB API operation.
-type backendError struct {
-	ueID      uenib.UeID //Identity of a user in question
-	err       string     //Error message
-	temporary bool       //Defines whether the error is temporary or not
-}
+//ErrorClass classifies UE-NIB errors.
+type ErrorClass int
+
+const (
+	ERROR_CLASS_UNKNOWN ErrorClass = iota
+	//Queried value is not found from database. Failure is temporary.
+	ERROR_CLASS_VALUE_NOT_FOUND
+	//Invalid parameter or database value. Error is permanent.
+	ERROR_CLASS_VALIDATION
+	//Internal error of UE-NIB. Error is permanent.
+	ERROR_CLASS_INTERNAL
+	//Database backend error. Error is temporary.
+	ERROR_CLASS_BACKEND
+	//UE-NIB writer modified the data while it was read. Error is temporary.
+	ERROR_CLASS_CONCURRENT_MODIFICATION
+)
 
-//Error implements built-in error interface for valueNotFoundFailure type.
-func (e *valueNotFoundFailure) Error() string {
-	return fmt.Sprintf("UE-NIB %s value of DB key '%s' not found", e.ueID.String(), e.name)
-}
+//Sentinel errors of the error classes. A UE-NIB error can be tested against them with errors.Is():
+//
+//	if errors.Is(err, uenibreader.ErrNotFound) {
+//		...
+//	}
+var (
+	ErrNotFound               = errors.New("UE-NIB value not found")
+	ErrValidation             = errors.New("UE-NIB validation error")
+	ErrInternal               = errors.New("UE-NIB internal error")
+	ErrBackend                = errors.New("UE-NIB database backend error")
+	ErrConcurrentModification = errors.New("UE-NIB concurrent modification")
+)
 
-//IsValueNotFoundFailure returns true if failure is UE-NIB valueNotFoundFailure type.
-func IsValueNotFoundFailure(e interface{}) bool {
-	if _, ok := e.(*valueNotFoundFailure); ok {
-		return true
+//UeNibError is the error type of the errors returned by Reader. It implements Error interface.
+//A UE-NIB error can be inspected with errors.As():
+//
+//	var ueNibErr *uenibreader.UeNibError
+//	if errors.As(err, &ueNibErr) {
+//		log.Printf("%s of key '%s' failed", ueNibErr.Operation, ueNibErr.Key)
+//	}
+//
+//Unwrap() returns the cause of the error, for example the error returned by the SDL database.
+type UeNibError struct {
+	Class     ErrorClass //Class of the error
+	UeID      uenib.UeID //Identity of a user in question
+	Key       string     //Database key in question, space separated keys for a failed read of several keys
+	Operation string     //Name of the failed Reader method
+	Err       error      //Cause of the error, if any
+	msg       string     //Error message
+	attempts  int        //Number of attempts made, zero if not retried
+}
+
+//Error implements built-in error interface for UeNibError type.
+func (e *UeNibError) Error() string {
+	switch e.Class {
+	case ERROR_CLASS_VALUE_NOT_FOUND:
+		return fmt.Sprintf("UE-NIB %s value of DB key '%s' not found", e.UeID.String(), e.Key)
+	case ERROR_CLASS_VALIDATION:
+		return fmt.Sprintf("UE-NIB %s validation error: %s", e.UeID.String(), e.msg)
+	case ERROR_CLASS_INTERNAL:
+		return fmt.Sprintf("UE-NIB %s internal error: %s", e.UeID.String(), e.msg)
+	case ERROR_CLASS_BACKEND:
+		return fmt.Sprintf("UE-NIB %s database backend error: %s", e.UeID.String(), e.msg)
+	case ERROR_CLASS_CONCURRENT_MODIFICATION:
+		return fmt.Sprintf("UE-NIB %s concurrent modification: %s", e.UeID.String(), e.msg)
+	}
+	return fmt.Sprintf("UE-NIB %s error: %s", e.UeID.String(), e.msg)
+}
+
+//Temporary implements Error interface for UeNibError type.
+//Returns true for value not found failures, database backend errors and concurrent modifications.
+//They are temporal and hence it is recommended to re-try failed UE-NIB operation. Other errors
+//are permanent and hence are not worth to re-try.
+func (e *UeNibError) Temporary() bool {
+	return e.Class == ERROR_CLASS_VALUE_NOT_FOUND || e.Class == ERROR_CLASS_BACKEND ||
+		e.Class == ERROR_CLASS_CONCURRENT_MODIFICATION
+}
+
+//Unwrap returns the cause of the error, or nil.
+func (e *UeNibError) Unwrap() error {
+	return e.Err
+}
+
+//Is returns true if the target is the sentinel error of the error class.
+func (e *UeNibError) Is(target error) bool {
+	switch e.Class {
+	case ERROR_CLASS_VALUE_NOT_FOUND:
+		return target == ErrNotFound
+	case ERROR_CLASS_VALIDATION:
+		return target == ErrValidation
+	case ERROR_CLASS_INTERNAL:
+		return target == ErrInternal
+	case ERROR_CLASS_BACKEND:
+		return target == ErrBackend
+	case ERROR_CLASS_CONCURRENT_MODIFICATION:
+		return target == ErrConcurrentModification
 	}
 	return false
 }
 
-//Temporary implements Error interface for valueNotFoundFailure type.
-//Returns always true for a valueNotFoundFailure error type. Failure is temporal and hence it is
-//recommended to re-try failed UE-NIB operation.
-func (e *valueNotFoundFailure) Temporary() bool {
-	return e.temporary
+//FailFastError can be implemented by an error of a database backend, which has rejected a call
+//without trying the database, for example because a circuit breaker is open. Reader does not
+//retry such an error, see RetryPolicy, and logs it on LOG_LEVEL_DEBUG instead of LOG_LEVEL_ERROR.
+type FailFastError interface {
+	error
+	FailFast() bool
 }
 
-//Error implements built-in error interface for validationError type.
-func (e *validationError) Error() string {
-	return fmt.Sprintf("UE-NIB %s validation error: %s", e.ueID.String(), e.err)
+func isFailFast(err error) bool {
+	var e FailFastError
+	return errors.As(err, &e) && e.FailFast()
 }
 
-//IsValidationError returns true if an error is UE-NIB validationError type.
-func IsValidationError(e interface{}) bool {
-	if _, ok := e.(*validationError); ok {
-		return true
-	}
-	return false
+//IsValueNotFoundFailure returns true if failure is UE-NIB value not found failure.
+func IsValueNotFoundFailure(e interface{}) bool {
+	return isErrorOfClass(e, ErrNotFound)
 }
 
-//Temporary implements Error interface for validationError type.
-//Returns always false for a validationError error type. Error is permanent and hence is not worth
-//to re-try failed UE-NIB operation.
-func (e *validationError) Temporary() bool {
-	return e.temporary
+//IsValidationError returns true if an error is UE-NIB validation error.
+func IsValidationError(e interface{}) bool {
+	return isErrorOfClass(e, ErrValidation)
 }
 
-//Error implements built-in error interface for internalError type.
-func (e *internalError) Error() string {
-	return fmt.Sprintf("UE-NIB %s internal error: %s", e.ueID.String(), e.err)
+//IsInternalError returns true if an error is UE-NIB internal error.
+func IsInternalError(e interface{}) bool {
+	return isErrorOfClass(e, ErrInternal)
 }
 
-//IsInternalError returns true if an error is UE-NIB internalError type.
-func IsInternalError(e interface{}) bool {
-	if _, ok := e.(*internalError); ok {
-		return true
-	}
-	return false
+//IsBackendError returns true if an error is UE-NIB database backend error.
+func IsBackendError(e interface{}) bool {
+	return isErrorOfClass(e, ErrBackend)
 }
 
-//Temporary implements Error interface for internalError type.
-//Returns always false for an internalError error type. Error is permanent and hence is not worth
-//to re-try failed UE-NIB operation.
-func (e *internalError) Temporary() bool {
-	return e.temporary
+//IsConcurrentModificationError returns true if an error is UE-NIB concurrent modification error.
+func IsConcurrentModificationError(e interface{}) bool {
+	return isErrorOfClass(e, ErrConcurrentModification)
 }
 
-//Error implements built-in error interface for backendError type.
-func (e *backendError) Error() string {
-	return fmt.Sprintf("UE-NIB %s database backend error: %s", e.ueID.String(), e.err)
+func isErrorOfClass(e interface{}, sentinel error) bool {
+	err, ok := e.(error)
+	return ok && errors.Is(err, sentinel)
 }
 
-//IsBackendError returns true if an error is UE-NIB backendError type.
-func IsBackendError(e interface{}) bool {
-	if _, ok := e.(*backendError); ok {
-		return true
+func asUeNibError(err error) *UeNibError {
+	var e *UeNibError
+	if errors.As(err, &e) {
+		return e
 	}
-	return false
+	return nil
 }
 
-//Temporary implements Error interface for backendError type.
-//Returns always true for a backendError error type. Error is temporal and hence it is recommended
-//to re-try failed UE-NIB operation.
-func (e *backendError) Temporary() bool {
-	return e.temporary
+//withKey sets the database key of a UE-NIB error.
+func withKey(err error, key string) error {
+	if e := asUeNibError(err); e != nil && e.Key == "" {
+		e.Key = key
+	}
+	return err
 }
@@ -0,0 +1,96 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+func TestBackendErrorWrapsCause(t *testing.T) {
+	m, reader := setup()
+	dbError := errors.New("Some DB Backend Error")
+	ueID := &uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"}
+	m.On("Get", "uenib/somegnb", []string{"1,UE_ERAB_IDS"}).Return(nil, dbError).Once()
+
+	_, err := reader.GetBearerIDs(ueID)
+	assert.True(t, errors.Is(err, dbError))
+	assert.True(t, errors.Is(err, uenibreader.ErrBackend))
+	assert.False(t, errors.Is(err, uenibreader.ErrNotFound))
+
+	var ueNibErr *uenibreader.UeNibError
+	assert.True(t, errors.As(fmt.Errorf("query: %w", err), &ueNibErr))
+	assert.Equal(t, uenibreader.ERROR_CLASS_BACKEND, ueNibErr.Class)
+	assert.Equal(t, *ueID, ueNibErr.UeID)
+	assert.Equal(t, "1,UE_ERAB_IDS", ueNibErr.Key)
+	assert.Equal(t, "GetBearerIDs", ueNibErr.Operation)
+	assert.True(t, ueNibErr.Temporary())
+	assert.True(t, uenibreader.IsBackendError(fmt.Errorf("query: %w", err)))
+}
+
+func TestValueNotFoundFailureHasKey(t *testing.T) {
+	m, reader := setup()
+	ueID := &uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"}
+	m.On("Get", "uenib/somegnb", []string{"1,UE_ERAB_IDS"}).Return(
+		map[string]interface{}{"1,UE_ERAB_IDS": nil}, nil).Once()
+
+	_, err := reader.GetBearerIDs(ueID)
+	assert.True(t, errors.Is(err, uenibreader.ErrNotFound))
+	var ueNibErr *uenibreader.UeNibError
+	assert.True(t, errors.As(err, &ueNibErr))
+	assert.Equal(t, uenibreader.ERROR_CLASS_VALUE_NOT_FOUND, ueNibErr.Class)
+	assert.Equal(t, "1,UE_ERAB_IDS", ueNibErr.Key)
+	assert.Nil(t, errors.Unwrap(err))
+	assert.EqualError(t, err, "UE-NIB "+ueID.String()+" value of DB key '1,UE_ERAB_IDS' not found")
+}
+
+func TestValidationErrorOfMalformedValueHasKeyAndCause(t *testing.T) {
+	m, reader := setup()
+	ueID := &uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"}
+	m.On("Get", "uenib/somegnb", []string{"1,UE_ERAB_IDS"}).Return(
+		map[string]interface{}{"1,UE_ERAB_IDS": "5,x"}, nil).Once()
+
+	_, err := reader.GetBearerIDs(ueID)
+	assert.True(t, errors.Is(err, uenibreader.ErrValidation))
+	assert.False(t, uenibreader.IsValueNotFoundFailure(err))
+	var ueNibErr *uenibreader.UeNibError
+	assert.True(t, errors.As(err, &ueNibErr))
+	assert.Equal(t, "1,UE_ERAB_IDS", ueNibErr.Key)
+	assert.False(t, ueNibErr.Temporary())
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(err, &numErr))
+	assert.Equal(t, "x", numErr.Num)
+}
+
+func TestValidationErrorOfInvalidParameter(t *testing.T) {
+	_, reader := setup()
+	_, err := reader.GetPsCell(&uenib.UeID{ENbUeX2ApID: "1"})
+	assert.True(t, errors.Is(err, uenibreader.ErrValidation))
+	var ueNibErr *uenibreader.UeNibError
+	assert.True(t, errors.As(err, &ueNibErr))
+	assert.Equal(t, "GetPsCell", ueNibErr.Operation)
+	assert.Equal(t, "", ueNibErr.Key)
+
+	err = reader.SubscribeEvents([]string{"somegnb"}, []uenibreader.EventCategory{uenibreader.EventCategory(10)},
+		func(gNb string, eventCategory uenibreader.EventCategory, events []string) {})
+	assert.True(t, errors.Is(err, uenibreader.ErrValidation))
+	assert.True(t, errors.As(err, &ueNibErr))
+	assert.Equal(t, "SubscribeEvents", ueNibErr.Operation)
+}
+
+func TestIsErrorHelpersOfOtherValues(t *testing.T) {
+	assert.False(t, uenibreader.IsBackendError(nil))
+	assert.False(t, uenibreader.IsBackendError("UE-NIB database backend error"))
+	assert.False(t, uenibreader.IsInternalError(errors.New("some error")))
+	assert.True(t, uenibreader.IsInternalError(uenibreader.ErrInternal))
+}
@@ -32,6 +32,13 @@ const (
 	//        -S1 uplink tunnel released.
 	//    GNB_ALL_UES_REMOVE
 	//        All UEs within the gNB were removed.
+	//    <UE_ID>_<BEARER_TYPES>_BEARER_TYPE_CHANGE
+	//        -EN-DC bearer option of one or more bearers has been changed.
+	//    <UE_ID>_<PSCELL>_PSCELL_CHANGE
+	//        -UE's PSCell has been changed. The same event may be published as an alias in the
+	//         Mobility category, so a subscriber of both categories should handle it in one.
+	//    <UE_ID>_<ERAB_IDS>_BEARER_MODIFY
+	//        -QoS parameters or DRB mapping of one or more bearers has been modified.
 	//
 	//<UE_ID> identifies a UE in question. It consists of three sub-fields separated by
 	//hashtag '#':
@@ -42,11 +49,66 @@ const (
 	//<Transport address>#<GTP TEID>. IP address and TEID are strings. IP address string can
 	//contain an IPv4 dotted decimal ("192.0.2.1"), an IPv6 ("2001:db8::68") or a dual address
 	//where IPv4 and IPv6 addresses are separated by '+' character.
+	//Optionally the tunnel endpoint can be preceded by the E-RAB ID of the bearer:
+	//<E-RAB ID>#<Transport address>#<GTP TEID>. Either all or none of the tunnel endpoints of
+	//an event have the E-RAB ID sub-field.
 	//Note that multiple S1 uplink GTP tunnel endpoints can be notified by a single event.
 	//Multiple S1 uplink GTP tunnel endpoints are separated by hashtag '#' in an event string.
+	//<BEARER_TYPES> identifies bearers and their new bearer types. It consists of two sub-fields
+	//separated by hashtag '#':
+	//<E-RAB ID>#<Bearer type>. Bearer type is one of the strings "MCG", "SCG" or "SPLIT".
+	//Multiple bearers are separated by hashtag '#' in an event string.
+	//<PSCELL> identifies UE's new PSCell. It consists of two or three sub-fields separated by
+	//hashtag '#':
+	//<PCI>#<SSB NR-ARFCN>[#<NR-CGI>]. NR-CGI is optional and it is form of:
+	//<3 MCC digits>-<2 or 3 MNC digits>-<NR Cell Identity in hex>.
+	//<ERAB_IDS> identifies modified bearers. It consists of one or more E-RAB IDs separated by
+	//hashtag '#'.
+	//
+	//Events can also be published in the versioned event encoding, see EventEncodingVersion.
+	//ParseDcEvent() detects the encoding and parses both forms.
 	DualConnectivity EventCategory = iota
+
+	//Mobility events are triggered after UE's mobility procedures affecting secondary node.
+	//
+	//Following events are possible in this category:
+	//    <UE_ID>_<PSCELL>_PSCELL_CHANGE
+	//        -UE's PSCell has been changed within the gNB. This is an alias of the PSCELL_CHANGE
+	//         event of the DualConnectivity category, which is the primary category of PSCell
+	//         changes. The event string is the same and it is parsed to the same UE ID and PSCell.
+	//    <UE_ID>_<TARGET_GNB>_SGNB_CHANGE
+	//        -UE has been moved from the gNB to another SgNB.
+	//    <UE_ID>_<TARGET_ENB>#<TARGET_ENB_UE_X2AP_ID>_INTER_MENB_HANDOVER
+	//        -UE has been handed over to another MeNB and the gNB remains as UE's SgNB.
+	//
+	//<UE_ID> and <PSCELL> are as in the DualConnectivity category.
+	//<TARGET_GNB> is the RanName of the target SgNB.
+	//<TARGET_ENB> identifies the target MeNB and <TARGET_ENB_UE_X2AP_ID> is the new MeNB UE
+	//X2AP ID allocated by the target MeNB.
+	//
+	//Events can also be published in the versioned event encoding, see EventEncodingVersion.
+	//ParseMobilityEvent() detects the encoding and parses both forms.
+	Mobility
 )
 
+//eventCategoryInfo defines the properties of an event category. New event categories are
+//added by declaring a constant above and adding a row to the eventCategoryTable.
+type eventCategoryInfo struct {
+	name  string                                   //Category name used in the event channel name.
+	parse func(evtStr string) (interface{}, error) //Category specific event parser.
+}
+
+var eventCategoryTable = map[EventCategory]eventCategoryInfo{
+	DualConnectivity: {
+		name:  "DUAL_CONNECTIVITY",
+		parse: func(evtStr string) (interface{}, error) { return ParseDcEvent(evtStr) },
+	},
+	Mobility: {
+		name:  "MOBILITY",
+		parse: func(evtStr string) (interface{}, error) { return ParseMobilityEvent(evtStr) },
+	},
+}
+
 //DcEventType defines possible dual connectivity event types.
 type DcEventType int
 
@@ -57,13 +119,22 @@ const (
 	DC_EVENT_S1UL_TUNNEL_ESTABLISH
 	DC_EVENT_S1UL_TUNNEL_RELEASE
 	DC_EVENT_GNB_ALL_UES_REMOVE
+	DC_EVENT_BEARER_TYPE_CHANGE
+	DC_EVENT_PSCELL_CHANGE
+	DC_EVENT_BEARER_MODIFY
 )
 
 //DcEvent defines all the entities what can be parsed from received dual connectivity event.
+//GNb identifies the gNB of DC_EVENT_GNB_ALL_UES_REMOVE event. It is available only when
+//the event has been published in the versioned event encoding.
 type DcEvent struct {
 	EventType      DcEventType
+	GNb            string
 	UeID           uenib.UeID
 	S1ULGtpTunnels []DcEventTunnel
+	BearerTypes    []DcEventBearerType
+	PsCell         uenib.Cell
+	ErabIDs        []uenib.ErabID
 }
 
 //DcEventTunnel defines tunnel endpoint address and tunnel identifier, which can
@@ -72,9 +143,19 @@ type DcEvent struct {
 //IPV4 ("192.0.2.1"), IPv6 ("2001:db8::68"). In dual address case both IPv4 and
 //IPv6 addresses are set to 'Addr' field separated by '+' character.
 //Field 'Teid' is Tunnel Endpoint ID (TEID) in host byte order.
+//Field 'ErabID' identifies the bearer of the tunnel, it is valid only if 'HasErabID' is true.
 type DcEventTunnel struct {
-	Addr string
-	Teid uint32
+	Addr      string
+	Teid      uint32
+	ErabID    uenib.ErabID
+	HasErabID bool
+}
+
+//DcEventBearerType defines bearer identifier (E-RAB ID) and bearer's new EN-DC bearer
+//option, which can be parsed from received event.
+type DcEventBearerType struct {
+	ErabID     uenib.ErabID
+	BearerType uenib.BearerType
 }
 
 //String returns dual connectivity event type as a string.
@@ -86,8 +167,11 @@ func (dcEvt DcEventType) String() string {
 		"_S1UL_TUNNEL_ESTABLISH",
 		"_S1UL_TUNNEL_RELEASE",
 		"GNB_ALL_UES_REMOVE",
+		"_BEARER_TYPE_CHANGE",
+		"_PSCELL_CHANGE",
+		"_BEARER_MODIFY",
 	}
-	if dcEvt > DC_EVENT_GNB_ALL_UES_REMOVE {
+	if dcEvt > DC_EVENT_BEARER_MODIFY {
 		panic(fmt.Sprintf("DC event ID %d overflows name string array.\n", dcEvt))
 	}
 	return evtStrMap[dcEvt]
@@ -95,11 +179,21 @@ func (dcEvt DcEventType) String() string {
 
 //String returns event category as a string.
 func (category EventCategory) String() string {
-	categories := [...]string{"DUAL_CONNECTIVITY"}
-	if category < DualConnectivity || category > DualConnectivity {
-		return "Unknown"
+	if info, ok := eventCategoryTable[category]; ok {
+		return info.name
 	}
-	return categories[category]
+	return "Unknown"
+}
+
+//ParseEvent parses an event string of the given event category. It returns a DcEvent for
+//the DualConnectivity category and a MobilityEvent for the Mobility category. An error is
+//returned, if the event category is unknown or parsing has failed.
+func ParseEvent(eventCategory EventCategory, evtStr string) (interface{}, error) {
+	info, ok := eventCategoryTable[eventCategory]
+	if !ok {
+		return nil, newValidationError("Unknown event category ID: %d", eventCategory)
+	}
+	return info.parse(evtStr)
 }
 
 //EventCallback defines the signature for the event callback function.
@@ -107,6 +201,30 @@ func (category EventCategory) String() string {
 //Parameter eventCategory identifies event category.
 type EventCallback func(gNb string, eventCategory EventCategory, events []string)
 
+//Event is a received event string and the result of parsing it with ParseEvent(). Parsed is a
+//DcEvent or a MobilityEvent, which is partially filled in, if parsing has failed with Err.
+type Event struct {
+	Raw    string
+	Parsed interface{}
+	Err    error
+}
+
+//ParsedEventCallback defines the signature for the callback function of parsed events, see
+//EventCallback for the parameters.
+type ParsedEventCallback func(gNb string, eventCategory EventCategory, events []Event)
+
+//UeID returns the UE ID of the parsed event. It is empty, if the event has no UE ID or if
+//parsing has failed before the UE ID.
+func (evt Event) UeID() uenib.UeID {
+	switch parsed := evt.Parsed.(type) {
+	case DcEvent:
+		return parsed.UeID
+	case MobilityEvent:
+		return parsed.UeID
+	}
+	return uenib.UeID{}
+}
+
 //SubscribeEvents is used to subscribe events from UE-NIB data changes
 //(event publishing is done by uenibwriter along with the backend database modification).
 //
@@ -124,27 +242,85 @@ type EventCallback func(gNb string, eventCategory EventCategory, events []string
 //In addition to Error() method defined in built-in error interface a function caller can test
 //returned error value for a reader.Error with a type assertion and then distinguish temporal errors
 //from permanent ones by using Temporary() method. In case of temporal error, the caller of
-//SubscribeEvents() may retry the call after a short period of time.
+//SubscribeEvents() may retry the call after a short period of time. If a retry policy has been
+//set with SetRetryPolicy(), the subscription of each gNB and event category is retried according
+//to it before an error is returned.
 func (reader *Reader) SubscribeEvents(gNbs []string, eventCategories []EventCategory, callback EventCallback) error {
+	return reader.subscribeEvents(gNbs, eventCategories, callback, nil)
+}
+
+//SubscribeParsedEvents subscribes events like SubscribeEvents(), but the callback is called with
+//the parsed events. Each event is parsed once by the Reader, so the callback and the Reader's
+//logging of malformed events share the result of parsing.
+func (reader *Reader) SubscribeParsedEvents(gNbs []string, eventCategories []EventCategory, callback ParsedEventCallback) error {
+	return reader.subscribeEvents(gNbs, eventCategories, nil, callback)
+}
+
+//subscribeEvents subscribes events with either a callback of event strings or a callback of
+//parsed events.
+func (reader *Reader) subscribeEvents(gNbs []string, eventCategories []EventCategory, callback EventCallback,
+	parsedCallback ParsedEventCallback) error {
 	for gNbIndex := range gNbs {
 		for eventCategoriesIndex := range eventCategories {
-			if eventCategories[eventCategoriesIndex].String() == "Unknown" {
-				return newValidationError("Unknown event category ID: %d", eventCategories[eventCategoriesIndex])
+			if _, ok := eventCategoryTable[eventCategories[eventCategoriesIndex]]; !ok {
+				e := newValidationError("Unknown event category ID: %d", eventCategories[eventCategoriesIndex])
+				e.Operation = "SubscribeEvents"
+				return e
 			}
 			channel := gNbs[gNbIndex] + "_" + eventCategories[eventCategoriesIndex].String()
 			ns := internal.GetUeNibNs(gNbs[gNbIndex])
-			err := reader.db.SubscribeChannel(ns, reader.eventCallback(gNbs[gNbIndex], eventCategories[eventCategoriesIndex], callback), channel)
+			cb := reader.eventCallback(gNbs[gNbIndex], eventCategories[eventCategoriesIndex], callback, parsedCallback)
+			err := reader.retry("SubscribeEvents", func() error {
+				if err := reader.db.SubscribeChannel(ns, cb, channel); err != nil {
+					return newBackendError(err)
+				}
+				return nil
+			})
 			if err != nil {
-				return newBackendError(err.Error())
+				reader.logBackendError("event subscription failed", err, "gNb", gNbs[gNbIndex],
+					"eventCategory", eventCategories[eventCategoriesIndex])
+				return err
 			}
+			reader.recordSubscription(gNbs[gNbIndex], eventCategories[eventCategoriesIndex])
+			reader.log(LOG_LEVEL_INFO, "events subscribed", "gNb", gNbs[gNbIndex],
+				"eventCategory", eventCategories[eventCategoriesIndex])
 		}
 	}
 	return nil
 }
 
-func (reader *Reader) eventCallback(gNb string, eventCategory EventCategory, clientCallback EventCallback) func(ch string, ev ...string) {
+//eventCallback returns the database callback of a subscription. The events are parsed only if
+//they are logged or the client callback takes parsed events.
+func (reader *Reader) eventCallback(gNb string, eventCategory EventCategory, clientCallback EventCallback,
+	parsedCallback ParsedEventCallback) func(ch string, ev ...string) {
 	return func(ch string, ev ...string) {
-		clientCallback(gNb, eventCategory, ev)
+		reader.recordEvents(gNb, eventCategory, ev)
+		if reader.logger == nil && parsedCallback == nil {
+			clientCallback(gNb, eventCategory, ev)
+			return
+		}
+		events := make([]Event, 0, len(ev))
+		for _, evtStr := range ev {
+			parsed, err := ParseEvent(eventCategory, evtStr)
+			events = append(events, Event{Raw: evtStr, Parsed: parsed, Err: err})
+		}
+		reader.logMalformedEvents(gNb, eventCategory, events)
+		if parsedCallback != nil {
+			parsedCallback(gNb, eventCategory, events)
+		} else {
+			clientCallback(gNb, eventCategory, ev)
+		}
+	}
+}
+
+//logMalformedEvents logs the received events, which cannot be parsed, with the UE ID parsed
+//before the failure.
+func (reader *Reader) logMalformedEvents(gNb string, eventCategory EventCategory, events []Event) {
+	for _, evt := range events {
+		if evt.Err != nil {
+			reader.log(LOG_LEVEL_WARNING, "malformed event", "gNb", gNb, "eventCategory", eventCategory,
+				"ueID", evt.UeID(), "event", evt.Raw, "error", evt.Err)
+		}
 	}
 }
 
@@ -155,7 +331,17 @@ func (reader *Reader) eventCallback(gNb string, eventCategory EventCategory, cli
 //are returned inside 'DcEvent' type. Note that success status is also returned, when
 //event type in parsed event string is unknown for the parser. In this case event type is
 //set to DC_EVENT_UNKNOWN in returned 'DcEvent' type.
+//
+//Both the legacy event string format and the versioned event encoding are parsed. An error
+//is returned for a versioned event of an unsupported encoding version.
 func ParseDcEvent(evtStr string) (DcEvent, error) {
+	if version, payload, ok := splitVersionedEvent(evtStr); ok {
+		return parseVersionedDcEvent(version, payload)
+	}
+	return parseLegacyDcEvent(evtStr)
+}
+
+func parseLegacyDcEvent(evtStr string) (DcEvent, error) {
 	var err error
 	var ret DcEvent
 	evtFieldStr := parseDcEventType(evtStr, &ret)
@@ -169,6 +355,12 @@ func ParseDcEvent(evtStr string) (DcEvent, error) {
 		err = parseUeFromDcEvent(evtFieldStr, &ret)
 	case DC_EVENT_REMOVE:
 		err = parseUeFromDcEvent(evtFieldStr, &ret)
+	case DC_EVENT_BEARER_TYPE_CHANGE:
+		err = parseDcBearerTypeEvent(evtFieldStr, &ret)
+	case DC_EVENT_PSCELL_CHANGE:
+		err = parseDcPsCellEvent(evtFieldStr, &ret)
+	case DC_EVENT_BEARER_MODIFY:
+		err = parseDcBearerModifyEvent(evtFieldStr, &ret)
 	}
 	return ret, err
 }
@@ -186,6 +378,18 @@ func parseDcEventType(evtStr string, ret *DcEvent) string {
 		ret.EventType = DC_EVENT_S1UL_TUNNEL_RELEASE
 		return strings.TrimSuffix(evtStr, ret.EventType.String())
 	}
+	if matched := strings.HasSuffix(evtStr, DC_EVENT_BEARER_TYPE_CHANGE.String()); matched {
+		ret.EventType = DC_EVENT_BEARER_TYPE_CHANGE
+		return strings.TrimSuffix(evtStr, ret.EventType.String())
+	}
+	if matched := strings.HasSuffix(evtStr, DC_EVENT_PSCELL_CHANGE.String()); matched {
+		ret.EventType = DC_EVENT_PSCELL_CHANGE
+		return strings.TrimSuffix(evtStr, ret.EventType.String())
+	}
+	if matched := strings.HasSuffix(evtStr, DC_EVENT_BEARER_MODIFY.String()); matched {
+		ret.EventType = DC_EVENT_BEARER_MODIFY
+		return strings.TrimSuffix(evtStr, ret.EventType.String())
+	}
 	if matched := strings.HasSuffix(evtStr, DC_EVENT_ADD.String()); matched {
 		ret.EventType = DC_EVENT_ADD
 		return strings.TrimSuffix(evtStr, ret.EventType.String())
@@ -209,39 +413,174 @@ func parseDcTunnelEvent(evtStr string, ret *DcEvent) error {
 	return parseTunnelsFromDcEvent(fields[1], ret)
 }
 
+func parseDcBearerTypeEvent(evtStr string, ret *DcEvent) error {
+	fields := strings.Split(evtStr, "_")
+	if cnt := len(fields); cnt != 2 {
+		return fmt.Errorf("Event '%s' parse failure: no UE ID or bearer type field in '%s'",
+			ret.EventType.String(), evtStr)
+	}
+	if err := parseUeFromDcEvent(fields[0], ret); err != nil {
+		return err
+	}
+	return parseBearerTypesFromDcEvent(fields[1], ret)
+}
+
+func parseDcPsCellEvent(evtStr string, ret *DcEvent) error {
+	fields := strings.Split(evtStr, "_")
+	if cnt := len(fields); cnt != 2 {
+		return fmt.Errorf("Event '%s' parse failure: no UE ID or PSCell field in '%s'",
+			ret.EventType.String(), evtStr)
+	}
+	if err := parseUeFromDcEvent(fields[0], ret); err != nil {
+		return err
+	}
+	return parsePsCellFromDcEvent(fields[1], ret)
+}
+
 func parseUeFromDcEvent(ueEvtStr string, ret *DcEvent) error {
+	var err error
+	ret.UeID, err = parseUeIDFromEvent(ret.EventType.String(), ueEvtStr)
+	return err
+}
+
+func parseUeIDFromEvent(evtName string, ueEvtStr string) (uenib.UeID, error) {
+	var ueID uenib.UeID
 	ueFields := strings.Split(ueEvtStr, "#")
 	if cnt := len(ueFields); cnt != 3 {
-		return fmt.Errorf("Event '%s' parse failure: wrong UE ID fields in '%s'",
-			ret.EventType.String(), ueEvtStr)
+		return ueID, fmt.Errorf("Event '%s' parse failure: wrong UE ID fields in '%s'",
+			evtName, ueEvtStr)
 	}
-	ret.UeID.GNb = ueFields[0]
-	ret.UeID.GNbUeX2ApID = ueFields[1]
-	ret.UeID.ENbUeX2ApID = ueFields[2]
-	return nil
+	ueID.GNb = ueFields[0]
+	ueID.GNbUeX2ApID = ueFields[1]
+	ueID.ENbUeX2ApID = ueFields[2]
+	return ueID, nil
 }
 
 func parseTunnelsFromDcEvent(tunEvtStr string, ret *DcEvent) error {
 	tunFields := strings.Split(tunEvtStr, "#")
-	if len(tunFields)%2 != 0 {
+	//Tunnel endpoints with E-RAB IDs are detected from the first sub-field, which is an E-RAB ID
+	//number instead of a transport address.
+	stride := 2
+	if isErabIDField(tunFields[0]) {
+		stride = 3
+	}
+	if len(tunFields)%stride != 0 {
 		return fmt.Errorf("Event '%s' parse failure: wrong tunnel fields in '%s'",
 			ret.EventType.String(), tunEvtStr)
 	}
-	for i := 0; i < len(tunFields); i = i + 2 {
-		var teid uint32
-		if tunFields[i+1] != "" {
-			u64Teid, err := strconv.ParseUint(tunFields[i+1], 10, 32)
+	for i := 0; i < len(tunFields); i = i + stride {
+		var t DcEventTunnel
+		if stride == 3 {
+			u64ErabID, err := strconv.ParseUint(tunFields[i], 10, 32)
 			if err != nil {
-				return fmt.Errorf("Event '%s' parse failure: wrong TEID field in '%s' conversion error:'%s'",
+				return fmt.Errorf("Event '%s' parse failure: wrong E-RAB ID field in '%s' conversion error:'%s'",
 					ret.EventType.String(), tunEvtStr, err.Error())
 			}
-			teid = uint32(u64Teid)
+			t.ErabID = uenib.ErabID(u64ErabID)
+			t.HasErabID = true
 		}
-		t := DcEventTunnel{
-			Addr: tunFields[i],
-			Teid: teid,
+		t.Addr = tunFields[i+stride-2]
+		if teidStr := tunFields[i+stride-1]; teidStr != "" {
+			u64Teid, err := strconv.ParseUint(teidStr, 10, 32)
+			if err != nil {
+				return fmt.Errorf("Event '%s' parse failure: wrong TEID field in '%s' conversion error:'%s'",
+					ret.EventType.String(), tunEvtStr, err.Error())
+			}
+			t.Teid = uint32(u64Teid)
 		}
 		ret.S1ULGtpTunnels = append(ret.S1ULGtpTunnels, t)
 	}
 	return nil
 }
+
+func isErabIDField(field string) bool {
+	if field == "" {
+		return false
+	}
+	for _, c := range field {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseDcBearerModifyEvent(evtStr string, ret *DcEvent) error {
+	fields := strings.Split(evtStr, "_")
+	if cnt := len(fields); cnt != 2 {
+		return fmt.Errorf("Event '%s' parse failure: no UE ID or E-RAB ID field in '%s'",
+			ret.EventType.String(), evtStr)
+	}
+	if err := parseUeFromDcEvent(fields[0], ret); err != nil {
+		return err
+	}
+	for _, erabIDStr := range strings.Split(fields[1], "#") {
+		u64ErabID, err := strconv.ParseUint(erabIDStr, 10, 32)
+		if err != nil {
+			return fmt.Errorf("Event '%s' parse failure: wrong E-RAB ID field in '%s' conversion error:'%s'",
+				ret.EventType.String(), fields[1], err.Error())
+		}
+		ret.ErabIDs = append(ret.ErabIDs, uenib.ErabID(u64ErabID))
+	}
+	return nil
+}
+
+func parseBearerTypesFromDcEvent(bearerEvtStr string, ret *DcEvent) error {
+	bearerFields := strings.Split(bearerEvtStr, "#")
+	if len(bearerFields)%2 != 0 {
+		return fmt.Errorf("Event '%s' parse failure: wrong bearer type fields in '%s'",
+			ret.EventType.String(), bearerEvtStr)
+	}
+	for i := 0; i < len(bearerFields); i = i + 2 {
+		u64ErabID, err := strconv.ParseUint(bearerFields[i], 10, 32)
+		if err != nil {
+			return fmt.Errorf("Event '%s' parse failure: wrong E-RAB ID field in '%s' conversion error:'%s'",
+				ret.EventType.String(), bearerEvtStr, err.Error())
+		}
+		bearerType, err := uenib.ParseBearerType(bearerFields[i+1])
+		if err != nil {
+			return fmt.Errorf("Event '%s' parse failure: wrong bearer type field in '%s' conversion error:'%s'",
+				ret.EventType.String(), bearerEvtStr, err.Error())
+		}
+		b := DcEventBearerType{
+			ErabID:     uenib.ErabID(u64ErabID),
+			BearerType: bearerType,
+		}
+		ret.BearerTypes = append(ret.BearerTypes, b)
+	}
+	return nil
+}
+
+func parsePsCellFromDcEvent(cellEvtStr string, ret *DcEvent) error {
+	var err error
+	ret.PsCell, err = parseCellFromEvent(ret.EventType.String(), cellEvtStr)
+	return err
+}
+
+func parseCellFromEvent(evtName string, cellEvtStr string) (uenib.Cell, error) {
+	var cell uenib.Cell
+	cellFields := strings.Split(cellEvtStr, "#")
+	if cnt := len(cellFields); cnt != 2 && cnt != 3 {
+		return cell, fmt.Errorf("Event '%s' parse failure: wrong PSCell fields in '%s'",
+			evtName, cellEvtStr)
+	}
+	pci, err := strconv.ParseUint(cellFields[0], 10, 32)
+	if err != nil {
+		return cell, fmt.Errorf("Event '%s' parse failure: wrong PCI field in '%s' conversion error:'%s'",
+			evtName, cellEvtStr, err.Error())
+	}
+	ssbFreq, err := strconv.ParseUint(cellFields[1], 10, 32)
+	if err != nil {
+		return cell, fmt.Errorf("Event '%s' parse failure: wrong SSB frequency field in '%s' conversion error:'%s'",
+			evtName, cellEvtStr, err.Error())
+	}
+	cell.Pci = uint32(pci)
+	cell.SsbFreq = uint32(ssbFreq)
+	if len(cellFields) == 3 {
+		if cell.NrCgi, err = uenib.ParseNrCgi(cellFields[2]); err != nil {
+			return cell, fmt.Errorf("Event '%s' parse failure: wrong NR-CGI field in '%s' conversion error:'%s'",
+				evtName, cellEvtStr, err.Error())
+		}
+	}
+	return cell, nil
+}
@@ -0,0 +1,373 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"strconv"
+	"strings"
+)
+
+//EventEncodingVersion is the version of the versioned event encoding produced by
+//EncodeDcEvent() and EncodeMobilityEvent(). The legacy event string format is version 1.
+//
+//A versioned event string is form of: v<VERSION>|<PAYLOAD>. In version 2 the payload is
+//a JSON object, so field values can contain any characters. Version 1 payload is the
+//legacy event string. Readers of this library version detect and parse both the legacy
+//event strings without a version prefix and the versioned event strings.
+const EventEncodingVersion = 2
+
+const eventVersionSeparator = "|"
+
+//dcEventJSON is the version 2 JSON payload of a dual connectivity event.
+type dcEventJSON struct {
+	Type        string              `json:"type"`
+	GNb         string              `json:"gNb,omitempty"`
+	UeID        *ueIDJSON           `json:"ueId,omitempty"`
+	Tunnels     []dcEventTunnelJSON `json:"s1ulTunnels,omitempty"`
+	BearerTypes []dcBearerTypeJSON  `json:"bearerTypes,omitempty"`
+	PsCell      *cellJSON           `json:"psCell,omitempty"`
+	ErabIDs     []uenib.ErabID      `json:"erabIds,omitempty"`
+}
+
+//mobilityEventJSON is the version 2 JSON payload of a mobility event.
+type mobilityEventJSON struct {
+	Type       string    `json:"type"`
+	UeID       *ueIDJSON `json:"ueId,omitempty"`
+	PsCell     *cellJSON `json:"psCell,omitempty"`
+	TargetUeID *ueIDJSON `json:"targetUeId,omitempty"`
+}
+
+type ueIDJSON struct {
+	GNb         string `json:"gNb"`
+	ENb         string `json:"eNb,omitempty"`
+	GNbUeX2ApID string `json:"gNbUeX2ApId"`
+	ENbUeX2ApID string `json:"eNbUeX2ApId"`
+}
+
+type dcEventTunnelJSON struct {
+	ErabID *uenib.ErabID `json:"erabId,omitempty"`
+	Addr   string        `json:"addr"`
+	Teid   uint32        `json:"teid"`
+}
+
+type dcBearerTypeJSON struct {
+	ErabID     uenib.ErabID `json:"erabId"`
+	BearerType string       `json:"bearerType"`
+}
+
+type cellJSON struct {
+	Pci     uint32 `json:"pci"`
+	SsbFreq uint32 `json:"ssbFreq"`
+	NrCgi   string `json:"nrCgi,omitempty"`
+}
+
+//EncodeDcEvent encodes a dual connectivity event to the versioned event encoding of
+//version EventEncodingVersion. Unlike the legacy event string format, the versioned
+//encoding can carry any characters in gNB names, UE X2AP IDs and addresses, and it
+//carries gNB identity also for the DC_EVENT_GNB_ALL_UES_REMOVE event.
+//
+//PSCell measurements are not part of the event encoding and they are ignored.
+//An error is returned, if the event type, some bearer type or the PSCell NR-CGI of the
+//event is invalid.
+//Note that readers older than this library version cannot parse the versioned encoding,
+//so writers should switch to it only after all the readers have been upgraded.
+func EncodeDcEvent(evt DcEvent) (string, error) {
+	data, err := evt.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	return "v" + strconv.Itoa(EventEncodingVersion) + eventVersionSeparator + string(data), nil
+}
+
+//MarshalJSON returns dual connectivity event in the canonical JSON form, which is the
+//payload of the versioned event encoding.
+func (evt DcEvent) MarshalJSON() ([]byte, error) {
+	payload, err := newDcEventJSON(evt)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("Event '%s' encode failure: %s", evt.EventType.String(), err.Error())
+	}
+	return data, nil
+}
+
+//UnmarshalJSON parses dual connectivity event from the canonical JSON form.
+func (evt *DcEvent) UnmarshalJSON(data []byte) error {
+	parsed, err := decodeDcEventJSON(string(data))
+	if err != nil {
+		return err
+	}
+	*evt = parsed
+	return nil
+}
+
+func newDcEventJSON(evt DcEvent) (*dcEventJSON, error) {
+	if evt.EventType > DC_EVENT_BEARER_MODIFY || evt.EventType < DC_EVENT_UNKNOWN {
+		return nil, fmt.Errorf("Event encode failure: unknown DC event type %d", evt.EventType)
+	}
+	payload := &dcEventJSON{
+		Type:    dcEventTypeName(evt.EventType),
+		GNb:     evt.GNb,
+		UeID:    newUeIDJSON(evt.UeID),
+		ErabIDs: evt.ErabIDs,
+	}
+	for _, t := range evt.S1ULGtpTunnels {
+		tunnel := dcEventTunnelJSON{Addr: t.Addr, Teid: t.Teid}
+		if t.HasErabID {
+			erabID := t.ErabID
+			tunnel.ErabID = &erabID
+		}
+		payload.Tunnels = append(payload.Tunnels, tunnel)
+	}
+	for _, b := range evt.BearerTypes {
+		if b.BearerType < uenib.BEARER_TYPE_MCG || b.BearerType > uenib.BEARER_TYPE_SPLIT {
+			return nil, fmt.Errorf("Event '%s' encode failure: invalid bearer type %d of E-RAB ID %d",
+				evt.EventType.String(), b.BearerType, b.ErabID)
+		}
+		payload.BearerTypes = append(payload.BearerTypes, dcBearerTypeJSON{
+			ErabID:     b.ErabID,
+			BearerType: b.BearerType.String(),
+		})
+	}
+	var err error
+	if payload.PsCell, err = newCellJSON(evt.EventType.String(), evt.PsCell); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func newUeIDJSON(ueID uenib.UeID) *ueIDJSON {
+	if ueID == (uenib.UeID{}) {
+		return nil
+	}
+	return &ueIDJSON{
+		GNb:         ueID.GNb,
+		ENb:         ueID.ENb,
+		GNbUeX2ApID: ueID.GNbUeX2ApID,
+		ENbUeX2ApID: ueID.ENbUeX2ApID,
+	}
+}
+
+func (ueID *ueIDJSON) toUeID() uenib.UeID {
+	if ueID == nil {
+		return uenib.UeID{}
+	}
+	return uenib.UeID{
+		GNb:         ueID.GNb,
+		ENb:         ueID.ENb,
+		GNbUeX2ApID: ueID.GNbUeX2ApID,
+		ENbUeX2ApID: ueID.ENbUeX2ApID,
+	}
+}
+
+//newCellJSON returns nil, if the cell is empty. PSCell measurements are ignored.
+func newCellJSON(evtTypeStr string, cell uenib.Cell) (*cellJSON, error) {
+	if cell.Pci == 0 && cell.SsbFreq == 0 && cell.NrCgi.IsEmpty() {
+		return nil, nil
+	}
+	ret := &cellJSON{Pci: cell.Pci, SsbFreq: cell.SsbFreq}
+	if !cell.NrCgi.IsEmpty() {
+		ret.NrCgi = cell.NrCgi.String()
+		if _, err := uenib.ParseNrCgi(ret.NrCgi); err != nil {
+			return nil, fmt.Errorf("Event '%s' encode failure: invalid NR-CGI: %s", evtTypeStr, err.Error())
+		}
+	}
+	return ret, nil
+}
+
+func (cell *cellJSON) toCell(evtTypeStr string) (uenib.Cell, error) {
+	var ret uenib.Cell
+	if cell == nil {
+		return ret, nil
+	}
+	ret.Pci = cell.Pci
+	ret.SsbFreq = cell.SsbFreq
+	if cell.NrCgi != "" {
+		nrCgi, err := uenib.ParseNrCgi(cell.NrCgi)
+		if err != nil {
+			return ret, fmt.Errorf("Event '%s' parse failure: wrong NR-CGI field conversion error:'%s'",
+				evtTypeStr, err.Error())
+		}
+		ret.NrCgi = nrCgi
+	}
+	return ret, nil
+}
+
+//splitVersionedEvent splits a versioned event string to the version and the payload.
+//False is returned, if the event string does not have a version prefix.
+func splitVersionedEvent(evtStr string) (int, string, bool) {
+	if !strings.HasPrefix(evtStr, "v") {
+		return 0, "", false
+	}
+	sepIndex := strings.Index(evtStr, eventVersionSeparator)
+	if sepIndex < 2 {
+		return 0, "", false
+	}
+	version, err := strconv.ParseUint(evtStr[1:sepIndex], 10, 16)
+	if err != nil {
+		return 0, "", false
+	}
+	return int(version), evtStr[sepIndex+1:], true
+}
+
+func parseVersionedDcEvent(version int, payload string) (DcEvent, error) {
+	switch version {
+	case 1:
+		return parseLegacyDcEvent(payload)
+	case 2:
+		return decodeDcEventJSON(payload)
+	}
+	return DcEvent{}, fmt.Errorf("Event parse failure: unsupported event encoding version %d", version)
+}
+
+func decodeDcEventJSON(payload string) (DcEvent, error) {
+	var ret DcEvent
+	var evtJSON dcEventJSON
+	if err := json.Unmarshal([]byte(payload), &evtJSON); err != nil {
+		return ret, fmt.Errorf("Event parse failure: invalid JSON payload '%s': %s", payload, err.Error())
+	}
+	ret.EventType = parseDcEventTypeName(evtJSON.Type)
+	if ret.EventType == DC_EVENT_UNKNOWN {
+		return ret, nil
+	}
+	ret.GNb = evtJSON.GNb
+	ret.UeID = evtJSON.UeID.toUeID()
+	for _, t := range evtJSON.Tunnels {
+		tunnel := DcEventTunnel{Addr: t.Addr, Teid: t.Teid}
+		if t.ErabID != nil {
+			tunnel.ErabID = *t.ErabID
+			tunnel.HasErabID = true
+		}
+		ret.S1ULGtpTunnels = append(ret.S1ULGtpTunnels, tunnel)
+	}
+	for _, b := range evtJSON.BearerTypes {
+		bearerType, err := uenib.ParseBearerType(b.BearerType)
+		if err != nil {
+			return ret, fmt.Errorf("Event '%s' parse failure: wrong bearer type of E-RAB ID %d conversion error:'%s'",
+				ret.EventType.String(), b.ErabID, err.Error())
+		}
+		ret.BearerTypes = append(ret.BearerTypes, DcEventBearerType{ErabID: b.ErabID, BearerType: bearerType})
+	}
+	var err error
+	if ret.PsCell, err = evtJSON.PsCell.toCell(ret.EventType.String()); err != nil {
+		return ret, err
+	}
+	ret.ErabIDs = evtJSON.ErabIDs
+	return ret, nil
+}
+
+//dcEventTypeName returns the event type name used in the versioned event encoding,
+//which is the event type string without the leading underscore.
+func dcEventTypeName(dcEvt DcEventType) string {
+	return strings.TrimPrefix(dcEvt.String(), "_")
+}
+
+func parseDcEventTypeName(name string) DcEventType {
+	for dcEvt := DC_EVENT_ADD; dcEvt <= DC_EVENT_BEARER_MODIFY; dcEvt++ {
+		if dcEventTypeName(dcEvt) == name {
+			return dcEvt
+		}
+	}
+	return DC_EVENT_UNKNOWN
+}
+
+//EncodeMobilityEvent encodes a mobility event to the versioned event encoding of version
+//EventEncodingVersion. Unlike the legacy event string format, the versioned encoding can
+//carry any characters in gNB and eNB names and UE X2AP IDs, including the target gNB of the
+//MOBILITY_EVENT_SGNB_CHANGE event.
+//
+//PSCell measurements are not part of the event encoding and they are ignored.
+//An error is returned, if the event type or the PSCell NR-CGI of the event is invalid.
+//Note that readers older than this library version cannot parse the versioned encoding,
+//so writers should switch to it only after all the readers have been upgraded.
+func EncodeMobilityEvent(evt MobilityEvent) (string, error) {
+	data, err := evt.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+	return "v" + strconv.Itoa(EventEncodingVersion) + eventVersionSeparator + string(data), nil
+}
+
+//MarshalJSON returns mobility event in the canonical JSON form, which is the payload of the
+//versioned event encoding.
+func (evt MobilityEvent) MarshalJSON() ([]byte, error) {
+	if evt.EventType > MOBILITY_EVENT_INTER_MENB_HANDOVER || evt.EventType < MOBILITY_EVENT_UNKNOWN {
+		return nil, fmt.Errorf("Event encode failure: unknown mobility event type %d", evt.EventType)
+	}
+	payload := mobilityEventJSON{
+		Type:       mobilityEventTypeName(evt.EventType),
+		UeID:       newUeIDJSON(evt.UeID),
+		TargetUeID: newUeIDJSON(evt.TargetUeID),
+	}
+	var err error
+	if payload.PsCell, err = newCellJSON(evt.EventType.String(), evt.PsCell); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("Event '%s' encode failure: %s", evt.EventType.String(), err.Error())
+	}
+	return data, nil
+}
+
+//UnmarshalJSON parses mobility event from the canonical JSON form.
+func (evt *MobilityEvent) UnmarshalJSON(data []byte) error {
+	parsed, err := decodeMobilityEventJSON(string(data))
+	if err != nil {
+		return err
+	}
+	*evt = parsed
+	return nil
+}
+
+func parseVersionedMobilityEvent(version int, payload string) (MobilityEvent, error) {
+	switch version {
+	case 1:
+		return parseLegacyMobilityEvent(payload)
+	case 2:
+		return decodeMobilityEventJSON(payload)
+	}
+	return MobilityEvent{}, fmt.Errorf("Event parse failure: unsupported event encoding version %d", version)
+}
+
+func decodeMobilityEventJSON(payload string) (MobilityEvent, error) {
+	var ret MobilityEvent
+	var evtJSON mobilityEventJSON
+	if err := json.Unmarshal([]byte(payload), &evtJSON); err != nil {
+		return ret, fmt.Errorf("Event parse failure: invalid JSON payload '%s': %s", payload, err.Error())
+	}
+	ret.EventType = parseMobilityEventTypeName(evtJSON.Type)
+	if ret.EventType == MOBILITY_EVENT_UNKNOWN {
+		return ret, nil
+	}
+	ret.UeID = evtJSON.UeID.toUeID()
+	ret.TargetUeID = evtJSON.TargetUeID.toUeID()
+	var err error
+	ret.PsCell, err = evtJSON.PsCell.toCell(ret.EventType.String())
+	return ret, err
+}
+
+//mobilityEventTypeName returns the event type name used in the versioned event encoding,
+//which is the event type string without the leading underscore.
+func mobilityEventTypeName(mobEvt MobilityEventType) string {
+	return strings.TrimPrefix(mobEvt.String(), "_")
+}
+
+func parseMobilityEventTypeName(name string) MobilityEventType {
+	for mobEvt := MOBILITY_EVENT_PSCELL_CHANGE; mobEvt <= MOBILITY_EVENT_INTER_MENB_HANDOVER; mobEvt++ {
+		if mobilityEventTypeName(mobEvt) == name {
+			return mobEvt
+		}
+	}
+	return MOBILITY_EVENT_UNKNOWN
+}
@@ -0,0 +1,222 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader_test
+
+import (
+	"encoding/json"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestEncodeDcEventSuccessForAddEvent(t *testing.T) {
+	evtStr, err := uenibreader.EncodeDcEvent(expParsedDcAddEvent)
+	assert.Nil(t, err)
+	assert.Equal(t,
+		`v2|{"type":"ADD","ueId":{"gNb":"somegnb:310-410-b5c67788","gNbUeX2ApId":"100","eNbUeX2ApId":"200"}}`,
+		evtStr)
+}
+
+func TestEncodeDcEventSuccessForRemoveAllUesEventWithGnb(t *testing.T) {
+	evt := uenibreader.DcEvent{
+		EventType: uenibreader.DC_EVENT_GNB_ALL_UES_REMOVE,
+		GNb:       someGNb,
+	}
+	evtStr, err := uenibreader.EncodeDcEvent(evt)
+	assert.Nil(t, err)
+	assert.Equal(t, `v2|{"type":"GNB_ALL_UES_REMOVE","gNb":"somegnb:310-410-b5c67788"}`, evtStr)
+
+	retEvt, err := uenibreader.ParseDcEvent(evtStr)
+	assert.Nil(t, err)
+	assert.Equal(t, evt, retEvt)
+}
+
+func TestEncodeDcEventSuccessForSeparatorCharactersInFields(t *testing.T) {
+	evt := uenibreader.DcEvent{
+		EventType: uenibreader.DC_EVENT_S1UL_TUNNEL_ESTABLISH,
+		UeID: uenib.UeID{
+			GNb:         "some_gnb#1:310-410-b5c67788",
+			GNbUeX2ApID: "1#00",
+			ENbUeX2ApID: "2_00",
+		},
+		S1ULGtpTunnels: []uenibreader.DcEventTunnel{
+			uenibreader.DcEventTunnel{
+				Addr:      "10.20.30.40+2001:db8::68",
+				Teid:      5000,
+				ErabID:    5,
+				HasErabID: true,
+			},
+			uenibreader.DcEventTunnel{
+				Addr: "weird_addr#|",
+				Teid: 6000,
+			},
+		},
+	}
+	evtStr, err := uenibreader.EncodeDcEvent(evt)
+	assert.Nil(t, err)
+
+	retEvt, err := uenibreader.ParseDcEvent(evtStr)
+	assert.Nil(t, err)
+	assert.Equal(t, evt, retEvt)
+}
+
+func TestEncodeDcEventFailure(t *testing.T) {
+	tests := []uenibreader.DcEvent{
+		{EventType: uenibreader.DC_EVENT_BEARER_MODIFY + 1},
+		{EventType: uenibreader.DC_EVENT_BEARER_TYPE_CHANGE,
+			BearerTypes: []uenibreader.DcEventBearerType{{ErabID: 5, BearerType: uenib.BEARER_TYPE_UNKNOWN}}},
+		{EventType: uenibreader.DC_EVENT_PSCELL_CHANGE,
+			PsCell: uenib.Cell{Pci: 500, SsbFreq: 632628, NrCgi: uenib.NrCgi{Mcc: "31", Mnc: "410", NrCellID: 1}}},
+	}
+	for _, evt := range tests {
+		_, err := uenibreader.EncodeDcEvent(evt)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestParseDcEventSuccessForVersionedLegacyEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseDcEvent("v1|" + dcPsCellChangeEvent)
+	assert.Nil(t, err)
+	assert.Equal(t, expParsedDcPsCellChangeEvent, retEvt)
+}
+
+func TestParseDcEventSuccessForVersionedBearerTypeChangeEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseDcEvent(`v2|{"type":"BEARER_TYPE_CHANGE",` +
+		`"ueId":{"gNb":"somegnb:310-410-b5c67788","gNbUeX2ApId":"100","eNbUeX2ApId":"200"},` +
+		`"bearerTypes":[{"erabId":5,"bearerType":"SPLIT"},{"erabId":6,"bearerType":"SCG"}]}`)
+	assert.Nil(t, err)
+	assert.Equal(t, expParsedDcBearerTypeChangeEvent, retEvt)
+}
+
+func TestParseDcEventPassThroughWithSuccessForUnknownVersionedEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseDcEvent(`v2|{"type":"SOME_FUTURE_EVENT","gNb":"somegnb"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.DcEvent{EventType: uenibreader.DC_EVENT_UNKNOWN}, retEvt)
+}
+
+func TestParseDcEventReturnsErrorIfUnsupportedEventEncodingVersion(t *testing.T) {
+	_, err := uenibreader.ParseDcEvent(`v3|{"type":"ADD"}`)
+	assert.EqualError(t, err, "Event parse failure: unsupported event encoding version 3")
+}
+
+func TestParseDcEventReturnsErrorIfInvalidJSONInVersionedEvent(t *testing.T) {
+	_, err := uenibreader.ParseDcEvent(`v2|{"type":"ADD"`)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventReturnsErrorIfUnknownBearerTypeInVersionedEvent(t *testing.T) {
+	_, err := uenibreader.ParseDcEvent(`v2|{"type":"BEARER_TYPE_CHANGE","bearerTypes":[{"erabId":5,"bearerType":"XYZ"}]}`)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventReturnsErrorIfInvalidNrCgiInVersionedEvent(t *testing.T) {
+	_, err := uenibreader.ParseDcEvent(`v2|{"type":"PSCELL_CHANGE","psCell":{"pci":1,"ssbFreq":2,"nrCgi":"1-2-3"}}`)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventIsInverseOfEncodeDcEvent(t *testing.T) {
+	property := func(r randomDcEvent) bool {
+		evtStr, err := uenibreader.EncodeDcEvent(r.evt)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		parsedEvt, err := uenibreader.ParseDcEvent(evtStr)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		if r.evt.EventType == uenibreader.DC_EVENT_UNKNOWN {
+			return parsedEvt.EventType == uenibreader.DC_EVENT_UNKNOWN
+		}
+		return reflect.DeepEqual(r.evt, parsedEvt)
+	}
+	err := quick.Check(property, &quick.Config{MaxCount: 2000})
+	assert.Nil(t, err)
+}
+
+func TestDcEventJSON(t *testing.T) {
+	data, err := json.Marshal(expParsedDcBearerTypeChangeEvent)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"type":"BEARER_TYPE_CHANGE",`+
+		`"ueId":{"gNb":"somegnb:310-410-b5c67788","gNbUeX2ApId":"100","eNbUeX2ApId":"200"},`+
+		`"bearerTypes":[{"erabId":5,"bearerType":"SPLIT"},{"erabId":6,"bearerType":"SCG"}]}`, string(data))
+
+	var retEvt uenibreader.DcEvent
+	assert.Nil(t, json.Unmarshal(data, &retEvt))
+	assert.Equal(t, expParsedDcBearerTypeChangeEvent, retEvt)
+}
+
+func TestDcEventJSONUnmarshalFailure(t *testing.T) {
+	var evt uenibreader.DcEvent
+	assert.NotNil(t, json.Unmarshal([]byte(`{"type":"BEARER_TYPE_CHANGE","bearerTypes":[{"erabId":5,"bearerType":"XYZ"}]}`), &evt))
+}
+
+func TestEncodeMobilityEventSuccessForSgNbChangeEvent(t *testing.T) {
+	evt := uenibreader.MobilityEvent{
+		EventType:  uenibreader.MOBILITY_EVENT_SGNB_CHANGE,
+		UeID:       uenib.UeID{GNb: "some_gnb#1", GNbUeX2ApID: "100", ENbUeX2ApID: "200"},
+		TargetUeID: uenib.UeID{GNb: "other_gnb#2"},
+	}
+	evtStr, err := uenibreader.EncodeMobilityEvent(evt)
+	assert.Nil(t, err)
+	assert.Equal(t, `v2|{"type":"SGNB_CHANGE",`+
+		`"ueId":{"gNb":"some_gnb#1","gNbUeX2ApId":"100","eNbUeX2ApId":"200"},`+
+		`"targetUeId":{"gNb":"other_gnb#2","gNbUeX2ApId":"","eNbUeX2ApId":""}}`, evtStr)
+
+	retEvt, err := uenibreader.ParseMobilityEvent(evtStr)
+	assert.Nil(t, err)
+	assert.Equal(t, evt, retEvt)
+}
+
+func TestParseMobilityEventIsInverseOfEncodeMobilityEvent(t *testing.T) {
+	evts := []uenibreader.MobilityEvent{
+		{
+			EventType: uenibreader.MOBILITY_EVENT_PSCELL_CHANGE,
+			UeID:      uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "1_0", ENbUeX2ApID: "2"},
+			PsCell:    uenib.Cell{Pci: 500, SsbFreq: 632628, NrCgi: uenib.NrCgi{Mcc: "310", Mnc: "410", NrCellID: 1}},
+		},
+		{
+			EventType:  uenibreader.MOBILITY_EVENT_INTER_MENB_HANDOVER,
+			UeID:       uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "1", ENbUeX2ApID: "2"},
+			TargetUeID: uenib.UeID{GNb: "somegnb", ENb: "some#enb", GNbUeX2ApID: "1", ENbUeX2ApID: "3"},
+		},
+	}
+	for _, evt := range evts {
+		evtStr, err := uenibreader.EncodeMobilityEvent(evt)
+		assert.Nil(t, err)
+		retEvt, err := uenibreader.ParseMobilityEvent(evtStr)
+		assert.Nil(t, err)
+		assert.Equal(t, evt, retEvt)
+	}
+}
+
+func TestEncodeMobilityEventFailure(t *testing.T) {
+	_, err := uenibreader.EncodeMobilityEvent(uenibreader.MobilityEvent{EventType: uenibreader.MOBILITY_EVENT_INTER_MENB_HANDOVER + 1})
+	assert.NotNil(t, err)
+	_, err = uenibreader.EncodeMobilityEvent(uenibreader.MobilityEvent{EventType: uenibreader.MOBILITY_EVENT_PSCELL_CHANGE,
+		PsCell: uenib.Cell{Pci: 500, NrCgi: uenib.NrCgi{Mcc: "31", Mnc: "410", NrCellID: 1}}})
+	assert.NotNil(t, err)
+}
+
+func TestParseMobilityEventOfVersionedEncoding(t *testing.T) {
+	evt, err := uenibreader.ParseMobilityEvent(`v2|{"type":"SOME_UNKNOWN_EVENT"}`)
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.MOBILITY_EVENT_UNKNOWN, evt.EventType)
+	_, err = uenibreader.ParseMobilityEvent("v1|somegnb#100#200_othergnb_SGNB_CHANGE")
+	assert.Nil(t, err)
+	_, err = uenibreader.ParseMobilityEvent(`v3|{"type":"SGNB_CHANGE"}`)
+	assert.NotNil(t, err)
+	_, err = uenibreader.ParseMobilityEvent(`v2|{"type":`)
+	assert.NotNil(t, err)
+	_, err = uenibreader.ParseMobilityEvent(`v2|{"type":"PSCELL_CHANGE","psCell":{"pci":1,"ssbFreq":2,"nrCgi":"1-2-3"}}`)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,159 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader
+
+import (
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"strconv"
+	"strings"
+)
+
+//FormatDcEvent formats a dual connectivity event to the event string format described in
+//the DualConnectivity event category declaration. It is the inverse of ParseDcEvent(), a
+//successfully formatted event string is parsed back to an equal 'DcEvent'.
+//
+//Only the fields used by the event type are formatted, other fields are ignored. PSCell
+//measurements and the GNb field are not part of the event string format and they are
+//ignored too. Use EncodeDcEvent() to encode events to the versioned event encoding.
+//An error is returned, if the event cannot be represented in the event string format,
+//for example if some string field contains a field separator ('_' or '#'), if a list
+//field required by the event type is empty or if the tunnel endpoints of the event do
+//not either all have or all lack an E-RAB ID.
+func FormatDcEvent(evt DcEvent) (string, error) {
+	if evt.EventType > DC_EVENT_BEARER_MODIFY || evt.EventType < DC_EVENT_UNKNOWN {
+		return "", fmt.Errorf("Event format failure: unknown DC event type %d", evt.EventType)
+	}
+	switch evt.EventType {
+	case DC_EVENT_UNKNOWN:
+		return "", nil
+	case DC_EVENT_GNB_ALL_UES_REMOVE:
+		return evt.EventType.String(), nil
+	}
+
+	fields := make([]string, 0, 2)
+	ueStr, err := formatUeIDToEvent(evt.EventType.String(), evt.UeID)
+	if err != nil {
+		return "", err
+	}
+	fields = append(fields, ueStr)
+
+	var evtFieldStr string
+	switch evt.EventType {
+	case DC_EVENT_S1UL_TUNNEL_ESTABLISH, DC_EVENT_S1UL_TUNNEL_RELEASE:
+		evtFieldStr, err = formatTunnelsToDcEvent(evt)
+	case DC_EVENT_BEARER_TYPE_CHANGE:
+		evtFieldStr, err = formatBearerTypesToDcEvent(evt)
+	case DC_EVENT_PSCELL_CHANGE:
+		evtFieldStr, err = formatCellToEvent(evt.EventType.String(), evt.PsCell)
+	case DC_EVENT_BEARER_MODIFY:
+		evtFieldStr, err = formatErabIDsToDcEvent(evt)
+	}
+	if err != nil {
+		return "", err
+	}
+	if evtFieldStr != "" {
+		fields = append(fields, evtFieldStr)
+	}
+	return strings.Join(fields, "_") + evt.EventType.String(), nil
+}
+
+//String returns dual connectivity event in the event string format. If the event cannot be
+//formatted, the returned string describes the formatting failure.
+func (evt DcEvent) String() string {
+	str, err := FormatDcEvent(evt)
+	if err != nil {
+		return fmt.Sprintf("<invalid DC event: %s>", err.Error())
+	}
+	return str
+}
+
+func formatUeIDToEvent(evtName string, ueID uenib.UeID) (string, error) {
+	ueFields := []string{ueID.GNb, ueID.GNbUeX2ApID, ueID.ENbUeX2ApID}
+	for _, field := range ueFields {
+		if err := checkEventSubField(evtName, "UE ID", field); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(ueFields, "#"), nil
+}
+
+func formatTunnelsToDcEvent(evt DcEvent) (string, error) {
+	if len(evt.S1ULGtpTunnels) == 0 {
+		return "", fmt.Errorf("Event '%s' format failure: no tunnels", evt.EventType.String())
+	}
+	withErabIDs := evt.S1ULGtpTunnels[0].HasErabID
+	tunFields := make([]string, 0, 3*len(evt.S1ULGtpTunnels))
+	for _, t := range evt.S1ULGtpTunnels {
+		if t.HasErabID != withErabIDs {
+			return "", fmt.Errorf("Event '%s' format failure: E-RAB ID missing from some tunnels",
+				evt.EventType.String())
+		}
+		if err := checkEventSubField(evt.EventType.String(), "tunnel address", t.Addr); err != nil {
+			return "", err
+		}
+		if withErabIDs {
+			tunFields = append(tunFields, strconv.FormatUint(uint64(t.ErabID), 10))
+		} else if isErabIDField(t.Addr) {
+			//Parser would take a numeric address for an E-RAB ID.
+			return "", fmt.Errorf("Event '%s' format failure: numeric tunnel address '%s'",
+				evt.EventType.String(), t.Addr)
+		}
+		tunFields = append(tunFields, t.Addr, strconv.FormatUint(uint64(t.Teid), 10))
+	}
+	return strings.Join(tunFields, "#"), nil
+}
+
+func formatBearerTypesToDcEvent(evt DcEvent) (string, error) {
+	if len(evt.BearerTypes) == 0 {
+		return "", fmt.Errorf("Event '%s' format failure: no bearer types", evt.EventType.String())
+	}
+	bearerFields := make([]string, 0, 2*len(evt.BearerTypes))
+	for _, b := range evt.BearerTypes {
+		if b.BearerType < uenib.BEARER_TYPE_MCG || b.BearerType > uenib.BEARER_TYPE_SPLIT {
+			return "", fmt.Errorf("Event '%s' format failure: invalid bearer type %d of E-RAB ID %d",
+				evt.EventType.String(), b.BearerType, b.ErabID)
+		}
+		bearerFields = append(bearerFields, strconv.FormatUint(uint64(b.ErabID), 10), b.BearerType.String())
+	}
+	return strings.Join(bearerFields, "#"), nil
+}
+
+func formatErabIDsToDcEvent(evt DcEvent) (string, error) {
+	if len(evt.ErabIDs) == 0 {
+		return "", fmt.Errorf("Event '%s' format failure: no E-RAB IDs", evt.EventType.String())
+	}
+	erabFields := make([]string, 0, len(evt.ErabIDs))
+	for _, erabID := range evt.ErabIDs {
+		erabFields = append(erabFields, strconv.FormatUint(uint64(erabID), 10))
+	}
+	return strings.Join(erabFields, "#"), nil
+}
+
+func formatCellToEvent(evtName string, cell uenib.Cell) (string, error) {
+	cellFields := []string{
+		strconv.FormatUint(uint64(cell.Pci), 10),
+		strconv.FormatUint(uint64(cell.SsbFreq), 10),
+	}
+	if !cell.NrCgi.IsEmpty() {
+		nrCgiStr := cell.NrCgi.String()
+		if _, err := uenib.ParseNrCgi(nrCgiStr); err != nil {
+			return "", fmt.Errorf("Event '%s' format failure: invalid NR-CGI: %s", evtName, err.Error())
+		}
+		cellFields = append(cellFields, nrCgiStr)
+	}
+	return strings.Join(cellFields, "#"), nil
+}
+
+func checkEventSubField(evtName string, fieldName string, field string) error {
+	if strings.ContainsAny(field, "_#") {
+		return fmt.Errorf("Event '%s' format failure: %s field '%s' contains a separator character",
+			evtName, fieldName, field)
+	}
+	return nil
+}
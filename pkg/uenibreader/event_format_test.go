@@ -0,0 +1,235 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader_test
+
+import (
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func TestFormatDcEventProducesParserTestEvents(t *testing.T) {
+	tests := []struct {
+		evt    uenibreader.DcEvent
+		evtStr string
+	}{
+		{expParsedDcAddEvent, dcAddEvent},
+		{expParsedDcRemoveEvent, dcRemoveEvent},
+		{expParsedDcS1ULTunnelEstablishEvent, dcS1ULTunnelEstablishEvent},
+		{expParsedDcS1ULTunnelReleaseEvent, dcS1ULTunnelReleaseEvent},
+		{expParsedDcRemoveAllUesEvent, dcRemoveAllUesEvent},
+		{expParsedDcBearerTypeChangeEvent, dcBearerTypeChangeEvent},
+		{expParsedDcPsCellChangeEvent, dcPsCellChangeEvent},
+	}
+	for _, test := range tests {
+		evtStr, err := uenibreader.FormatDcEvent(test.evt)
+		assert.Nil(t, err)
+		assert.Equal(t, test.evtStr, evtStr)
+		assert.Equal(t, test.evtStr, test.evt.String())
+	}
+}
+
+func TestFormatDcEventSuccessForDualStackTunnelsWithErabIDs(t *testing.T) {
+	evt := uenibreader.DcEvent{
+		EventType: uenibreader.DC_EVENT_S1UL_TUNNEL_ESTABLISH,
+		UeID: uenib.UeID{
+			GNb:         someGNb,
+			GNbUeX2ApID: "100",
+			ENbUeX2ApID: "200",
+		},
+		S1ULGtpTunnels: []uenibreader.DcEventTunnel{
+			uenibreader.DcEventTunnel{
+				Addr:      "10.20.30.40+2001:db8::68",
+				Teid:      5000,
+				ErabID:    5,
+				HasErabID: true,
+			},
+			uenibreader.DcEventTunnel{
+				Addr:      "2001:db8::69",
+				Teid:      4294967295,
+				ErabID:    6,
+				HasErabID: true,
+			},
+		},
+	}
+	evtStr, err := uenibreader.FormatDcEvent(evt)
+	assert.Nil(t, err)
+	assert.Equal(t,
+		"somegnb:310-410-b5c67788#100#200_5#10.20.30.40+2001:db8::68#5000#6#2001:db8::69#4294967295_S1UL_TUNNEL_ESTABLISH",
+		evtStr)
+}
+
+func TestFormatDcEventSuccessForBearerModifyEvent(t *testing.T) {
+	evt := uenibreader.DcEvent{
+		EventType: uenibreader.DC_EVENT_BEARER_MODIFY,
+		UeID: uenib.UeID{
+			GNb:         someGNb,
+			GNbUeX2ApID: "100",
+			ENbUeX2ApID: "200",
+		},
+		ErabIDs: []uenib.ErabID{5, 7},
+	}
+	evtStr, err := uenibreader.FormatDcEvent(evt)
+	assert.Nil(t, err)
+	assert.Equal(t, "somegnb:310-410-b5c67788#100#200_5#7_BEARER_MODIFY", evtStr)
+}
+
+func TestFormatDcEventReturnsEmptyStringForUnknownEvent(t *testing.T) {
+	evtStr, err := uenibreader.FormatDcEvent(uenibreader.DcEvent{})
+	assert.Nil(t, err)
+	assert.Equal(t, "", evtStr)
+}
+
+func TestFormatDcEventFailure(t *testing.T) {
+	ueID := uenib.UeID{
+		GNb:         someGNb,
+		GNbUeX2ApID: "100",
+		ENbUeX2ApID: "200",
+	}
+	tests := []uenibreader.DcEvent{
+		{EventType: uenibreader.DC_EVENT_BEARER_MODIFY + 1},
+		{EventType: uenibreader.DC_EVENT_ADD, UeID: uenib.UeID{GNb: "some_gnb"}},
+		{EventType: uenibreader.DC_EVENT_ADD, UeID: uenib.UeID{GNb: someGNb, GNbUeX2ApID: "1#2"}},
+		{EventType: uenibreader.DC_EVENT_S1UL_TUNNEL_ESTABLISH, UeID: ueID},
+		{EventType: uenibreader.DC_EVENT_S1UL_TUNNEL_RELEASE, UeID: ueID,
+			S1ULGtpTunnels: []uenibreader.DcEventTunnel{{Addr: "10.20.30.40#1", Teid: 1}}},
+		{EventType: uenibreader.DC_EVENT_S1UL_TUNNEL_RELEASE, UeID: ueID,
+			S1ULGtpTunnels: []uenibreader.DcEventTunnel{{Addr: "1234", Teid: 1}}},
+		{EventType: uenibreader.DC_EVENT_S1UL_TUNNEL_RELEASE, UeID: ueID,
+			S1ULGtpTunnels: []uenibreader.DcEventTunnel{
+				{Addr: "10.20.30.40", Teid: 1, ErabID: 5, HasErabID: true},
+				{Addr: "10.20.30.41", Teid: 2},
+			}},
+		{EventType: uenibreader.DC_EVENT_BEARER_TYPE_CHANGE, UeID: ueID},
+		{EventType: uenibreader.DC_EVENT_BEARER_TYPE_CHANGE, UeID: ueID,
+			BearerTypes: []uenibreader.DcEventBearerType{{ErabID: 5, BearerType: uenib.BEARER_TYPE_UNKNOWN}}},
+		{EventType: uenibreader.DC_EVENT_PSCELL_CHANGE, UeID: ueID,
+			PsCell: uenib.Cell{Pci: 500, SsbFreq: 632628, NrCgi: uenib.NrCgi{Mcc: "31", Mnc: "410", NrCellID: 1}}},
+		{EventType: uenibreader.DC_EVENT_BEARER_MODIFY, UeID: ueID},
+	}
+	for _, evt := range tests {
+		_, err := uenibreader.FormatDcEvent(evt)
+		assert.NotNil(t, err)
+		assert.Contains(t, evt.String(), "invalid DC event")
+	}
+}
+
+//randomDcEvent generates random dual connectivity events, which can be represented in the
+//event string format.
+type randomDcEvent struct {
+	evt uenibreader.DcEvent
+}
+
+func (randomDcEvent) Generate(r *rand.Rand, size int) reflect.Value {
+	evtTypes := []uenibreader.DcEventType{
+		uenibreader.DC_EVENT_UNKNOWN,
+		uenibreader.DC_EVENT_ADD,
+		uenibreader.DC_EVENT_REMOVE,
+		uenibreader.DC_EVENT_S1UL_TUNNEL_ESTABLISH,
+		uenibreader.DC_EVENT_S1UL_TUNNEL_RELEASE,
+		uenibreader.DC_EVENT_GNB_ALL_UES_REMOVE,
+		uenibreader.DC_EVENT_BEARER_TYPE_CHANGE,
+		uenibreader.DC_EVENT_PSCELL_CHANGE,
+		uenibreader.DC_EVENT_BEARER_MODIFY,
+	}
+	evt := uenibreader.DcEvent{EventType: evtTypes[r.Intn(len(evtTypes))]}
+	if evt.EventType == uenibreader.DC_EVENT_UNKNOWN || evt.EventType == uenibreader.DC_EVENT_GNB_ALL_UES_REMOVE {
+		return reflect.ValueOf(randomDcEvent{evt})
+	}
+	evt.UeID = uenib.UeID{
+		GNb:         fmt.Sprintf("gnb:%03d-%03d-%08x", r.Intn(1000), r.Intn(1000), r.Uint32()),
+		GNbUeX2ApID: fmt.Sprint(r.Intn(4096)),
+		ENbUeX2ApID: fmt.Sprint(r.Intn(4096)),
+	}
+	count := 1 + r.Intn(4)
+	switch evt.EventType {
+	case uenibreader.DC_EVENT_S1UL_TUNNEL_ESTABLISH, uenibreader.DC_EVENT_S1UL_TUNNEL_RELEASE:
+		hasErabID := r.Intn(2) == 0
+		for i := 0; i < count; i++ {
+			t := uenibreader.DcEventTunnel{Addr: randomTunnelAddr(r), Teid: r.Uint32()}
+			if hasErabID {
+				t.ErabID = uenib.ErabID(r.Intn(16))
+				t.HasErabID = true
+			}
+			evt.S1ULGtpTunnels = append(evt.S1ULGtpTunnels, t)
+		}
+	case uenibreader.DC_EVENT_BEARER_TYPE_CHANGE:
+		for i := 0; i < count; i++ {
+			evt.BearerTypes = append(evt.BearerTypes, uenibreader.DcEventBearerType{
+				ErabID:     uenib.ErabID(r.Intn(16)),
+				BearerType: uenib.BEARER_TYPE_MCG + uenib.BearerType(r.Intn(3)),
+			})
+		}
+	case uenibreader.DC_EVENT_PSCELL_CHANGE:
+		evt.PsCell = uenib.Cell{Pci: uint32(r.Intn(1008)), SsbFreq: uint32(r.Intn(3279166))}
+		if r.Intn(2) == 0 {
+			evt.PsCell.NrCgi = uenib.NrCgi{
+				Mcc:      fmt.Sprintf("%03d", r.Intn(1000)),
+				Mnc:      fmt.Sprintf("%0*d", 2+r.Intn(2), r.Intn(100)),
+				NrCellID: uint64(r.Int63n(1 << 36)),
+			}
+		}
+	case uenibreader.DC_EVENT_BEARER_MODIFY:
+		for i := 0; i < count; i++ {
+			evt.ErabIDs = append(evt.ErabIDs, uenib.ErabID(r.Intn(16)))
+		}
+	}
+	return reflect.ValueOf(randomDcEvent{evt})
+}
+
+func randomTunnelAddr(r *rand.Rand) string {
+	v4 := fmt.Sprintf("%d.%d.%d.%d", r.Intn(256), r.Intn(256), r.Intn(256), r.Intn(256))
+	v6 := fmt.Sprintf("2001:db8::%x", r.Intn(65536))
+	switch r.Intn(4) {
+	case 0:
+		return v4
+	case 1:
+		return v6
+	case 2:
+		return v4 + "+" + v6
+	}
+	return ""
+}
+
+func TestParseDcEventIsInverseOfFormatDcEvent(t *testing.T) {
+	property := func(r randomDcEvent) bool {
+		evtStr, err := uenibreader.FormatDcEvent(r.evt)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		parsedEvt, err := uenibreader.ParseDcEvent(evtStr)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		return reflect.DeepEqual(r.evt, parsedEvt)
+	}
+	err := quick.Check(property, &quick.Config{MaxCount: 2000})
+	assert.Nil(t, err)
+}
+
+func TestFormatDcEventIsInverseOfParseDcEvent(t *testing.T) {
+	property := func(r randomDcEvent) bool {
+		evtStr := r.evt.String()
+		parsedEvt, err := uenibreader.ParseDcEvent(evtStr)
+		if err != nil {
+			t.Log(err)
+			return false
+		}
+		formattedStr, err := uenibreader.FormatDcEvent(parsedEvt)
+		return err == nil && formattedStr == evtStr
+	}
+	err := quick.Check(property, &quick.Config{MaxCount: 2000})
+	assert.Nil(t, err)
+}
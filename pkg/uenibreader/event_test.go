@@ -32,6 +32,10 @@ var expParsedDcS1ULTunnelReleaseEvent uenibreader.DcEvent
 var dcRemoveAllUesEvent string
 var expParsedDcRemoveAllUesEvent uenibreader.DcEvent
 var dcEmptyS1ULTunnelEstablishEvent string
+var dcBearerTypeChangeEvent string
+var expParsedDcBearerTypeChangeEvent uenibreader.DcEvent
+var dcPsCellChangeEvent string
+var expParsedDcPsCellChangeEvent uenibreader.DcEvent
 var expParsedEmptyDcS1ULTunnelEstablishEvent uenibreader.DcEvent
 
 func init() {
@@ -113,6 +117,45 @@ func init() {
 			uenibreader.DcEventTunnel{},
 		},
 	}
+
+	dcBearerTypeChangeEvent = "somegnb:310-410-b5c67788#100#200_5#SPLIT#6#SCG_BEARER_TYPE_CHANGE"
+	expParsedDcBearerTypeChangeEvent = uenibreader.DcEvent{
+		EventType: uenibreader.DC_EVENT_BEARER_TYPE_CHANGE,
+		UeID: uenib.UeID{
+			GNb:         someGNb,
+			GNbUeX2ApID: "100",
+			ENbUeX2ApID: "200",
+		},
+		BearerTypes: []uenibreader.DcEventBearerType{
+			uenibreader.DcEventBearerType{
+				ErabID:     5,
+				BearerType: uenib.BEARER_TYPE_SPLIT,
+			},
+			uenibreader.DcEventBearerType{
+				ErabID:     6,
+				BearerType: uenib.BEARER_TYPE_SCG,
+			},
+		},
+	}
+
+	dcPsCellChangeEvent = "somegnb:310-410-b5c67788#100#200_500#632628#310-410-b5c677880_PSCELL_CHANGE"
+	expParsedDcPsCellChangeEvent = uenibreader.DcEvent{
+		EventType: uenibreader.DC_EVENT_PSCELL_CHANGE,
+		UeID: uenib.UeID{
+			GNb:         someGNb,
+			GNbUeX2ApID: "100",
+			ENbUeX2ApID: "200",
+		},
+		PsCell: uenib.Cell{
+			Pci:     500,
+			SsbFreq: 632628,
+			NrCgi: uenib.NrCgi{
+				Mcc:      "310",
+				Mnc:      "410",
+				NrCellID: 0xb5c677880,
+			},
+		},
+	}
 }
 
 type eventCbArgs struct {
@@ -211,6 +254,28 @@ func TestSubscribeEventsTwoEventsInOneEvent(t *testing.T) {
 	m.AssertExpectations(t)
 }
 
+func TestSubscribeParsedEventsGetsParsedEvents(t *testing.T) {
+	m, i := setup()
+	var storedSdlCallback func(string, ...string)
+	m.On("SubscribeChannel", someEvNs, mock.AnythingOfType("func(string, ...string)"), []string{someChannel}).Run(
+		func(args mock.Arguments) {
+			storedSdlCallback = args.Get(1).(func(string, ...string))
+		}).Return(nil).Once()
+	var received []uenibreader.Event
+	err := i.SubscribeParsedEvents([]string{someGNb}, []uenibreader.EventCategory{someEventCategory},
+		func(gNb string, eventCategory uenibreader.EventCategory, events []uenibreader.Event) {
+			received = append(received, events...)
+		})
+	assert.Nil(t, err)
+	storedSdlCallback(someChannel, dcAddEvent, "somegnb#2#1_x_S1UL_TUNNEL_ESTABLISH")
+
+	assert.Equal(t, 2, len(received))
+	assert.Equal(t, uenibreader.Event{Raw: dcAddEvent, Parsed: expParsedDcAddEvent}, received[0])
+	assert.NotNil(t, received[1].Err)
+	assert.Equal(t, uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "2", ENbUeX2ApID: "1"}, received[1].UeID())
+	m.AssertExpectations(t)
+}
+
 func TestParseDcEventSuccessForAddEvent(t *testing.T) {
 	retEvt, err := uenibreader.ParseDcEvent(dcAddEvent)
 	assert.Nil(t, err)
@@ -241,6 +306,125 @@ func TestParseDcEventSuccessForRemoveAllUesEvent(t *testing.T) {
 	assert.Equal(t, expParsedDcRemoveAllUesEvent, retEvt)
 }
 
+func TestParseDcEventSuccessForBearerTypeChangeEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseDcEvent(dcBearerTypeChangeEvent)
+	assert.Nil(t, err)
+	assert.Equal(t, expParsedDcBearerTypeChangeEvent, retEvt)
+}
+
+func TestParseDcEventReturnsErrorIfNoBearerTypeFieldInEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_BEARER_TYPE_CHANGE"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventReturnsErrorIfNoBearerTypeInEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_5#SPLIT#6_BEARER_TYPE_CHANGE"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventReturnsErrorIfUnknownBearerTypeInEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_5#SOMETHING_BEARER_TYPE_CHANGE"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventReturnsErrorIfErabIDNotIntInBearerTypeEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_IamNotInt#SCG_BEARER_TYPE_CHANGE"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventSuccessForPsCellChangeEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseDcEvent(dcPsCellChangeEvent)
+	assert.Nil(t, err)
+	assert.Equal(t, expParsedDcPsCellChangeEvent, retEvt)
+}
+
+func TestParseDcEventSuccessForPsCellChangeEventWithoutNrCgi(t *testing.T) {
+	retEvt, err := uenibreader.ParseDcEvent("somegnb:310-410-b5c67788#100#200_500#632628_PSCELL_CHANGE")
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.DC_EVENT_PSCELL_CHANGE, retEvt.EventType)
+	assert.Equal(t, uenib.Cell{Pci: 500, SsbFreq: 632628}, retEvt.PsCell)
+}
+
+func TestParseDcEventReturnsErrorIfNoPsCellFieldInEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_PSCELL_CHANGE"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventReturnsErrorIfPciNotIntInPsCellEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_IamNotInt#632628_PSCELL_CHANGE"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventReturnsErrorIfSsbFreqNotIntInPsCellEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_500#IamNotInt_PSCELL_CHANGE"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventReturnsErrorIfInvalidNrCgiInPsCellEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_500#632628#IamNotNrCgi_PSCELL_CHANGE"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventSuccessForBearerModifyEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseDcEvent("somegnb:310-410-b5c67788#100#200_5#7_BEARER_MODIFY")
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.DcEvent{
+		EventType: uenibreader.DC_EVENT_BEARER_MODIFY,
+		UeID: uenib.UeID{
+			GNb:         someGNb,
+			GNbUeX2ApID: "100",
+			ENbUeX2ApID: "200",
+		},
+		ErabIDs: []uenib.ErabID{5, 7},
+	}, retEvt)
+}
+
+func TestParseDcEventReturnsErrorIfNoErabIDFieldInBearerModifyEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_BEARER_MODIFY"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventReturnsErrorIfErabIDNotIntInBearerModifyEvent(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_5#IamNotInt_BEARER_MODIFY"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
+func TestParseDcEventSuccessForS1ULTunnelEstablishEventWithErabIDs(t *testing.T) {
+	retEvt, err := uenibreader.ParseDcEvent(
+		"somegnb:310-410-b5c67788#100#200_5#10.20.30.40#5000#6#2001:db8::68#6000_S1UL_TUNNEL_ESTABLISH")
+	assert.Nil(t, err)
+	assert.Equal(t, []uenibreader.DcEventTunnel{
+		uenibreader.DcEventTunnel{
+			Addr:      "10.20.30.40",
+			Teid:      5000,
+			ErabID:    5,
+			HasErabID: true,
+		},
+		uenibreader.DcEventTunnel{
+			Addr:      "2001:db8::68",
+			Teid:      6000,
+			ErabID:    6,
+			HasErabID: true,
+		},
+	}, retEvt.S1ULGtpTunnels)
+}
+
+func TestParseDcEventReturnsErrorIfWrongTunnelFieldCountWithErabIDs(t *testing.T) {
+	var illegalEvent string = "somegnb:310-410-b5c67788#100#200_5#10.20.30.40#5000#6#6000_S1UL_TUNNEL_RELEASE"
+	_, err := uenibreader.ParseDcEvent(illegalEvent)
+	assert.NotNil(t, err)
+}
+
 func TestParseDcEventPassThroughWithSuccessForUnknownEvent(t *testing.T) {
 	var unknownEvent string = "somegnb:310-410-b5c67788#100#200_SOME_UNKNOWN_EVENT"
 	retEvt, err := uenibreader.ParseDcEvent(unknownEvent)
@@ -297,7 +481,7 @@ func TestParseDcEventPassThroughWithSuccessIfEmptyEvent(t *testing.T) {
 }
 
 func TestDcEventStringPanicsIfStringMapEntryNotFound(t *testing.T) {
-	var evt uenibreader.DcEventType = uenibreader.DC_EVENT_GNB_ALL_UES_REMOVE + 1
+	var evt uenibreader.DcEventType = uenibreader.DC_EVENT_BEARER_MODIFY + 1
 	assert.Panics(t, func() { evt.String() },
 		"Too big event type didn't cause panic. Check event string map implementation")
 }
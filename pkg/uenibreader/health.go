@@ -0,0 +1,158 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/nokia/ue-nib-library/internal"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+//HealthStatus is the health of a Reader returned by Health().
+//Reader is healthy, if the database backend can be read and the Reader has not been closed.
+type HealthStatus struct {
+	Healthy       bool                 `json:"healthy"`
+	Backend       BackendHealth        `json:"backend"`
+	Subscriptions []SubscriptionHealth `json:"subscriptions"`
+}
+
+//BackendHealth is the result of the database backend connectivity check.
+type BackendHealth struct {
+	Reachable bool          `json:"reachable"`
+	Latency   time.Duration `json:"latency"`
+	Error     string        `json:"error,omitempty"`
+}
+
+//SubscriptionHealth is the status of the event subscription of a gNB and an event category.
+//LastEventAt and LastEvent are set after the first event has been received.
+type SubscriptionHealth struct {
+	GNb           string     `json:"gNb"`
+	EventCategory string     `json:"eventCategory"`
+	Active        bool       `json:"active"`
+	SubscribedAt  time.Time  `json:"subscribedAt"`
+	LastEventAt   *time.Time `json:"lastEventAt,omitempty"`
+	LastEvent     string     `json:"lastEvent,omitempty"`
+}
+
+//healthState keeps the subscriptions of a Reader for the health check.
+type healthState struct {
+	mutex         sync.Mutex
+	closed        bool
+	subscriptions map[subscriptionKey]*SubscriptionHealth
+}
+
+type subscriptionKey struct {
+	gNb           string
+	eventCategory EventCategory
+}
+
+//Health checks the health of the Reader: it verifies the database backend connectivity by reading
+//a key, which does not exist, and reports the status of the event subscriptions with the last
+//event received per subscribed gNB and event category. A subscription is active, if it has been
+//subscribed successfully and the Reader has not been closed since.
+//
+//The connectivity check is abandoned, when the context is done. The check itself is not
+//cancelled, because the database backend does not support it.
+func (reader *Reader) Health(ctx context.Context) *HealthStatus {
+	status := &HealthStatus{Backend: reader.checkBackend(ctx)}
+
+	reader.health.mutex.Lock()
+	closed := reader.health.closed
+	status.Subscriptions = make([]SubscriptionHealth, 0, len(reader.health.subscriptions))
+	for _, s := range reader.health.subscriptions {
+		subscription := *s
+		subscription.Active = !closed
+		status.Subscriptions = append(status.Subscriptions, subscription)
+	}
+	reader.health.mutex.Unlock()
+
+	sort.Slice(status.Subscriptions, func(i, j int) bool {
+		a, b := status.Subscriptions[i], status.Subscriptions[j]
+		if a.GNb != b.GNb {
+			return a.GNb < b.GNb
+		}
+		return a.EventCategory < b.EventCategory
+	})
+	status.Healthy = status.Backend.Reachable && !closed
+	return status
+}
+
+//healthCheckKey is a key, which is never written to UE-NIB.
+const healthCheckKey = "HEALTH_CHECK"
+
+func (reader *Reader) checkBackend(ctx context.Context) BackendHealth {
+	start := time.Now()
+	result := make(chan error, 1)
+	go func() {
+		_, err := reader.db.Get(internal.GetUeNibNs(""), []string{healthCheckKey})
+		result <- err
+	}()
+	select {
+	case err := <-result:
+		if err != nil {
+			reader.logBackendError("health check failed", err)
+			return BackendHealth{Latency: time.Since(start), Error: err.Error()}
+		}
+		return BackendHealth{Reachable: true, Latency: time.Since(start)}
+	case <-ctx.Done():
+		return BackendHealth{Latency: time.Since(start), Error: ctx.Err().Error()}
+	}
+}
+
+func (reader *Reader) recordSubscription(gNb string, eventCategory EventCategory) {
+	reader.health.mutex.Lock()
+	defer reader.health.mutex.Unlock()
+	if reader.health.subscriptions == nil {
+		reader.health.subscriptions = make(map[subscriptionKey]*SubscriptionHealth)
+	}
+	reader.health.closed = false
+	reader.health.subscriptions[subscriptionKey{gNb, eventCategory}] = &SubscriptionHealth{
+		GNb:           gNb,
+		EventCategory: eventCategory.String(),
+		SubscribedAt:  time.Now(),
+	}
+}
+
+func (reader *Reader) recordEvents(gNb string, eventCategory EventCategory, events []string) {
+	if len(events) == 0 {
+		return
+	}
+	now := time.Now()
+	reader.health.mutex.Lock()
+	defer reader.health.mutex.Unlock()
+	if s, ok := reader.health.subscriptions[subscriptionKey{gNb, eventCategory}]; ok {
+		s.LastEventAt = &now
+		s.LastEvent = events[len(events)-1]
+	}
+}
+
+func (reader *Reader) recordClose() {
+	reader.health.mutex.Lock()
+	defer reader.health.mutex.Unlock()
+	reader.health.closed = true
+}
+
+//NewHealthHandler creates an http.Handler, which responds the health of the Reader as JSON.
+//The response status is 200 OK for a healthy Reader and 503 Service Unavailable otherwise.
+//The health check is done with the context of the request.
+func NewHealthHandler(reader *Reader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := reader.Health(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if status.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(status)
+	})
+}
@@ -0,0 +1,97 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type blockingDb struct {
+	*uenibmem.Db
+	release chan struct{}
+}
+
+func (db blockingDb) Get(ns string, keys []string) (map[string]interface{}, error) {
+	<-db.release
+	return db.Db.Get(ns, keys)
+}
+
+func TestHealthReportsSubscriptionsAndLastEvents(t *testing.T) {
+	db := uenibmem.NewDb()
+	reader := uenibreader.NewReaderWithDbBackend(db)
+	err := reader.SubscribeEvents([]string{"gnb2", "gnb1"}, []uenibreader.EventCategory{uenibreader.DualConnectivity},
+		func(gNb string, eventCategory uenibreader.EventCategory, events []string) {})
+	assert.Nil(t, err)
+	db.Publish("uenib/gnb1", "gnb1_DUAL_CONNECTIVITY", "gnb1#2#1_ADD", "gnb1_DUAL_CONNECTIVITY", "gnb1#2#1_REMOVE")
+
+	status := reader.Health(context.Background())
+	assert.True(t, status.Healthy)
+	assert.True(t, status.Backend.Reachable)
+	assert.Equal(t, "", status.Backend.Error)
+	assert.Equal(t, 2, len(status.Subscriptions))
+	gnb1, gnb2 := status.Subscriptions[0], status.Subscriptions[1]
+	assert.Equal(t, "gnb1", gnb1.GNb)
+	assert.Equal(t, "DUAL_CONNECTIVITY", gnb1.EventCategory)
+	assert.True(t, gnb1.Active)
+	assert.False(t, gnb1.SubscribedAt.IsZero())
+	assert.NotNil(t, gnb1.LastEventAt)
+	assert.Equal(t, "gnb1#2#1_REMOVE", gnb1.LastEvent)
+	assert.Equal(t, "gnb2", gnb2.GNb)
+	assert.Nil(t, gnb2.LastEventAt)
+
+	assert.Nil(t, reader.Close())
+	status = reader.Health(context.Background())
+	assert.False(t, status.Healthy)
+	assert.False(t, status.Subscriptions[0].Active)
+}
+
+func TestHealthIsUnhealthyIfBackendDoesNotRespond(t *testing.T) {
+	db := blockingDb{Db: uenibmem.NewDb(), release: make(chan struct{})}
+	defer close(db.release)
+	reader := uenibreader.NewReaderWithDbBackend(db)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	status := reader.Health(ctx)
+	assert.False(t, status.Healthy)
+	assert.False(t, status.Backend.Reachable)
+	assert.Equal(t, "context deadline exceeded", status.Backend.Error)
+	assert.Empty(t, status.Subscriptions)
+}
+
+func TestHealthIsUnhealthyIfBackendFails(t *testing.T) {
+	reader := uenibreader.NewReaderWithDbBackend(failingGetDb{uenibmem.NewDb()})
+	status := reader.Health(context.Background())
+	assert.False(t, status.Healthy)
+	assert.Equal(t, "Some DB Backend Error", status.Backend.Error)
+}
+
+func TestHealthHandlerRespondsJSON(t *testing.T) {
+	reader := uenibreader.NewReaderWithDbBackend(uenibmem.NewDb())
+	handler := uenibreader.NewHealthHandler(reader)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/ready", nil))
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var status uenibreader.HealthStatus
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.True(t, status.Healthy)
+	assert.Equal(t, []uenibreader.SubscriptionHealth{}, status.Subscriptions)
+
+	handler = uenibreader.NewHealthHandler(uenibreader.NewReaderWithDbBackend(failingGetDb{uenibmem.NewDb()}))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/ready", nil))
+	assert.Equal(t, 503, rec.Code)
+}
@@ -0,0 +1,134 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+//LogLevel defines the severity of a log message.
+type LogLevel int
+
+const (
+	LOG_LEVEL_DEBUG LogLevel = iota
+	LOG_LEVEL_INFO
+	LOG_LEVEL_WARNING
+	LOG_LEVEL_ERROR
+)
+
+//String returns log level as a string.
+func (level LogLevel) String() string {
+	levelStrMap := [...]string{
+		"DEBUG",
+		"INFO",
+		"WARNING",
+		"ERROR",
+	}
+	if level < LOG_LEVEL_DEBUG || level > LOG_LEVEL_ERROR {
+		return "UNKNOWN"
+	}
+	return levelStrMap[level]
+}
+
+//Logger is the interface of the structured logger used by Reader. Parameter keysAndValues
+//contains the fields of the message as alternating keys and values, for example
+//"gNb", "somegnb", "ueID", "UeID:[...]".
+//
+//Reader logs the event subscriptions and closing on LOG_LEVEL_INFO, malformed received events
+//on LOG_LEVEL_WARNING and database backend errors on LOG_LEVEL_ERROR. Backend errors implementing
+//FailFastError are logged on LOG_LEVEL_DEBUG.
+type Logger interface {
+	Log(level LogLevel, msg string, keysAndValues ...interface{})
+}
+
+//SetLogger sets the logger of the Reader. By default Reader does not log. SetLogger() should
+//be called before the Reader is used.
+func (reader *Reader) SetLogger(logger Logger) {
+	reader.logger = logger
+}
+
+//logBackendError logs a database backend error.
+func (reader *Reader) logBackendError(msg string, err error, keysAndValues ...interface{}) {
+	level := LOG_LEVEL_ERROR
+	if isFailFast(err) {
+		level = LOG_LEVEL_DEBUG
+	}
+	reader.log(level, msg, append(keysAndValues, "error", err)...)
+}
+
+func (reader *Reader) log(level LogLevel, msg string, keysAndValues ...interface{}) {
+	if reader.logger != nil {
+		reader.logger.Log(level, msg, keysAndValues...)
+	}
+}
+
+//NewStdLogger creates a Logger, which writes the messages of the given level and above to the
+//standard library logger. The fields are appended to the message as key=value pairs.
+func NewStdLogger(logger *log.Logger, level LogLevel) Logger {
+	return &stdLogger{logger: logger, level: level}
+}
+
+type stdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+func (l *stdLogger) Log(level LogLevel, msg string, keysAndValues ...interface{}) {
+	if level < l.level {
+		return
+	}
+	l.logger.Print(level.String() + " " + formatLogMessage(msg, keysAndValues))
+}
+
+//RicLogger is the part of the RIC logging library API (the MdcLogger of package golog) used by
+//the adapter returned by NewRicLogger(). The RIC logging library filters the messages by level.
+type RicLogger interface {
+	Error(pattern string, args ...interface{})
+	Warning(pattern string, args ...interface{})
+	Info(pattern string, args ...interface{})
+	Debug(pattern string, args ...interface{})
+}
+
+//NewRicLogger creates a Logger, which writes the messages to the RIC logging library logger.
+//The fields are appended to the message as key=value pairs.
+func NewRicLogger(logger RicLogger) Logger {
+	return &ricLogger{logger: logger}
+}
+
+type ricLogger struct {
+	logger RicLogger
+}
+
+func (l *ricLogger) Log(level LogLevel, msg string, keysAndValues ...interface{}) {
+	str := formatLogMessage(msg, keysAndValues)
+	switch level {
+	case LOG_LEVEL_DEBUG:
+		l.logger.Debug("%s", str)
+	case LOG_LEVEL_INFO:
+		l.logger.Info("%s", str)
+	case LOG_LEVEL_WARNING:
+		l.logger.Warning("%s", str)
+	default:
+		l.logger.Error("%s", str)
+	}
+}
+
+func formatLogMessage(msg string, keysAndValues []interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&b, " %v=%q", keysAndValues[i], fmt.Sprint(keysAndValues[i+1]))
+		} else {
+			fmt.Fprintf(&b, " %v=", keysAndValues[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,131 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"testing"
+)
+
+type logEntry struct {
+	level uenibreader.LogLevel
+	msg   string
+	kv    []interface{}
+}
+
+type recordingLogger struct {
+	entries []logEntry
+}
+
+func (l *recordingLogger) Log(level uenibreader.LogLevel, msg string, keysAndValues ...interface{}) {
+	l.entries = append(l.entries, logEntry{level, msg, keysAndValues})
+}
+
+type failingGetDb struct {
+	*uenibmem.Db
+}
+
+func (db failingGetDb) Get(ns string, keys []string) (map[string]interface{}, error) {
+	return nil, errors.New("Some DB Backend Error")
+}
+
+func TestReaderLogsSubscriptionsAndMalformedEvents(t *testing.T) {
+	db := uenibmem.NewDb()
+	logger := &recordingLogger{}
+	reader := uenibreader.NewReaderWithDbBackend(db)
+	reader.SetLogger(logger)
+	var received []string
+	err := reader.SubscribeEvents([]string{"somegnb"}, []uenibreader.EventCategory{uenibreader.DualConnectivity},
+		func(gNb string, eventCategory uenibreader.EventCategory, events []string) {
+			received = append(received, events...)
+		})
+	assert.Nil(t, err)
+	db.Publish("uenib/somegnb", "somegnb_DUAL_CONNECTIVITY", "somegnb#2#1_ADD")
+	db.Publish("uenib/somegnb", "somegnb_DUAL_CONNECTIVITY", "somegnb#2#1_x_S1UL_TUNNEL_ESTABLISH")
+	assert.Nil(t, reader.Close())
+
+	assert.Equal(t, 2, len(received))
+	assert.Equal(t, 3, len(logger.entries))
+	assert.Equal(t, logEntry{uenibreader.LOG_LEVEL_INFO, "events subscribed",
+		[]interface{}{"gNb", "somegnb", "eventCategory", uenibreader.DualConnectivity}}, logger.entries[0])
+	assert.Equal(t, uenibreader.LOG_LEVEL_WARNING, logger.entries[1].level)
+	assert.Equal(t, "malformed event", logger.entries[1].msg)
+	assert.Equal(t, uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "2", ENbUeX2ApID: "1"}, logger.entries[1].kv[5])
+	assert.Equal(t, "somegnb#2#1_x_S1UL_TUNNEL_ESTABLISH", logger.entries[1].kv[7])
+	assert.Equal(t, logEntry{uenibreader.LOG_LEVEL_INFO, "reader closed", nil}, logger.entries[2])
+}
+
+func TestReaderLogsBackendErrors(t *testing.T) {
+	logger := &recordingLogger{}
+	reader := uenibreader.NewReaderWithDbBackend(failingGetDb{uenibmem.NewDb()})
+	reader.SetLogger(logger)
+	ueID := &uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"}
+	_, err := reader.GetPsCell(ueID)
+	assert.True(t, uenibreader.IsBackendError(err))
+
+	assert.Equal(t, 1, len(logger.entries))
+	assert.Equal(t, uenibreader.LOG_LEVEL_ERROR, logger.entries[0].level)
+	assert.Equal(t, "database read failed", logger.entries[0].msg)
+	assert.Equal(t, []interface{}{"gNb", "somegnb", "ueID", ueID, "error", errors.New("Some DB Backend Error")},
+		logger.entries[0].kv)
+}
+
+func TestStdLoggerFiltersByLevelAndFormatsFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := uenibreader.NewStdLogger(log.New(&buf, "", 0), uenibreader.LOG_LEVEL_INFO)
+	logger.Log(uenibreader.LOG_LEVEL_DEBUG, "not logged")
+	logger.Log(uenibreader.LOG_LEVEL_WARNING, "malformed event", "gNb", "somegnb", "event", "x y", "odd")
+	assert.Equal(t, "WARNING malformed event gNb=\"somegnb\" event=\"x y\" odd=\n", buf.String())
+}
+
+type fakeRicLogger struct {
+	lines []string
+}
+
+func (l *fakeRicLogger) log(level string, pattern string, args ...interface{}) {
+	l.lines = append(l.lines, level+" "+fmt.Sprintf(pattern, args...))
+}
+
+func (l *fakeRicLogger) Error(pattern string, args ...interface{}) {
+	l.log("ERROR", pattern, args...)
+}
+
+func (l *fakeRicLogger) Warning(pattern string, args ...interface{}) {
+	l.log("WARNING", pattern, args...)
+}
+
+func (l *fakeRicLogger) Info(pattern string, args ...interface{}) {
+	l.log("INFO", pattern, args...)
+}
+
+func (l *fakeRicLogger) Debug(pattern string, args ...interface{}) {
+	l.log("DEBUG", pattern, args...)
+}
+
+func TestRicLoggerMapsLevels(t *testing.T) {
+	ric := &fakeRicLogger{}
+	logger := uenibreader.NewRicLogger(ric)
+	logger.Log(uenibreader.LOG_LEVEL_DEBUG, "a")
+	logger.Log(uenibreader.LOG_LEVEL_INFO, "b", "gNb", "somegnb")
+	logger.Log(uenibreader.LOG_LEVEL_WARNING, "%c")
+	logger.Log(uenibreader.LOG_LEVEL_ERROR, "d")
+	assert.Equal(t, []string{"DEBUG a", "INFO b gNb=\"somegnb\"", "WARNING %c", "ERROR d"}, ric.lines)
+}
+
+func TestLogLevelString(t *testing.T) {
+	assert.Equal(t, "DEBUG", uenibreader.LOG_LEVEL_DEBUG.String())
+	assert.Equal(t, "ERROR", uenibreader.LOG_LEVEL_ERROR.String())
+	assert.Equal(t, "UNKNOWN", uenibreader.LogLevel(10).String())
+}
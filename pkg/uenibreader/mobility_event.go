@@ -0,0 +1,124 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader
+
+import (
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"strings"
+)
+
+//MobilityEventType defines possible mobility event types. MOBILITY_EVENT_PSCELL_CHANGE is an
+//alias of DC_EVENT_PSCELL_CHANGE, see the Mobility event category.
+type MobilityEventType int
+
+const (
+	MOBILITY_EVENT_UNKNOWN MobilityEventType = iota
+	MOBILITY_EVENT_PSCELL_CHANGE
+	MOBILITY_EVENT_SGNB_CHANGE
+	MOBILITY_EVENT_INTER_MENB_HANDOVER
+)
+
+//MobilityEvent defines all the entities what can be parsed from received mobility event.
+//PsCell is set for MOBILITY_EVENT_PSCELL_CHANGE event type.
+//TargetUeID is set for MOBILITY_EVENT_SGNB_CHANGE and MOBILITY_EVENT_INTER_MENB_HANDOVER
+//event types and it identifies the UE after the mobility procedure: in case of SgNB change
+//GNb is the target SgNB and in case of inter-MeNB handover ENb and ENbUeX2ApID are the ones
+//allocated by the target MeNB.
+type MobilityEvent struct {
+	EventType  MobilityEventType
+	UeID       uenib.UeID
+	PsCell     uenib.Cell
+	TargetUeID uenib.UeID
+}
+
+//String returns mobility event type as a string.
+func (mobEvt MobilityEventType) String() string {
+	evtStrMap := [...]string{
+		"UNKNOWN",
+		"_PSCELL_CHANGE",
+		"_SGNB_CHANGE",
+		"_INTER_MENB_HANDOVER",
+	}
+	if mobEvt > MOBILITY_EVENT_INTER_MENB_HANDOVER {
+		panic(fmt.Sprintf("Mobility event ID %d overflows name string array.\n", mobEvt))
+	}
+	return evtStrMap[mobEvt]
+}
+
+//ParseMobilityEvent parses an event string of the mobility category and it returns
+//two values: parsing results in a return value of 'MobilityEvent' type and status of parsing
+//in a return value of standard 'error' type. Error status is returned, if parsing has
+//been failed for some reason. If parsing has succeeded, parsed values from event string
+//are returned inside 'MobilityEvent' type. Note that success status is also returned, when
+//event type in parsed event string is unknown for the parser. In this case event type is
+//set to MOBILITY_EVENT_UNKNOWN in returned 'MobilityEvent' type.
+//
+//Both the legacy event string format and the versioned event encoding are parsed. An error
+//is returned for a versioned event of an unsupported encoding version.
+func ParseMobilityEvent(evtStr string) (MobilityEvent, error) {
+	if version, payload, ok := splitVersionedEvent(evtStr); ok {
+		return parseVersionedMobilityEvent(version, payload)
+	}
+	return parseLegacyMobilityEvent(evtStr)
+}
+
+func parseLegacyMobilityEvent(evtStr string) (MobilityEvent, error) {
+	var err error
+	var ret MobilityEvent
+
+	for evtType := MOBILITY_EVENT_PSCELL_CHANGE; evtType <= MOBILITY_EVENT_INTER_MENB_HANDOVER; evtType++ {
+		if strings.HasSuffix(evtStr, evtType.String()) {
+			ret.EventType = evtType
+			break
+		}
+	}
+	if ret.EventType == MOBILITY_EVENT_UNKNOWN {
+		return ret, err
+	}
+
+	fields := strings.Split(strings.TrimSuffix(evtStr, ret.EventType.String()), "_")
+	if cnt := len(fields); cnt != 2 {
+		return ret, fmt.Errorf("Event '%s' parse failure: no UE ID or target field in '%s'",
+			ret.EventType.String(), evtStr)
+	}
+	if ret.UeID, err = parseUeIDFromEvent(ret.EventType.String(), fields[0]); err != nil {
+		return ret, err
+	}
+
+	switch ret.EventType {
+	case MOBILITY_EVENT_PSCELL_CHANGE:
+		ret.PsCell, err = parseCellFromEvent(ret.EventType.String(), fields[1])
+	case MOBILITY_EVENT_SGNB_CHANGE:
+		err = parseSgNbChangeTarget(fields[1], &ret)
+	case MOBILITY_EVENT_INTER_MENB_HANDOVER:
+		err = parseInterMeNbHandoverTarget(fields[1], &ret)
+	}
+	return ret, err
+}
+
+func parseSgNbChangeTarget(targetEvtStr string, ret *MobilityEvent) error {
+	if targetEvtStr == "" || strings.Contains(targetEvtStr, "#") {
+		return fmt.Errorf("Event '%s' parse failure: wrong target gNB field in '%s'",
+			ret.EventType.String(), targetEvtStr)
+	}
+	ret.TargetUeID = uenib.UeID{GNb: targetEvtStr}
+	return nil
+}
+
+func parseInterMeNbHandoverTarget(targetEvtStr string, ret *MobilityEvent) error {
+	targetFields := strings.Split(targetEvtStr, "#")
+	if len(targetFields) != 2 || targetFields[1] == "" {
+		return fmt.Errorf("Event '%s' parse failure: wrong target eNB fields in '%s'",
+			ret.EventType.String(), targetEvtStr)
+	}
+	ret.TargetUeID = ret.UeID
+	ret.TargetUeID.ENb = targetFields[0]
+	ret.TargetUeID.ENbUeX2ApID = targetFields[1]
+	return nil
+}
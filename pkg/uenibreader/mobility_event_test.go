@@ -0,0 +1,137 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+var someMobilityUeID = uenib.UeID{
+	GNb:         "somegnb:310-410-b5c67788",
+	GNbUeX2ApID: "100",
+	ENbUeX2ApID: "200",
+}
+
+func TestSubscribeEventsCanSubscribeMobilityEventCategory(t *testing.T) {
+	m, i := setup()
+	m.On("SubscribeChannel", someEvNs, mock.AnythingOfType("func(string, ...string)"),
+		[]string{someGNb + "_MOBILITY"}).Return(nil).Once()
+
+	err := i.SubscribeEvents([]string{someGNb}, []uenibreader.EventCategory{uenibreader.Mobility},
+		func(string, uenibreader.EventCategory, []string) {})
+	assert.Nil(t, err)
+	m.AssertExpectations(t)
+}
+
+func TestParseMobilityEventSuccessForPsCellChangeEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseMobilityEvent(
+		"somegnb:310-410-b5c67788#100#200_500#632628#310-410-b5c677880_PSCELL_CHANGE")
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.MobilityEvent{
+		EventType: uenibreader.MOBILITY_EVENT_PSCELL_CHANGE,
+		UeID:      someMobilityUeID,
+		PsCell: uenib.Cell{
+			Pci:     500,
+			SsbFreq: 632628,
+			NrCgi:   uenib.NrCgi{Mcc: "310", Mnc: "410", NrCellID: 0xb5c677880},
+		},
+	}, retEvt)
+}
+
+func TestMobilityPsCellChangeEventIsAliasOfDcPsCellChangeEvent(t *testing.T) {
+	evtStr := "somegnb:310-410-b5c67788#100#200_500#632628#310-410-b5c677880_PSCELL_CHANGE"
+	mobEvt, err := uenibreader.ParseMobilityEvent(evtStr)
+	assert.Nil(t, err)
+	dcEvt, err := uenibreader.ParseDcEvent(evtStr)
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.DC_EVENT_PSCELL_CHANGE, dcEvt.EventType)
+	assert.Equal(t, dcEvt.UeID, mobEvt.UeID)
+	assert.Equal(t, dcEvt.PsCell, mobEvt.PsCell)
+}
+
+func TestParseMobilityEventSuccessForSgNbChangeEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseMobilityEvent(
+		"somegnb:310-410-b5c67788#100#200_othergnb:310-410-b5c67799_SGNB_CHANGE")
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.MobilityEvent{
+		EventType:  uenibreader.MOBILITY_EVENT_SGNB_CHANGE,
+		UeID:       someMobilityUeID,
+		TargetUeID: uenib.UeID{GNb: "othergnb:310-410-b5c67799"},
+	}, retEvt)
+}
+
+func TestParseMobilityEventSuccessForInterMeNbHandoverEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseMobilityEvent(
+		"somegnb:310-410-b5c67788#100#200_someenb:310-410-1234#300_INTER_MENB_HANDOVER")
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.MobilityEvent{
+		EventType: uenibreader.MOBILITY_EVENT_INTER_MENB_HANDOVER,
+		UeID:      someMobilityUeID,
+		TargetUeID: uenib.UeID{
+			GNb:         "somegnb:310-410-b5c67788",
+			ENb:         "someenb:310-410-1234",
+			GNbUeX2ApID: "100",
+			ENbUeX2ApID: "300",
+		},
+	}, retEvt)
+}
+
+func TestParseMobilityEventPassThroughWithSuccessForUnknownEvent(t *testing.T) {
+	retEvt, err := uenibreader.ParseMobilityEvent("somegnb:310-410-b5c67788#100#200_SOME_UNKNOWN_EVENT")
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.MobilityEvent{}, retEvt)
+}
+
+func TestParseMobilityEventReturnsErrorForIllegalEvents(t *testing.T) {
+	illegalEvents := []string{
+		"somegnb:310-410-b5c67788#100#200_PSCELL_CHANGE",
+		"100#200_500#632628_PSCELL_CHANGE",
+		"somegnb:310-410-b5c67788#100#200_IamNotInt#632628_PSCELL_CHANGE",
+		"somegnb:310-410-b5c67788#100#200__SGNB_CHANGE",
+		"somegnb:310-410-b5c67788#100#200_othergnb#1_SGNB_CHANGE",
+		"somegnb:310-410-b5c67788#100#200_someenb_INTER_MENB_HANDOVER",
+		"somegnb:310-410-b5c67788#100#200_someenb#_INTER_MENB_HANDOVER",
+	}
+	for _, illegalEvent := range illegalEvents {
+		_, err := uenibreader.ParseMobilityEvent(illegalEvent)
+		assert.NotNil(t, err, illegalEvent)
+	}
+}
+
+func TestMobilityEventStringPanicsIfStringMapEntryNotFound(t *testing.T) {
+	var evt uenibreader.MobilityEventType = uenibreader.MOBILITY_EVENT_INTER_MENB_HANDOVER + 1
+	assert.Panics(t, func() { _ = evt.String() },
+		"Too big event type didn't cause panic. Check event string map implementation")
+}
+
+func TestParseEventDispatchesByEventCategory(t *testing.T) {
+	dcEvt, err := uenibreader.ParseEvent(uenibreader.DualConnectivity, dcAddEvent)
+	assert.Nil(t, err)
+	assert.Equal(t, expParsedDcAddEvent, dcEvt)
+
+	mobEvt, err := uenibreader.ParseEvent(uenibreader.Mobility,
+		"somegnb:310-410-b5c67788#100#200_othergnb_SGNB_CHANGE")
+	assert.Nil(t, err)
+	assert.Equal(t, uenibreader.MOBILITY_EVENT_SGNB_CHANGE, mobEvt.(uenibreader.MobilityEvent).EventType)
+}
+
+func TestParseEventReturnsErrorForUnknownEventCategory(t *testing.T) {
+	ret, err := uenibreader.ParseEvent(uenibreader.EventCategory(100), dcAddEvent)
+	assert.Nil(t, ret)
+	assert.Equal(t, true, uenibreader.IsValidationError(err))
+}
+
+func TestEventCategoryString(t *testing.T) {
+	assert.Equal(t, "DUAL_CONNECTIVITY", uenibreader.DualConnectivity.String())
+	assert.Equal(t, "MOBILITY", uenibreader.Mobility.String())
+	assert.Equal(t, "Unknown", uenibreader.EventCategory(-1).String())
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"github.com/nokia/ue-nib-library/internal"
 	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -19,6 +20,15 @@ import (
 //GetMeNbUEX2APID returns UE MeNbUEX2APID.
 //Parameter ueID identifies User equipment (UE).
 func (reader *Reader) GetMeNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
+	var ret uint32
+	err := reader.retry("GetMeNbUEX2APID", func() (err error) {
+		ret, err = reader.getMeNbUEX2APID(ueID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getMeNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
 	if len(ueID.GNb) == 0 {
 		return uint32(0), toValidationError(ueID, errors.New(fmt.Sprintf("%s :: missing GNb", ueID.String())))
 	}
@@ -34,12 +44,30 @@ func (reader *Reader) GetMeNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
 		return uint32(0), err
 	}
 
-	return q.getKeyUint32Value(ueID, key)
+	val, err := q.getKeyUint32Value(ueID, key)
+	if err != nil {
+		return uint32(0), err
+	}
+	id := *ueID
+	id.ENbUeX2ApID = q.kvMap[key].(string)
+	if err = reader.checkStale(&id); err != nil {
+		return uint32(0), err
+	}
+	return val, nil
 }
 
 //GetSgNbUEX2APID returns UE SgNbUEX2APID.
 //Parameter ueID identifies User equipment (UE).
 func (reader *Reader) GetSgNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
+	var ret uint32
+	err := reader.retry("GetSgNbUEX2APID", func() (err error) {
+		ret, err = reader.getSgNbUEX2APID(ueID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getSgNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
 	if len(ueID.GNb) == 0 {
 		return uint32(0), toValidationError(ueID, errors.New(fmt.Sprintf("%s :: missing GNb", ueID.String())))
 	}
@@ -55,13 +83,97 @@ func (reader *Reader) GetSgNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
 		return uint32(0), err
 	}
 
-	return q.getKeyUint32Value(ueID, key)
+	val, err := q.getKeyUint32Value(ueID, key)
+	if err != nil {
+		return uint32(0), err
+	}
+	if err = reader.checkStale(ueID); err != nil {
+		return uint32(0), err
+	}
+	return val, nil
+}
+
+//GetUeIDs returns identifiers of all the UEs of a gNB sorted by ENbUeX2ApID. UEs are found
+//by their MeNB UE X2AP ID to SgNB UE X2AP ID mapping, so both X2AP IDs are set in the
+//returned UE identifiers. The UEs treated as stale by the filter set by SetStaleThreshold() are
+//not returned.
+//Parameter gNb identifies GNb RanName what is form of: <Antenna-Type>:<3 MCC digits>-<3 MNC digits>-<Node ID>.
+func (reader *Reader) GetUeIDs(gNb string) ([]uenib.UeID, error) {
+	var ret []uenib.UeID
+	err := reader.retry("GetUeIDs", func() (err error) {
+		ret, err = reader.getUeIDs(gNb)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getUeIDs(gNb string) ([]uenib.UeID, error) {
+	ueIDs, err := reader.getAllUeIDs(gNb)
+	if err != nil {
+		return nil, err
+	}
+	return reader.filterStaleUeIDs(gNb, ueIDs)
+}
+
+//getAllUeIDs returns the UEs of a gNB without the stale UE filter.
+func (reader *Reader) getAllUeIDs(gNb string) ([]uenib.UeID, error) {
+	gNbID := &uenib.UeID{GNb: gNb}
+	if len(gNb) == 0 {
+		return nil, toValidationError(gNbID, errors.New(fmt.Sprintf("%s :: missing GNb", gNbID.String())))
+	}
+
+	keys, err := reader.db.GetAll(internal.GetUeNibNs(gNb))
+	if err != nil {
+		reader.logBackendError("database read failed", err, "gNb", gNb)
+		return nil, toBackendError(gNbID, err)
+	}
+	var ueMapKeys []string
+	for _, key := range keys {
+		if _, ok := internal.ParseDbKeyUeMapENbToGNbUeX2ApID(key); ok {
+			ueMapKeys = append(ueMapKeys, key)
+		}
+	}
+	if len(ueMapKeys) == 0 {
+		return nil, nil
+	}
+
+	q, err := reader.newGetQuery(gNbID, ueMapKeys)
+	if err != nil {
+		return nil, err
+	}
+	ueIDs := make([]uenib.UeID, 0, len(ueMapKeys))
+	for _, key := range ueMapKeys {
+		//UE may have been removed after the keys were listed.
+		val, ok := q.kvMap[key]
+		if !ok || val == nil {
+			continue
+		}
+		eNbUeX2ApID, _ := internal.ParseDbKeyUeMapENbToGNbUeX2ApID(key)
+		ueIDs = append(ueIDs, uenib.UeID{
+			GNb:         gNb,
+			GNbUeX2ApID: val.(string),
+			ENbUeX2ApID: eNbUeX2ApID,
+		})
+	}
+	sort.Slice(ueIDs, func(i, j int) bool {
+		return internal.LessX2ApID(ueIDs[i].ENbUeX2ApID, ueIDs[j].ENbUeX2ApID)
+	})
+	return ueIDs, nil
 }
 
 //GetPsCell returns UE radio resource information container, called as a Primary
 //Cell in secondary Node (PSCell).
 //Parameter ueID identifies User equipment (UE).
 func (reader *Reader) GetPsCell(ueID *uenib.UeID) (*uenib.Cell, error) {
+	var ret *uenib.Cell
+	err := reader.retry("GetPsCell", func() (err error) {
+		ret, err = reader.getPsCell(ueID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getPsCell(ueID *uenib.UeID) (*uenib.Cell, error) {
 	var q *query
 	var retCell uenib.Cell
 
@@ -86,10 +198,100 @@ func (reader *Reader) GetPsCell(ueID *uenib.UeID) (*uenib.Cell, error) {
 	return &retCell, err
 }
 
+//GetServingCells returns UE serving cells in secondary node: a Primary Cell in secondary Node
+//(PSCell) and secondary cells (SCells) used in carrier aggregation.
+//NR-CGI and the latest measurement results of a cell are returned only if they are known
+//by UE-NIB, otherwise NrCgi is empty and Measurement is nil. SCells is empty if UE does not
+//have any secondary cells.
+//Parameter ueID identifies User equipment (UE).
+func (reader *Reader) GetServingCells(ueID *uenib.UeID) (*uenib.ServingCells, error) {
+	var ret *uenib.ServingCells
+	err := reader.retry("GetServingCells", func() (err error) {
+		ret, err = reader.getServingCells(ueID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getServingCells(ueID *uenib.UeID) (*uenib.ServingCells, error) {
+	var q *query
+	var sCellKeys []string
+	var retCells uenib.ServingCells
+
+	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
+	if err != nil {
+		return nil, err
+	}
+
+	sCellIndexesKey := internal.DbKeyUeSCellIndexes(id)
+
+	if q, err = reader.newGetQuery(ueID, append(internal.GetPsCellAllDbKeys(id), sCellIndexesKey)); err != nil {
+		return nil, err
+	}
+
+	if retCells.PsCell, err = q.getPsCellValue(id); err != nil {
+		return nil, err
+	}
+
+	sCellIndexes, err := q.getOptionalKeySCellIndexesValue(id, sCellIndexesKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(sCellIndexes) == 0 {
+		return &retCells, err
+	}
+
+	for _, sCellIndex := range sCellIndexes {
+		sCellKeys = append(sCellKeys, internal.GetSCellAllDbKeys(id, sCellIndex)...)
+	}
+
+	if q, err = reader.newGetQuery(ueID, sCellKeys); err != nil {
+		return nil, err
+	}
+
+	if retCells.SCells, err = q.getSCellsValue(id, sCellIndexes); err != nil {
+		return nil, err
+	}
+	return &retCells, err
+}
+
+func (q *query) getPsCellValue(id *uenib.UeID) (uenib.Cell, error) {
+	return q.getCellValue(id, internal.DbKeyPsCellPci(id), internal.DbKeyPsCellSsbFreq(id),
+		internal.DbKeyPsCellNrCgi(id), internal.DbKeyPsCellRsrp(id), internal.DbKeyPsCellRsrq(id),
+		internal.DbKeyPsCellSinr(id))
+}
+
+func (q *query) getSCellsValue(id *uenib.UeID, sCellIndexes []uenib.SCellIndex) ([]uenib.SCell, error) {
+	var err error
+	var retSCells []uenib.SCell
+
+	for _, sCellIndex := range sCellIndexes {
+		var sCell uenib.SCell
+		sCell.SCellIndex = sCellIndex
+		if sCell.Cell, err = q.getCellValue(id, internal.DbKeySCellPci(id, sCellIndex),
+			internal.DbKeySCellSsbFreq(id, sCellIndex), internal.DbKeySCellNrCgi(id, sCellIndex),
+			internal.DbKeySCellRsrp(id, sCellIndex), internal.DbKeySCellRsrq(id, sCellIndex),
+			internal.DbKeySCellSinr(id, sCellIndex)); err != nil {
+			return nil, err
+		}
+		retSCells = append(retSCells, sCell)
+	}
+	return retSCells, err
+}
+
 //GetState returns UE's last known state in UE-NIB and the last GTP Cause code if there has
 //been any Cause IEs set in any UE's X2 messages.
 //Parameter ueID identifies User equipment (UE).
 func (reader *Reader) GetState(ueID *uenib.UeID) (*uenib.UeState, error) {
+	var ret *uenib.UeState
+	err := reader.retry("GetState", func() (err error) {
+		ret, err = reader.getState(ueID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getState(ueID *uenib.UeID) (*uenib.UeState, error) {
 	var q *query
 	var retState uenib.UeState
 
@@ -120,9 +322,100 @@ func (reader *Reader) GetState(ueID *uenib.UeID) (*uenib.UeState, error) {
 	return &retState, err
 }
 
+//GetStateHistory returns UE's state history, in other words the X2 messages and Cause IEs
+//UE-NIB has detected, ordered by time from the oldest to the newest one.
+//The history is maintained by the UE-NIB writer as a capped list per UE: the writer appends
+//a new state entry with an increasing sequence number every time it updates UE's state and
+//removes the oldest entries when the list is full. Entries removed by the writer during the
+//query are skipped.
+//An empty history is returned, if the writer has not recorded any state entries of the UE.
+//A value not found failure is returned only, if the UE itself is not found.
+//Parameter ueID identifies User equipment (UE).
+//Parameter limit defines the maximum number of the newest entries to be returned. All entries
+//are returned, if limit is zero.
+func (reader *Reader) GetStateHistory(ueID *uenib.UeID, limit int) ([]uenib.UeState, error) {
+	var ret []uenib.UeState
+	err := reader.retry("GetStateHistory", func() (err error) {
+		ret, err = reader.getStateHistory(ueID, limit)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getStateHistory(ueID *uenib.UeID, limit int) ([]uenib.UeState, error) {
+	var q *query
+	var strVal string
+	var historyKeys []string
+	retStates := []uenib.UeState{}
+
+	if limit < 0 {
+		return nil, toValidationError(ueID, errors.New(fmt.Sprintf("%s :: negative history limit %d", ueID.String(), limit)))
+	}
+
+	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
+	if err != nil {
+		return nil, err
+	}
+
+	seqsKey := internal.DbKeyUeStateHistorySeqs(id)
+	mapKey := internal.DbKeyUeMapENbToGNbUeX2ApID(id)
+
+	if q, err = reader.newGetQuery(ueID, []string{seqsKey, mapKey}); err != nil {
+		return nil, err
+	}
+
+	if strVal, err = q.getKeyStringValue(ueID, seqsKey); err != nil {
+		//UE without recorded history has an empty history.
+		if _, mapErr := q.getKeyStringValue(id, mapKey); mapErr != nil {
+			return nil, mapErr
+		}
+		return retStates, nil
+	}
+
+	seqs, err := parseSeqsStringToSortedSeqSlice(ueID, strVal)
+	if err != nil {
+		return nil, withKey(err, seqsKey)
+	}
+	if limit > 0 && len(seqs) > limit {
+		seqs = seqs[len(seqs)-limit:]
+	}
+
+	for _, seq := range seqs {
+		historyKeys = append(historyKeys, internal.DbKeyUeStateHistoryEvent(id, seq), internal.DbKeyUeStateHistoryCause(id, seq))
+	}
+
+	if q, err = reader.newGetQuery(ueID, historyKeys); err != nil {
+		return nil, err
+	}
+
+	for _, seq := range seqs {
+		var state uenib.UeState
+		if state.Event, err = q.getKeyStringValue(id, internal.DbKeyUeStateHistoryEvent(id, seq)); err != nil {
+			if IsValueNotFoundFailure(err) {
+				continue
+			}
+			return nil, err
+		}
+		if val, ok := q.kvMap[internal.DbKeyUeStateHistoryCause(id, seq)]; ok && val != nil {
+			state.Cause = val.(string)
+		}
+		retStates = append(retStates, state)
+	}
+	return retStates, nil
+}
+
 //GetBearerIDs returns existing bearer identifiers (E-RAB IDs) of an UE.
 //Parameter ueID identifies User equipment (UE).
 func (reader *Reader) GetBearerIDs(ueID *uenib.UeID) ([]uenib.ErabID, error) {
+	var ret []uenib.ErabID
+	err := reader.retry("GetBearerIDs", func() (err error) {
+		ret, err = reader.getBearerIDs(ueID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getBearerIDs(ueID *uenib.UeID) ([]uenib.ErabID, error) {
 	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
 	if err != nil {
 		return nil, err
@@ -134,9 +427,17 @@ func (reader *Reader) GetBearerIDs(ueID *uenib.UeID) ([]uenib.ErabID, error) {
 //GetBearers returns active bearers (E-RABs) of an UE.
 //Parameter ueID identifies User equipment (UE).
 func (reader *Reader) GetBearers(ueID *uenib.UeID) ([]uenib.Bearer, error) {
+	var ret []uenib.Bearer
+	err := reader.retry("GetBearers", func() (err error) {
+		ret, err = reader.getBearers(ueID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getBearers(ueID *uenib.UeID) ([]uenib.Bearer, error) {
 	var q *query
 	var erabIDKeys []string
-	var retBearers []uenib.Bearer
 
 	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
 	if err != nil {
@@ -155,6 +456,12 @@ func (reader *Reader) GetBearers(ueID *uenib.UeID) ([]uenib.Bearer, error) {
 	if q, err = reader.newGetQuery(ueID, erabIDKeys); err != nil {
 		return nil, err
 	}
+	return q.getBearersValue(id, erabIDs)
+}
+
+func (q *query) getBearersValue(id *uenib.UeID, erabIDs []uenib.ErabID) ([]uenib.Bearer, error) {
+	var err error
+	var retBearers []uenib.Bearer
 
 	for _, erabID := range erabIDs {
 		var br uenib.Bearer
@@ -174,6 +481,15 @@ func (reader *Reader) GetBearers(ueID *uenib.UeID) ([]uenib.Bearer, error) {
 		if br.S1ULGtpTE.Teid, err = q.getKeyByteSliceValue(id, internal.DbKeyErabS1UlGtpTendpTeid(id, erabID)); err != nil {
 			return nil, err
 		}
+		//Bearer type and SgNB's S1 downlink and X2-U tunnel endpoints are not set for all
+		//bearer types, hence values not found are not treated as failures.
+		if br.BearerType, err = q.getOptionalKeyBearerTypeValue(id, internal.DbKeyErabBearerType(id, erabID)); err != nil {
+			return nil, err
+		}
+		br.S1DLGtpTE.Address = q.getOptionalKeyByteSliceValue(internal.DbKeyErabS1DlGtpTendpAddr(id, erabID))
+		br.S1DLGtpTE.Teid = q.getOptionalKeyByteSliceValue(internal.DbKeyErabS1DlGtpTendpTeid(id, erabID))
+		br.X2UGtpTE.Address = q.getOptionalKeyByteSliceValue(internal.DbKeyErabX2UGtpTendpAddr(id, erabID))
+		br.X2UGtpTE.Teid = q.getOptionalKeyByteSliceValue(internal.DbKeyErabX2UGtpTendpTeid(id, erabID))
 		retBearers = append(retBearers, br)
 	}
 	return retBearers, err
@@ -183,9 +499,15 @@ func (reader *Reader) GetBearers(ueID *uenib.UeID) ([]uenib.Bearer, error) {
 //Parameter ueID identifies User equipment (UE).
 //Parameter erabID identifies bearer.
 func (reader *Reader) GetErabS1ULGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
-	var q *query
-	var retTEp uenib.TunnelEndpoint
+	var ret *uenib.TunnelEndpoint
+	err := reader.retry("GetErabS1ULGtpTE", func() (err error) {
+		ret, err = reader.getErabS1ULGtpTE(ueID, erabID)
+		return err
+	})
+	return ret, err
+}
 
+func (reader *Reader) getErabS1ULGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
 	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
 	if err != nil {
 		return nil, err
@@ -194,23 +516,98 @@ func (reader *Reader) GetErabS1ULGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*
 	addrKey := internal.DbKeyErabS1UlGtpTendpAddr(id, erabID)
 	teidKey := internal.DbKeyErabS1UlGtpTendpTeid(id, erabID)
 
-	if q, err = reader.newGetQuery(ueID, []string{addrKey, teidKey}); err != nil {
+	return reader.getTunnelEndpoint(ueID, addrKey, teidKey)
+}
+
+//GetErabS1DLGtpTE returns UE bearer's S1 downlink GTP tunnel endpoint in SgNB.
+//Parameter ueID identifies User equipment (UE).
+//Parameter erabID identifies bearer.
+func (reader *Reader) GetErabS1DLGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	var ret *uenib.TunnelEndpoint
+	err := reader.retry("GetErabS1DLGtpTE", func() (err error) {
+		ret, err = reader.getErabS1DLGtpTE(ueID, erabID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getErabS1DLGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
+	if err != nil {
 		return nil, err
 	}
 
-	if retTEp.Address, err = q.getKeyByteSliceValue(ueID, addrKey); err != nil {
+	addrKey := internal.DbKeyErabS1DlGtpTendpAddr(id, erabID)
+	teidKey := internal.DbKeyErabS1DlGtpTendpTeid(id, erabID)
+
+	return reader.getTunnelEndpoint(ueID, addrKey, teidKey)
+}
+
+//GetErabX2UGtpTE returns UE split bearer's X2-U GTP tunnel endpoint in SgNB.
+//Parameter ueID identifies User equipment (UE).
+//Parameter erabID identifies bearer.
+func (reader *Reader) GetErabX2UGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	var ret *uenib.TunnelEndpoint
+	err := reader.retry("GetErabX2UGtpTE", func() (err error) {
+		ret, err = reader.getErabX2UGtpTE(ueID, erabID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getErabX2UGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
+	if err != nil {
 		return nil, err
 	}
-	if retTEp.Teid, err = q.getKeyByteSliceValue(ueID, teidKey); err != nil {
-		return nil, err
+
+	addrKey := internal.DbKeyErabX2UGtpTendpAddr(id, erabID)
+	teidKey := internal.DbKeyErabX2UGtpTendpTeid(id, erabID)
+
+	return reader.getTunnelEndpoint(ueID, addrKey, teidKey)
+}
+
+//GetErabBearerType returns UE bearer's EN-DC bearer option (MCG, SCG or split bearer).
+//Parameter ueID identifies User equipment (UE).
+//Parameter erabID identifies bearer.
+func (reader *Reader) GetErabBearerType(ueID *uenib.UeID, erabID uenib.ErabID) (uenib.BearerType, error) {
+	var ret uenib.BearerType
+	err := reader.retry("GetErabBearerType", func() (err error) {
+		ret, err = reader.getErabBearerType(ueID, erabID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getErabBearerType(ueID *uenib.UeID, erabID uenib.ErabID) (uenib.BearerType, error) {
+	var q *query
+	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
+	if err != nil {
+		return uenib.BEARER_TYPE_UNKNOWN, err
 	}
-	return &retTEp, err
+
+	bearerTypeKey := internal.DbKeyErabBearerType(id, erabID)
+
+	if q, err = reader.newGetQuery(ueID, []string{bearerTypeKey}); err != nil {
+		return uenib.BEARER_TYPE_UNKNOWN, err
+	}
+
+	return q.getKeyBearerTypeValue(ueID, bearerTypeKey)
 }
 
 //GetErabS1ULGtpTEAddr returns UE bearer's transport layer address of an S1 uplink GTP tunnel endpoint.
 //Parameter ueID identifies User equipment (UE).
 //Parameter erabID identifies bearer.
 func (reader *Reader) GetErabS1ULGtpTEAddr(ueID *uenib.UeID, erabID uenib.ErabID) ([]byte, error) {
+	var ret []byte
+	err := reader.retry("GetErabS1ULGtpTEAddr", func() (err error) {
+		ret, err = reader.getErabS1ULGtpTEAddr(ueID, erabID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getErabS1ULGtpTEAddr(ueID *uenib.UeID, erabID uenib.ErabID) ([]byte, error) {
 	var q *query
 	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
 	if err != nil {
@@ -230,6 +627,15 @@ func (reader *Reader) GetErabS1ULGtpTEAddr(ueID *uenib.UeID, erabID uenib.ErabID
 //Parameter ueID identifies User equipment (UE).
 //Parameter erabID identifies bearer.
 func (reader *Reader) GetErabS1ULGtpTETeid(ueID *uenib.UeID, erabID uenib.ErabID) ([]byte, error) {
+	var ret []byte
+	err := reader.retry("GetErabS1ULGtpTETeid", func() (err error) {
+		ret, err = reader.getErabS1ULGtpTETeid(ueID, erabID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getErabS1ULGtpTETeid(ueID *uenib.UeID, erabID uenib.ErabID) ([]byte, error) {
 	var q *query
 	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
 	if err != nil {
@@ -249,6 +655,15 @@ func (reader *Reader) GetErabS1ULGtpTETeid(ueID *uenib.UeID, erabID uenib.ErabID
 //Parameter ueID identifies User equipment (UE).
 //Parameter erabID identifies bearer.
 func (reader *Reader) GetErabQosArpPL(ueID *uenib.UeID, erabID uenib.ErabID) (uint32, error) {
+	var ret uint32
+	err := reader.retry("GetErabQosArpPL", func() (err error) {
+		ret, err = reader.getErabQosArpPL(ueID, erabID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getErabQosArpPL(ueID *uenib.UeID, erabID uenib.ErabID) (uint32, error) {
 	var q *query
 	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
 	if err != nil {
@@ -268,6 +683,15 @@ func (reader *Reader) GetErabQosArpPL(ueID *uenib.UeID, erabID uenib.ErabID) (ui
 //Parameter ueID identifies User equipment (UE).
 //Parameter erabID identifies bearer.
 func (reader *Reader) GetErabQosQci(ueID *uenib.UeID, erabID uenib.ErabID) (uint32, error) {
+	var ret uint32
+	err := reader.retry("GetErabQosQci", func() (err error) {
+		ret, err = reader.getErabQosQci(ueID, erabID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getErabQosQci(ueID *uenib.UeID, erabID uenib.ErabID) (uint32, error) {
 	var q *query
 	id, err := reader.validateUeIDAndResolveENbX2ApID(ueID)
 	if err != nil {
@@ -283,7 +707,35 @@ func (reader *Reader) GetErabQosQci(ueID *uenib.UeID, erabID uenib.ErabID) (uint
 	return q.getKeyUint32Value(ueID, qciKey)
 }
 
+func (reader *Reader) getTunnelEndpoint(ueID *uenib.UeID, addrKey string, teidKey string) (*uenib.TunnelEndpoint, error) {
+	var retTEp uenib.TunnelEndpoint
+
+	q, err := reader.newGetQuery(ueID, []string{addrKey, teidKey})
+	if err != nil {
+		return nil, err
+	}
+
+	if retTEp.Address, err = q.getKeyByteSliceValue(ueID, addrKey); err != nil {
+		return nil, err
+	}
+	if retTEp.Teid, err = q.getKeyByteSliceValue(ueID, teidKey); err != nil {
+		return nil, err
+	}
+	return &retTEp, err
+}
+
 func (reader *Reader) validateUeIDAndResolveENbX2ApID(ueID *uenib.UeID) (*uenib.UeID, error) {
+	id, err := reader.resolveUeID(ueID)
+	if err != nil {
+		return nil, err
+	}
+	if err = reader.checkStale(id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func (reader *Reader) resolveUeID(ueID *uenib.UeID) (*uenib.UeID, error) {
 	var err error
 	if err = validateUe(ueID); err != nil {
 		return nil, err
@@ -322,7 +774,8 @@ func (reader *Reader) getErabIDs(ueID *uenib.UeID) ([]uenib.ErabID, error) {
 	if strVal, err = q.getKeyStringValue(ueID, key); err != nil {
 		return nil, err
 	}
-	return parseErabIDsStringToErabIDSlice(ueID, strVal)
+	erabIDs, err := parseErabIDsStringToErabIDSlice(ueID, strVal)
+	return erabIDs, withKey(err, key)
 }
 
 func (reader *Reader) newGetQuery(ueID *uenib.UeID, keys []string) (*query, error) {
@@ -330,7 +783,8 @@ func (reader *Reader) newGetQuery(ueID *uenib.UeID, keys []string) (*query, erro
 	q := &query{}
 	ns := internal.GetUeNibNs(ueID.GNb)
 	if q.kvMap, err = reader.db.Get(ns, keys); err != nil {
-		return nil, toBackendError(ueID, err)
+		reader.logBackendError("database read failed", err, "gNb", ueID.GNb, "ueID", ueID)
+		return nil, withKey(toBackendError(ueID, err), strings.Join(keys, " "))
 	}
 	return q, err
 }
@@ -355,7 +809,8 @@ func (q *query) getKeyUint32Value(ueID *uenib.UeID, key string) (uint32, error)
 	if err != nil {
 		return uint32(0), err
 	}
-	return parseStringToUint32(ueID, strVal)
+	val, err := parseStringToUint32(ueID, strVal)
+	return val, withKey(err, key)
 }
 
 func (q *query) getKeyByteSliceValue(ueID *uenib.UeID, key string) ([]byte, error) {
@@ -366,16 +821,88 @@ func (q *query) getKeyByteSliceValue(ueID *uenib.UeID, key string) ([]byte, erro
 	return []byte(strVal), err
 }
 
-func toValueNotFoundFailure(ueID *uenib.UeID, key string) *valueNotFoundFailure {
-	return &valueNotFoundFailure{ueID: *ueID, name: key, temporary: true}
+func (q *query) getOptionalKeyByteSliceValue(key string) []byte {
+	if val, ok := q.kvMap[key]; ok && val != nil {
+		return []byte(val.(string))
+	}
+	return nil
 }
 
-func toBackendError(ueID *uenib.UeID, err error) *backendError {
-	return &backendError{ueID: *ueID, err: err.Error(), temporary: true}
+func (q *query) getKeyBearerTypeValue(ueID *uenib.UeID, key string) (uenib.BearerType, error) {
+	strVal, err := q.getKeyStringValue(ueID, key)
+	if err != nil {
+		return uenib.BEARER_TYPE_UNKNOWN, err
+	}
+	val, err := parseStringToBearerType(ueID, strVal)
+	return val, withKey(err, key)
+}
+
+func (q *query) getOptionalKeyBearerTypeValue(ueID *uenib.UeID, key string) (uenib.BearerType, error) {
+	if val, ok := q.kvMap[key]; ok && val != nil {
+		bearerType, err := parseStringToBearerType(ueID, val.(string))
+		return bearerType, withKey(err, key)
+	}
+	return uenib.BEARER_TYPE_UNKNOWN, nil
+}
+
+//getCellValue reads a cell from the query results. PCI and SSB frequency are mandatory,
+//NR-CGI and measurement results are optional. Measurement is set only if all of the RSRP,
+//RSRQ and SINR values are found.
+func (q *query) getCellValue(ueID *uenib.UeID, pciKey string, freqKey string, nrCgiKey string,
+	rsrpKey string, rsrqKey string, sinrKey string) (uenib.Cell, error) {
+	var err error
+	var cell uenib.Cell
+	var meas uenib.CellMeasurement
+
+	if cell.Pci, err = q.getKeyUint32Value(ueID, pciKey); err != nil {
+		return cell, err
+	}
+	if cell.SsbFreq, err = q.getKeyUint32Value(ueID, freqKey); err != nil {
+		return cell, err
+	}
+	if val, ok := q.kvMap[nrCgiKey]; ok && val != nil {
+		if cell.NrCgi, err = uenib.ParseNrCgi(val.(string)); err != nil {
+			return cell, withKey(toValidationError(ueID, err), nrCgiKey)
+		}
+	}
+	if meas.Rsrp, err = q.getKeyUint32Value(ueID, rsrpKey); err != nil {
+		return cell, ignoreValueNotFoundFailure(err)
+	}
+	if meas.Rsrq, err = q.getKeyUint32Value(ueID, rsrqKey); err != nil {
+		return cell, ignoreValueNotFoundFailure(err)
+	}
+	if meas.Sinr, err = q.getKeyUint32Value(ueID, sinrKey); err != nil {
+		return cell, ignoreValueNotFoundFailure(err)
+	}
+	cell.Measurement = &meas
+	return cell, err
 }
 
-func toValidationError(ueID *uenib.UeID, err error) *validationError {
-	return &validationError{ueID: *ueID, err: err.Error()}
+func (q *query) getOptionalKeySCellIndexesValue(ueID *uenib.UeID, key string) ([]uenib.SCellIndex, error) {
+	if val, ok := q.kvMap[key]; ok && val != nil && val.(string) != "" {
+		sCellIndexes, err := parseSCellIndexesStringToSCellIndexSlice(ueID, val.(string))
+		return sCellIndexes, withKey(err, key)
+	}
+	return nil, nil
+}
+
+func ignoreValueNotFoundFailure(err error) error {
+	if IsValueNotFoundFailure(err) {
+		return nil
+	}
+	return err
+}
+
+func toValueNotFoundFailure(ueID *uenib.UeID, key string) *UeNibError {
+	return &UeNibError{Class: ERROR_CLASS_VALUE_NOT_FOUND, UeID: *ueID, Key: key}
+}
+
+func toBackendError(ueID *uenib.UeID, err error) *UeNibError {
+	return &UeNibError{Class: ERROR_CLASS_BACKEND, UeID: *ueID, Err: err, msg: err.Error()}
+}
+
+func toValidationError(ueID *uenib.UeID, err error) *UeNibError {
+	return &UeNibError{Class: ERROR_CLASS_VALIDATION, UeID: *ueID, Err: err, msg: err.Error()}
 }
 
 func validateUe(ueID *uenib.UeID) error {
@@ -400,6 +927,14 @@ func parseStringToUint32(ueID *uenib.UeID, str string) (uint32, error) {
 	return uint32(val), err
 }
 
+func parseStringToBearerType(ueID *uenib.UeID, str string) (uenib.BearerType, error) {
+	val, err := uenib.ParseBearerType(str)
+	if err != nil {
+		return uenib.BEARER_TYPE_UNKNOWN, toValidationError(ueID, err)
+	}
+	return val, err
+}
+
 func parseErabIDsStringToErabIDSlice(ueID *uenib.UeID, strList string) ([]uenib.ErabID, error) {
 	var err error
 	var uIntVal uint32
@@ -414,3 +949,33 @@ func parseErabIDsStringToErabIDSlice(ueID *uenib.UeID, strList string) ([]uenib.
 	}
 	return erabVals, err
 }
+
+func parseSCellIndexesStringToSCellIndexSlice(ueID *uenib.UeID, strList string) ([]uenib.SCellIndex, error) {
+	var err error
+	var uIntVal uint32
+	strVals := strings.Split(strList, ",")
+	sCellIndexes := make([]uenib.SCellIndex, len(strVals))
+
+	for i, s := range strVals {
+		if uIntVal, err = parseStringToUint32(ueID, s); err != nil {
+			return nil, err
+		}
+		sCellIndexes[i] = uenib.SCellIndex(uIntVal)
+	}
+	return sCellIndexes, err
+}
+
+func parseSeqsStringToSortedSeqSlice(ueID *uenib.UeID, strList string) ([]uint64, error) {
+	strVals := strings.Split(strList, ",")
+	seqs := make([]uint64, len(strVals))
+
+	for i, s := range strVals {
+		val, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, toValidationError(ueID, err)
+		}
+		seqs[i] = val
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
@@ -34,12 +34,22 @@ var someDbKeyBearerS1ULTepAddr string
 var someDbKeyBearerS1ULTepTeid string
 var someDbKeyBearerArpPL string
 var someDbKeyBearerQci string
+var someDbKeyBearerType string
+var someDbKeyBearerS1DLTepAddr string
+var someDbKeyBearerS1DLTepTeid string
+var someDbKeyBearerX2UTepAddr string
+var someDbKeyBearerX2UTepTeid string
 
 var anotherDbKeyBearerDrbID string
 var anotherDbKeyBearerS1ULTepAddr string
 var anotherDbKeyBearerS1ULTepTeid string
 var anotherDbKeyBearerArpPL string
 var anotherDbKeyBearerQci string
+var anotherDbKeyBearerType string
+var anotherDbKeyBearerS1DLTepAddr string
+var anotherDbKeyBearerS1DLTepTeid string
+var anotherDbKeyBearerX2UTepAddr string
+var anotherDbKeyBearerX2UTepTeid string
 
 func init() {
 	someGnb = "somegnb:310-410-b5c67788"
@@ -73,6 +83,16 @@ func init() {
 		fmt.Sprint(someErabID) + ",UE_ERAB_QOS_ARP_PL"
 	someDbKeyBearerQci = someUeID.ENbUeX2ApID + "," +
 		fmt.Sprint(someErabID) + ",UE_ERAB_QOS_QCI"
+	someDbKeyBearerType = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(someErabID) + ",UE_ERAB_BEARER_TYPE"
+	someDbKeyBearerS1DLTepAddr = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(someErabID) + ",UE_ERAB_S1_DL_GTP_TUNNEL_ADDR"
+	someDbKeyBearerS1DLTepTeid = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(someErabID) + ",UE_ERAB_S1_DL_GTP_TUNNEL_TEID"
+	someDbKeyBearerX2UTepAddr = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(someErabID) + ",UE_ERAB_X2U_GTP_TUNNEL_ADDR"
+	someDbKeyBearerX2UTepTeid = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(someErabID) + ",UE_ERAB_X2U_GTP_TUNNEL_TEID"
 
 	anotherDbKeyBearerDrbID = someUeID.ENbUeX2ApID + "," +
 		fmt.Sprint(anotherErabID) + ",UE_ERAB_DRB_ID"
@@ -84,6 +104,16 @@ func init() {
 		fmt.Sprint(anotherErabID) + ",UE_ERAB_QOS_ARP_PL"
 	anotherDbKeyBearerQci = someUeID.ENbUeX2ApID + "," +
 		fmt.Sprint(anotherErabID) + ",UE_ERAB_QOS_QCI"
+	anotherDbKeyBearerType = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(anotherErabID) + ",UE_ERAB_BEARER_TYPE"
+	anotherDbKeyBearerS1DLTepAddr = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(anotherErabID) + ",UE_ERAB_S1_DL_GTP_TUNNEL_ADDR"
+	anotherDbKeyBearerS1DLTepTeid = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(anotherErabID) + ",UE_ERAB_S1_DL_GTP_TUNNEL_TEID"
+	anotherDbKeyBearerX2UTepAddr = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(anotherErabID) + ",UE_ERAB_X2U_GTP_TUNNEL_ADDR"
+	anotherDbKeyBearerX2UTepTeid = someUeID.ENbUeX2ApID + "," +
+		fmt.Sprint(anotherErabID) + ",UE_ERAB_X2U_GTP_TUNNEL_TEID"
 }
 
 func getTestCellEntry(pci uint32, ssbFreq uint32) *uenib.Cell {
@@ -180,6 +210,65 @@ func getTestTunnelEndpointEntry(addr string, teid string) *uenib.TunnelEndpoint
 	}
 }
 
+func TestGetUeIDsSuccess(t *testing.T) {
+	m, i := setup()
+	m.On("GetAll", someNs).Return([]string{
+		"100,UE_PSCELL_PCI",
+		"101,UEMAP_GNBUEX2APID",
+		"20,UEMAP_GNBUEX2APID",
+		"200,UEMAP_ENBUEX2APID",
+		"100,UEMAP_GNBUEX2APID",
+		"100,5,UE_ERAB_DRB_ID",
+	}, nil).Once()
+	m.On("Get", someNs, []string{"101,UEMAP_GNBUEX2APID", "20,UEMAP_GNBUEX2APID", "100,UEMAP_GNBUEX2APID"}).Return(
+		map[string]interface{}{
+			"101,UEMAP_GNBUEX2APID": "201",
+			"20,UEMAP_GNBUEX2APID":  "120",
+			"100,UEMAP_GNBUEX2APID": nil,
+		}, nil,
+	).Once()
+
+	ret, err := i.GetUeIDs(someGnb)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uenib.UeID{
+		uenib.UeID{GNb: someGnb, GNbUeX2ApID: "120", ENbUeX2ApID: "20"},
+		uenib.UeID{GNb: someGnb, GNbUeX2ApID: "201", ENbUeX2ApID: "101"},
+	}, ret)
+	m.AssertExpectations(t)
+}
+
+func TestGetUeIDsReturnsEmptyIfNoUes(t *testing.T) {
+	m, i := setup()
+	m.On("GetAll", someNs).Return([]string{"100,UE_PSCELL_PCI"}, nil).Once()
+
+	ret, err := i.GetUeIDs(someGnb)
+
+	assert.Nil(t, err)
+	assert.Empty(t, ret)
+	m.AssertExpectations(t)
+}
+
+func TestGetUeIDsReturnsErrorIfNoGNb(t *testing.T) {
+	_, i := setup()
+
+	ret, err := i.GetUeIDs("")
+
+	expectValidationError(t, err, "GNb")
+	assert.Nil(t, ret)
+}
+
+func TestGetUeIDsReturnsErrorIfDbBackendFailure(t *testing.T) {
+	m, i := setup()
+	m.On("GetAll", someNs).Return(nil, errors.New("Some DB Backend Error")).Once()
+
+	ret, err := i.GetUeIDs(someGnb)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, true, uenibreader.IsBackendError(err))
+	assert.Nil(t, ret)
+}
+
 func TestGetMeNbUEX2APIDSuccess(t *testing.T) {
 	m, i := setup()
 	m.On("Get", someNs, []string{someDbKeyENbUeX2ApID}).Return(
@@ -675,11 +764,21 @@ func TestGetBearersSuccess(t *testing.T) {
 		someDbKeyBearerS1ULTepTeid,
 		someDbKeyBearerArpPL,
 		someDbKeyBearerQci,
+		someDbKeyBearerType,
+		someDbKeyBearerS1DLTepAddr,
+		someDbKeyBearerS1DLTepTeid,
+		someDbKeyBearerX2UTepAddr,
+		someDbKeyBearerX2UTepTeid,
 		anotherDbKeyBearerDrbID,
 		anotherDbKeyBearerS1ULTepAddr,
 		anotherDbKeyBearerS1ULTepTeid,
 		anotherDbKeyBearerArpPL,
 		anotherDbKeyBearerQci,
+		anotherDbKeyBearerType,
+		anotherDbKeyBearerS1DLTepAddr,
+		anotherDbKeyBearerS1DLTepTeid,
+		anotherDbKeyBearerX2UTepAddr,
+		anotherDbKeyBearerX2UTepTeid,
 	}).Return(
 		map[string]interface{}{
 			someDbKeyBearerDrbID:          "150",
@@ -700,6 +799,85 @@ func TestGetBearersSuccess(t *testing.T) {
 	assert.Equal(t, getTestErabs(), ret)
 }
 
+func TestGetBearersWithBearerTypeAndSgNbTunnelEndpointsSuccess(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, []string{someDbKeyBearerIDs}).Return(
+		map[string]interface{}{someDbKeyBearerIDs: "1000"}, nil,
+	).Once()
+	m.On("Get", someNs, []string{
+		someDbKeyBearerDrbID,
+		someDbKeyBearerS1ULTepAddr,
+		someDbKeyBearerS1ULTepTeid,
+		someDbKeyBearerArpPL,
+		someDbKeyBearerQci,
+		someDbKeyBearerType,
+		someDbKeyBearerS1DLTepAddr,
+		someDbKeyBearerS1DLTepTeid,
+		someDbKeyBearerX2UTepAddr,
+		someDbKeyBearerX2UTepTeid,
+	}).Return(
+		map[string]interface{}{
+			someDbKeyBearerDrbID:       "150",
+			someDbKeyBearerS1ULTepAddr: "10.20.30.40",
+			someDbKeyBearerS1ULTepTeid: "1999",
+			someDbKeyBearerArpPL:       "1",
+			someDbKeyBearerQci:         "10",
+			someDbKeyBearerType:        "SPLIT",
+			someDbKeyBearerS1DLTepAddr: "30.20.30.40",
+			someDbKeyBearerS1DLTepTeid: "3999",
+			someDbKeyBearerX2UTepAddr:  "40.20.30.40",
+			someDbKeyBearerX2UTepTeid:  "4999",
+		}, nil).Once()
+
+	ret, err := i.GetBearers(&someUeID)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uenib.Bearer{
+		uenib.Bearer{
+			ErabID:     1000,
+			DrbID:      150,
+			ArpPL:      1,
+			Qci:        10,
+			BearerType: uenib.BEARER_TYPE_SPLIT,
+			S1ULGtpTE:  *getTestTunnelEndpointEntry("10.20.30.40", "1999"),
+			S1DLGtpTE:  *getTestTunnelEndpointEntry("30.20.30.40", "3999"),
+			X2UGtpTE:   *getTestTunnelEndpointEntry("40.20.30.40", "4999"),
+		},
+	}, ret)
+}
+
+func TestGetBearersReturnsErrorIfBearerTypeIsUnknown(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, []string{someDbKeyBearerIDs}).Return(
+		map[string]interface{}{someDbKeyBearerIDs: "1000"}, nil,
+	).Once()
+	m.On("Get", someNs, []string{
+		someDbKeyBearerDrbID,
+		someDbKeyBearerS1ULTepAddr,
+		someDbKeyBearerS1ULTepTeid,
+		someDbKeyBearerArpPL,
+		someDbKeyBearerQci,
+		someDbKeyBearerType,
+		someDbKeyBearerS1DLTepAddr,
+		someDbKeyBearerS1DLTepTeid,
+		someDbKeyBearerX2UTepAddr,
+		someDbKeyBearerX2UTepTeid,
+	}).Return(
+		map[string]interface{}{
+			someDbKeyBearerDrbID:       "150",
+			someDbKeyBearerS1ULTepAddr: "10.20.30.40",
+			someDbKeyBearerS1ULTepTeid: "1999",
+			someDbKeyBearerArpPL:       "1",
+			someDbKeyBearerQci:         "10",
+			someDbKeyBearerType:        "IamNotBearerType",
+		}, nil).Once()
+
+	ret, err := i.GetBearers(&someUeID)
+
+	expectValidationError(t, err, "IamNotBearerType")
+	assert.Nil(t, ret)
+}
+
 func TestGetBearersReturnsErrorIfNoGNbInUeID(t *testing.T) {
 	_, i := setup()
 
@@ -739,6 +917,11 @@ func TestGetBearersReturnsErrorIfDbQueryFails(t *testing.T) {
 		someDbKeyBearerS1ULTepTeid,
 		someDbKeyBearerArpPL,
 		someDbKeyBearerQci,
+		someDbKeyBearerType,
+		someDbKeyBearerS1DLTepAddr,
+		someDbKeyBearerS1DLTepTeid,
+		someDbKeyBearerX2UTepAddr,
+		someDbKeyBearerX2UTepTeid,
 	}).Return(nil, dbError).Once()
 
 	ret, err := i.GetBearers(&someUeID)
@@ -780,6 +963,11 @@ func TestGetBearersReturnsErrorIfDbKeyValueNotFound(t *testing.T) {
 		someDbKeyBearerS1ULTepTeid,
 		someDbKeyBearerArpPL,
 		someDbKeyBearerQci,
+		someDbKeyBearerType,
+		someDbKeyBearerS1DLTepAddr,
+		someDbKeyBearerS1DLTepTeid,
+		someDbKeyBearerX2UTepAddr,
+		someDbKeyBearerX2UTepTeid,
 	}).Return(
 		map[string]interface{}{
 			someDbKeyBearerDrbID: nil,
@@ -814,6 +1002,11 @@ func TestGetBearersReturnsErrorIfValueConvertToUint32Fails(t *testing.T) {
 		someDbKeyBearerS1ULTepTeid,
 		someDbKeyBearerArpPL,
 		someDbKeyBearerQci,
+		someDbKeyBearerType,
+		someDbKeyBearerS1DLTepAddr,
+		someDbKeyBearerS1DLTepTeid,
+		someDbKeyBearerX2UTepAddr,
+		someDbKeyBearerX2UTepTeid,
 	}).Return(
 		map[string]interface{}{
 			someDbKeyBearerDrbID: "IamNotInt",
@@ -1149,6 +1342,106 @@ func TestGetErabS1ULGtpTETeidReturnsErrorIfDbKeyValueNotFound(t *testing.T) {
 	assert.Nil(t, ret)
 }
 
+func TestGetErabS1DLGtpTESuccess(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, []string{
+		someDbKeyBearerS1DLTepAddr,
+		someDbKeyBearerS1DLTepTeid,
+	}).Return(
+		map[string]interface{}{
+			someDbKeyBearerS1DLTepAddr: "30.20.30.40",
+			someDbKeyBearerS1DLTepTeid: "3999",
+		}, nil).Once()
+
+	ret, err := i.GetErabS1DLGtpTE(&someUeID, someErabID)
+
+	assert.Nil(t, err)
+	assert.Equal(t, getTestTunnelEndpointEntry("30.20.30.40", "3999"), ret)
+}
+
+func TestGetErabS1DLGtpTEReturnsErrorIfAddressDbKeyValueNotFound(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, []string{
+		someDbKeyBearerS1DLTepAddr,
+		someDbKeyBearerS1DLTepTeid,
+	}).Return(
+		map[string]interface{}{
+			someDbKeyBearerS1DLTepTeid: "3999",
+		}, nil).Once()
+
+	ret, err := i.GetErabS1DLGtpTE(&someUeID, someErabID)
+
+	expectValueNotFoundFailure(t, err, someDbKeyBearerS1DLTepAddr)
+	assert.Nil(t, ret)
+}
+
+func TestGetErabX2UGtpTESuccess(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, []string{
+		someDbKeyBearerX2UTepAddr,
+		someDbKeyBearerX2UTepTeid,
+	}).Return(
+		map[string]interface{}{
+			someDbKeyBearerX2UTepAddr: "40.20.30.40",
+			someDbKeyBearerX2UTepTeid: "4999",
+		}, nil).Once()
+
+	ret, err := i.GetErabX2UGtpTE(&someUeID, someErabID)
+
+	assert.Nil(t, err)
+	assert.Equal(t, getTestTunnelEndpointEntry("40.20.30.40", "4999"), ret)
+}
+
+func TestGetErabX2UGtpTEReturnsErrorIfDbQueryFails(t *testing.T) {
+	m, i := setup()
+	dbError := errors.New("Some DB Error")
+	m.On("Get", someNs, []string{
+		someDbKeyBearerX2UTepAddr,
+		someDbKeyBearerX2UTepTeid,
+	}).Return(nil, dbError).Once()
+
+	ret, err := i.GetErabX2UGtpTE(&someUeID, someErabID)
+
+	expectDbError(t, err, "Some DB Error")
+	assert.Nil(t, ret)
+}
+
+func TestGetErabBearerTypeSuccess(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, []string{someDbKeyBearerType}).Return(
+		map[string]interface{}{someDbKeyBearerType: "SCG"}, nil,
+	).Once()
+
+	ret, err := i.GetErabBearerType(&someUeID, someErabID)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uenib.BEARER_TYPE_SCG, ret)
+}
+
+func TestGetErabBearerTypeReturnsErrorIfDbKeyValueNotFound(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, []string{someDbKeyBearerType}).Return(
+		map[string]interface{}{someDbKeyBearerType: nil}, nil,
+	).Once()
+
+	ret, err := i.GetErabBearerType(&someUeID, someErabID)
+
+	expectValueNotFoundFailure(t, err, someDbKeyBearerType)
+	assert.Equal(t, uenib.BEARER_TYPE_UNKNOWN, ret)
+}
+
+func TestGetErabBearerTypeReturnsErrorIfValueIsNotBearerType(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, []string{someDbKeyBearerType}).Return(
+		map[string]interface{}{someDbKeyBearerType: "IamNotBearerType"}, nil,
+	).Once()
+
+	ret, err := i.GetErabBearerType(&someUeID, someErabID)
+
+	expectValidationError(t, err, "IamNotBearerType")
+	assert.Equal(t, uenib.BEARER_TYPE_UNKNOWN, ret)
+}
+
 func TestGetErabQosArpPLSuccessSuccess(t *testing.T) {
 	m, i := setup()
 	m.On("Get", someNs, []string{
@@ -1348,3 +1641,339 @@ func TestGetErabQosQciReturnsErrorIfDbKeyValueNotFound(t *testing.T) {
 	expectValueNotFoundFailure(t, err, someDbKeyBearerQci)
 	assert.Equal(t, uint32(0), ret)
 }
+
+func getTestPsCellDbKeys() []string {
+	return []string{
+		someDbKeyPsCellPci,
+		someDbKeyPsCellSsbFreq,
+		someUeID.ENbUeX2ApID + ",UE_PSCELL_NRCGI",
+		someUeID.ENbUeX2ApID + ",UE_PSCELL_RSRP",
+		someUeID.ENbUeX2ApID + ",UE_PSCELL_RSRQ",
+		someUeID.ENbUeX2ApID + ",UE_PSCELL_SINR",
+		someUeID.ENbUeX2ApID + ",UE_SCELL_INDEXES",
+	}
+}
+
+func getTestSCellDbKeys(sCellIndex uenib.SCellIndex) []string {
+	prefix := someUeID.ENbUeX2ApID + "," + fmt.Sprint(sCellIndex)
+	return []string{
+		prefix + ",UE_SCELL_PCI",
+		prefix + ",UE_SCELL_FREQ",
+		prefix + ",UE_SCELL_NRCGI",
+		prefix + ",UE_SCELL_RSRP",
+		prefix + ",UE_SCELL_RSRQ",
+		prefix + ",UE_SCELL_SINR",
+	}
+}
+
+func TestGetServingCellsWithOnlyPsCellSuccess(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, getTestPsCellDbKeys()).Return(
+		map[string]interface{}{
+			someDbKeyPsCellPci:     "500",
+			someDbKeyPsCellSsbFreq: "632628",
+		}, nil).Once()
+
+	ret, err := i.GetServingCells(&someUeID)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &uenib.ServingCells{PsCell: *getTestCellEntry(500, 632628)}, ret)
+}
+
+func TestGetServingCellsWithSCellsSuccess(t *testing.T) {
+	m, i := setup()
+	psCellKeys := getTestPsCellDbKeys()
+	sCell1Keys := getTestSCellDbKeys(1)
+	sCell2Keys := getTestSCellDbKeys(2)
+	m.On("Get", someNs, psCellKeys).Return(
+		map[string]interface{}{
+			psCellKeys[0]: "500",
+			psCellKeys[1]: "632628",
+			psCellKeys[2]: "310-410-b5c677880",
+			psCellKeys[3]: "60",
+			psCellKeys[4]: "70",
+			psCellKeys[5]: "80",
+			psCellKeys[6]: "1,2",
+		}, nil).Once()
+	m.On("Get", someNs, append(sCell1Keys, sCell2Keys...)).Return(
+		map[string]interface{}{
+			sCell1Keys[0]: "501",
+			sCell1Keys[1]: "633000",
+			sCell2Keys[0]: "502",
+			sCell2Keys[1]: "634000",
+			sCell2Keys[2]: "310-410-b5c677882",
+			sCell2Keys[3]: "61",
+			sCell2Keys[4]: "71",
+			sCell2Keys[5]: "81",
+		}, nil).Once()
+
+	ret, err := i.GetServingCells(&someUeID)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &uenib.ServingCells{
+		PsCell: uenib.Cell{
+			Pci:         500,
+			SsbFreq:     632628,
+			NrCgi:       uenib.NrCgi{Mcc: "310", Mnc: "410", NrCellID: 0xb5c677880},
+			Measurement: &uenib.CellMeasurement{Rsrp: 60, Rsrq: 70, Sinr: 80},
+		},
+		SCells: []uenib.SCell{
+			uenib.SCell{
+				SCellIndex: 1,
+				Cell:       *getTestCellEntry(501, 633000),
+			},
+			uenib.SCell{
+				SCellIndex: 2,
+				Cell: uenib.Cell{
+					Pci:         502,
+					SsbFreq:     634000,
+					NrCgi:       uenib.NrCgi{Mcc: "310", Mnc: "410", NrCellID: 0xb5c677882},
+					Measurement: &uenib.CellMeasurement{Rsrp: 61, Rsrq: 71, Sinr: 81},
+				},
+			},
+		},
+	}, ret)
+}
+
+func TestGetServingCellsReturnsErrorIfNoGNbInUeID(t *testing.T) {
+	_, i := setup()
+
+	ret, err := i.GetServingCells(
+		&uenib.UeID{
+			ENbUeX2ApID: "100",
+		},
+	)
+
+	expectValidationError(t, err, "GNb")
+	assert.Nil(t, ret)
+}
+
+func TestGetServingCellsReturnsErrorIfDbQueryFails(t *testing.T) {
+	m, i := setup()
+	dbError := errors.New("Some DB Error")
+	m.On("Get", someNs, getTestPsCellDbKeys()).Return(nil, dbError).Once()
+
+	ret, err := i.GetServingCells(&someUeID)
+
+	expectDbError(t, err, "Some DB Error")
+	assert.Nil(t, ret)
+}
+
+func TestGetServingCellsReturnsErrorIfSCellDbQueryFails(t *testing.T) {
+	m, i := setup()
+	dbError := errors.New("Some DB Error")
+	m.On("Get", someNs, getTestPsCellDbKeys()).Return(
+		map[string]interface{}{
+			someDbKeyPsCellPci:                         "500",
+			someDbKeyPsCellSsbFreq:                     "632628",
+			someUeID.ENbUeX2ApID + ",UE_SCELL_INDEXES": "1",
+		}, nil).Once()
+	m.On("Get", someNs, getTestSCellDbKeys(1)).Return(nil, dbError).Once()
+
+	ret, err := i.GetServingCells(&someUeID)
+
+	expectDbError(t, err, "Some DB Error")
+	assert.Nil(t, ret)
+}
+
+func TestGetServingCellsReturnsErrorIfPciDbKeyValueNotFound(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, getTestPsCellDbKeys()).Return(
+		map[string]interface{}{
+			someDbKeyPsCellSsbFreq: "632628",
+		}, nil).Once()
+
+	ret, err := i.GetServingCells(&someUeID)
+
+	expectValueNotFoundFailure(t, err, someDbKeyPsCellPci)
+	assert.Nil(t, ret)
+}
+
+func TestGetServingCellsReturnsErrorIfSCellPciDbKeyValueNotFound(t *testing.T) {
+	m, i := setup()
+	sCellKeys := getTestSCellDbKeys(3)
+	m.On("Get", someNs, getTestPsCellDbKeys()).Return(
+		map[string]interface{}{
+			someDbKeyPsCellPci:                         "500",
+			someDbKeyPsCellSsbFreq:                     "632628",
+			someUeID.ENbUeX2ApID + ",UE_SCELL_INDEXES": "3",
+		}, nil).Once()
+	m.On("Get", someNs, sCellKeys).Return(
+		map[string]interface{}{
+			sCellKeys[1]: "633000",
+		}, nil).Once()
+
+	ret, err := i.GetServingCells(&someUeID)
+
+	expectValueNotFoundFailure(t, err, sCellKeys[0])
+	assert.Nil(t, ret)
+}
+
+func TestGetServingCellsReturnsErrorIfNrCgiIsInvalid(t *testing.T) {
+	m, i := setup()
+	psCellKeys := getTestPsCellDbKeys()
+	m.On("Get", someNs, psCellKeys).Return(
+		map[string]interface{}{
+			psCellKeys[0]: "500",
+			psCellKeys[1]: "632628",
+			psCellKeys[2]: "IamNotNrCgi",
+		}, nil).Once()
+
+	ret, err := i.GetServingCells(&someUeID)
+
+	expectValidationError(t, err, "IamNotNrCgi")
+	assert.Nil(t, ret)
+}
+
+func TestGetServingCellsReturnsErrorIfSCellIndexesValueConvertToUint32Fails(t *testing.T) {
+	m, i := setup()
+	m.On("Get", someNs, getTestPsCellDbKeys()).Return(
+		map[string]interface{}{
+			someDbKeyPsCellPci:                         "500",
+			someDbKeyPsCellSsbFreq:                     "632628",
+			someUeID.ENbUeX2ApID + ",UE_SCELL_INDEXES": "IamNotInt",
+		}, nil).Once()
+
+	ret, err := i.GetServingCells(&someUeID)
+
+	expectValidationError(t, err, "IamNotInt")
+	assert.Nil(t, ret)
+}
+
+func getTestStateHistoryDbKeys(seqs ...uint64) []string {
+	var keys []string
+	for _, seq := range seqs {
+		keys = append(keys,
+			someUeID.ENbUeX2ApID+","+fmt.Sprint(seq)+",UE_STATE_HISTORY_EVENT",
+			someUeID.ENbUeX2ApID+","+fmt.Sprint(seq)+",UE_STATE_HISTORY_CAUSE")
+	}
+	return keys
+}
+
+func TestGetStateHistorySuccess(t *testing.T) {
+	m, i := setup()
+	seqsKey := someUeID.ENbUeX2ApID + ",UE_STATE_HISTORY_SEQS"
+	keys := getTestStateHistoryDbKeys(9, 10, 11)
+	m.On("Get", someNs, []string{seqsKey, someDbKeyGNbUeX2ApID}).Return(
+		map[string]interface{}{seqsKey: "11,9,10"}, nil,
+	).Once()
+	m.On("Get", someNs, keys).Return(
+		map[string]interface{}{
+			keys[0]: "1589278270 SgNBAdditionRequest",
+			keys[2]: "1589278271 SgNBReleaseRequired",
+			keys[3]: "radioNetwork:unspecified",
+			keys[4]: "1589278272 SgNBAdditionRequest",
+		}, nil).Once()
+
+	ret, err := i.GetStateHistory(&someUeID, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uenib.UeState{
+		*getTestStateEntryOnlyEvent("1589278270 SgNBAdditionRequest"),
+		*getTestStateEntry("1589278271 SgNBReleaseRequired", "radioNetwork:unspecified"),
+		*getTestStateEntryOnlyEvent("1589278272 SgNBAdditionRequest"),
+	}, ret)
+}
+
+func TestGetStateHistoryWithLimitReturnsNewestEntries(t *testing.T) {
+	m, i := setup()
+	seqsKey := someUeID.ENbUeX2ApID + ",UE_STATE_HISTORY_SEQS"
+	keys := getTestStateHistoryDbKeys(10, 11)
+	m.On("Get", someNs, []string{seqsKey, someDbKeyGNbUeX2ApID}).Return(
+		map[string]interface{}{seqsKey: "9,10,11"}, nil,
+	).Once()
+	m.On("Get", someNs, keys).Return(
+		map[string]interface{}{
+			keys[0]: "1589278271 SgNBReleaseRequired",
+			keys[2]: "1589278272 SgNBAdditionRequest",
+		}, nil).Once()
+
+	ret, err := i.GetStateHistory(&someUeID, 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uenib.UeState{
+		*getTestStateEntryOnlyEvent("1589278271 SgNBReleaseRequired"),
+		*getTestStateEntryOnlyEvent("1589278272 SgNBAdditionRequest"),
+	}, ret)
+}
+
+func TestGetStateHistorySkipsRemovedEntries(t *testing.T) {
+	m, i := setup()
+	seqsKey := someUeID.ENbUeX2ApID + ",UE_STATE_HISTORY_SEQS"
+	keys := getTestStateHistoryDbKeys(1, 2)
+	m.On("Get", someNs, []string{seqsKey, someDbKeyGNbUeX2ApID}).Return(
+		map[string]interface{}{seqsKey: "1,2"}, nil,
+	).Once()
+	m.On("Get", someNs, keys).Return(
+		map[string]interface{}{
+			keys[2]: "1589278272 SgNBAdditionRequest",
+		}, nil).Once()
+
+	ret, err := i.GetStateHistory(&someUeID, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uenib.UeState{*getTestStateEntryOnlyEvent("1589278272 SgNBAdditionRequest")}, ret)
+}
+
+func TestGetStateHistoryReturnsErrorIfNegativeLimit(t *testing.T) {
+	_, i := setup()
+
+	ret, err := i.GetStateHistory(&someUeID, -1)
+
+	expectValidationError(t, err, "negative history limit")
+	assert.Nil(t, ret)
+}
+
+func TestGetStateHistoryReturnsEmptyHistoryIfSeqsDbKeyValueNotFound(t *testing.T) {
+	m, i := setup()
+	seqsKey := someUeID.ENbUeX2ApID + ",UE_STATE_HISTORY_SEQS"
+	m.On("Get", someNs, []string{seqsKey, someDbKeyGNbUeX2ApID}).Return(
+		map[string]interface{}{seqsKey: nil, someDbKeyGNbUeX2ApID: someUeID.GNbUeX2ApID}, nil,
+	).Once()
+
+	ret, err := i.GetStateHistory(&someUeID, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []uenib.UeState{}, ret)
+}
+
+func TestGetStateHistoryReturnsErrorIfUeNotFound(t *testing.T) {
+	m, i := setup()
+	seqsKey := someUeID.ENbUeX2ApID + ",UE_STATE_HISTORY_SEQS"
+	m.On("Get", someNs, []string{seqsKey, someDbKeyGNbUeX2ApID}).Return(
+		map[string]interface{}{seqsKey: nil, someDbKeyGNbUeX2ApID: nil}, nil,
+	).Once()
+
+	ret, err := i.GetStateHistory(&someUeID, 0)
+
+	expectValueNotFoundFailure(t, err, someDbKeyGNbUeX2ApID)
+	assert.Nil(t, ret)
+}
+
+func TestGetStateHistoryReturnsErrorIfSeqValueConvertFails(t *testing.T) {
+	m, i := setup()
+	seqsKey := someUeID.ENbUeX2ApID + ",UE_STATE_HISTORY_SEQS"
+	m.On("Get", someNs, []string{seqsKey, someDbKeyGNbUeX2ApID}).Return(
+		map[string]interface{}{seqsKey: "1,IamNotInt"}, nil,
+	).Once()
+
+	ret, err := i.GetStateHistory(&someUeID, 0)
+
+	expectValidationError(t, err, "IamNotInt")
+	assert.Nil(t, ret)
+}
+
+func TestGetStateHistoryReturnsErrorIfDbQueryFails(t *testing.T) {
+	m, i := setup()
+	dbError := errors.New("Some DB Error")
+	seqsKey := someUeID.ENbUeX2ApID + ",UE_STATE_HISTORY_SEQS"
+	m.On("Get", someNs, []string{seqsKey, someDbKeyGNbUeX2ApID}).Return(
+		map[string]interface{}{seqsKey: "1"}, nil,
+	).Once()
+	m.On("Get", someNs, getTestStateHistoryDbKeys(1)).Return(nil, dbError).Once()
+
+	ret, err := i.GetStateHistory(&someUeID, 0)
+
+	expectDbError(t, err, "Some DB Error")
+	assert.Nil(t, ret)
+}
@@ -11,23 +11,47 @@ package uenibreader
 import (
 	"fmt"
 	sdl "gerrit.o-ran-sc.org/r/ric-plt/sdlgo"
+	"time"
 )
 
 //Reader is used to read UE data from RIC Radio Network Information Base (UE-NIB) database.
 //NOTE: Use NewReader() function to create a Reader instance.
 type Reader struct {
-	db iDbBackend
+	db             DbBackend
+	logger         Logger
+	retryPolicy    RetryPolicy
+	staleThreshold time.Duration
+	health         *healthState
 }
 
 //NewReader creates and initializes a new Reader instance.
 func NewReader() *Reader {
-	reader := &Reader{}
+	reader := &Reader{health: &healthState{}}
 	if !disableSdlCreationInConstructor {
 		reader.setDbBackend(sdl.NewSyncStorage())
 	}
 	return reader
 }
 
+//NewReaderWithDbBackend creates a new Reader instance, which reads the UE-NIB data from the given
+//database backend instead of the SDL database. It can be used, for example, to read UE-NIB data
+//restored to an in-memory database, see packages uenibmem and uenibdump.
+func NewReaderWithDbBackend(dbBackend DbBackend) *Reader {
+	reader := &Reader{health: &healthState{}}
+	reader.setDbBackend(dbBackend)
+	return reader
+}
+
+//WithDbBackend returns a shallow copy of the Reader, which reads through the database backend
+//returned by the given function for the database backend of the Reader. The copy shares the
+//configuration and the event subscription status of the Reader. It can be used, for example, to
+//observe the database calls of a single query.
+func (reader *Reader) WithDbBackend(wrap func(dbBackend DbBackend) DbBackend) *Reader {
+	r := *reader
+	r.db = wrap(reader.db)
+	return &r
+}
+
 //Close closes the connection to the database.
 //It is recommended to call Close() after Reader is not used any more, otherwise client process may
 //have hanging file descriptor open for the socket which was used for the backend database
@@ -40,17 +64,23 @@ func NewReader() *Reader {
 func (reader *Reader) Close() error {
 	err := reader.db.Close()
 	if err != nil {
-		return newBackendError(err.Error())
+		reader.log(LOG_LEVEL_ERROR, "closing database failed", "error", err)
+		e := newBackendError(err)
+		e.Operation = "Close"
+		return e
 	}
+	reader.recordClose()
+	reader.log(LOG_LEVEL_INFO, "reader closed")
 	return err
 }
 
 //Variable used for bypassing the real SDL database backend usage in unit tests of UE-NIB.
 var disableSdlCreationInConstructor bool
 
-//SDL database backend interface.
-type iDbBackend interface {
+//DbBackend is the database backend interface used by Reader. SDL SyncStorage implements it.
+type DbBackend interface {
 	Get(ns string, keys []string) (map[string]interface{}, error)
+	GetAll(ns string) ([]string, error)
 	SubscribeChannel(ns string, cb func(string, ...string), channels ...string) error
 	Close() error
 }
@@ -59,17 +89,17 @@ func setDisableSdlCreationInConstructor(disabled bool) {
 	disableSdlCreationInConstructor = disabled
 }
 
-func (reader *Reader) setDbBackend(dbBackend iDbBackend) {
+func (reader *Reader) setDbBackend(dbBackend DbBackend) {
 	reader.db = dbBackend
 }
 
-func newValidationError(err string, vals ...interface{}) *validationError {
-	return &validationError{err: fmt.Sprintf(err, vals...)}
+func newValidationError(err string, vals ...interface{}) *UeNibError {
+	return &UeNibError{Class: ERROR_CLASS_VALIDATION, msg: fmt.Sprintf(err, vals...)}
 }
-func newInternalError(err string) *internalError {
-	return &internalError{err: err}
+func newInternalError(err string) *UeNibError {
+	return &UeNibError{Class: ERROR_CLASS_INTERNAL, msg: err}
 }
 
-func newBackendError(err string) *backendError {
-	return &backendError{err: err, temporary: true}
+func newBackendError(err error) *UeNibError {
+	return &UeNibError{Class: ERROR_CLASS_BACKEND, Err: err, msg: err.Error()}
 }
@@ -7,6 +7,10 @@
 
 package uenibreader
 
+import (
+	"time"
+)
+
 //SetDisableSdlCreationInConstructor exports the private setDisableSdlCreationInConstructor
 //function for unit tests.
 func SetDisableSdlCreationInConstructor(disabled bool) {
@@ -15,6 +19,11 @@ func SetDisableSdlCreationInConstructor(disabled bool) {
 
 //SetDbBackend exports the private setDbBackend function for unit tests.
 //Used to inject mock implementation for database operations.
-func (reader *Reader) SetDbBackend(dbBackend iDbBackend) {
+func (reader *Reader) SetDbBackend(dbBackend DbBackend) {
 	reader.setDbBackend(dbBackend)
 }
+
+//SetRetrySleep replaces the backoff sleep function for unit tests.
+func SetRetrySleep(sleep func(time.Duration)) {
+	retrySleep = sleep
+}
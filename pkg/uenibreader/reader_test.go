@@ -27,6 +27,14 @@ func (m *mockSdlBackend) Get(ns string, keys []string) (map[string]interface{},
 	return a.Get(0).(map[string]interface{}), a.Error(1)
 }
 
+func (m *mockSdlBackend) GetAll(ns string) ([]string, error) {
+	a := m.Called(ns)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).([]string), a.Error(1)
+}
+
 func (m *mockSdlBackend) SubscribeChannel(ns string, cb func(string, ...string), channels ...string) error {
 	a := m.Called(ns, cb, channels)
 	return a.Error(0)
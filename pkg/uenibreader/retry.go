@@ -0,0 +1,122 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader
+
+import (
+	"math/rand"
+	"time"
+)
+
+//RetryPolicy defines how Reader retries queries and event subscriptions, which have failed
+//with a temporary error. Retrying is opted in per error class: database backend errors and
+//value not found failures. Other errors, and database backend errors implementing FailFastError,
+//are never retried.
+//
+//The backoff before the second attempt is InitialBackoff and it is multiplied by Multiplier
+//before each further attempt, up to MaxBackoff. Jitter randomizes each backoff by the given
+//fraction, for example 0.2 gives a backoff between 80% and 120% of the nominal one.
+type RetryPolicy struct {
+	MaxAttempts                int           //Maximum number of attempts including the first one. Values below 2 disable retrying.
+	InitialBackoff             time.Duration //Backoff before the second attempt.
+	MaxBackoff                 time.Duration //Maximum backoff. Zero means no maximum.
+	Multiplier                 float64       //Backoff multiplier. Values below 1 mean 2.
+	Jitter                     float64       //Randomization fraction of the backoff between 0 and 1.
+	RetryBackendErrors         bool          //Retry database backend errors.
+	RetryValueNotFoundFailures bool          //Retry value not found failures, for example when the UE is being added.
+	//OnRetry is called, if not nil, before each retry with the name of the Reader method, the
+	//number of the failed attempt and its error.
+	OnRetry func(method string, attempt int, err error)
+}
+
+//DefaultRetryPolicy returns a retry policy, which makes at most three attempts of the
+//operations failed with a database backend error.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:        3,
+		InitialBackoff:     10 * time.Millisecond,
+		MaxBackoff:         time.Second,
+		Multiplier:         2,
+		Jitter:             0.2,
+		RetryBackendErrors: true,
+	}
+}
+
+//SetRetryPolicy sets the retry policy of the Reader. By default Reader does not retry.
+//SetRetryPolicy() should be called before the Reader is used.
+func (reader *Reader) SetRetryPolicy(policy RetryPolicy) {
+	reader.retryPolicy = policy
+}
+
+//Attempts returns the number of attempts made by a Reader operation, which has failed with
+//the given error. It returns 1 for an error, which was not retried, and 0 for nil.
+func Attempts(err error) int {
+	if err == nil {
+		return 0
+	}
+	if e := asUeNibError(err); e != nil && e.attempts > 0 {
+		return e.attempts
+	}
+	return 1
+}
+
+//Variable used for bypassing the backoff sleeps in unit tests of UE-NIB.
+var retrySleep = time.Sleep
+
+//retry calls the operation until it succeeds, fails with an error not to be retried or the
+//maximum number of attempts has been made.
+func (reader *Reader) retry(method string, operation func() error) error {
+	policy := &reader.retryPolicy
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := operation()
+		if err == nil {
+			return nil
+		}
+		if attempt >= policy.MaxAttempts || !policy.retries(err) {
+			if e := asUeNibError(err); e != nil {
+				e.Operation = method
+				e.attempts = attempt
+			}
+			return err
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(method, attempt, err)
+		}
+		reader.log(LOG_LEVEL_DEBUG, "retrying", "method", method, "attempt", attempt, "error", err)
+		retrySleep(policy.jitter(backoff))
+		backoff = policy.next(backoff)
+	}
+}
+
+func (policy *RetryPolicy) retries(err error) bool {
+	e := asUeNibError(err)
+	if e == nil || !e.Temporary() || isFailFast(e.Err) {
+		return false
+	}
+	return (e.Class == ERROR_CLASS_BACKEND && policy.RetryBackendErrors) ||
+		(e.Class == ERROR_CLASS_VALUE_NOT_FOUND && policy.RetryValueNotFoundFailures)
+}
+
+func (policy *RetryPolicy) next(backoff time.Duration) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	backoff = time.Duration(float64(backoff) * multiplier)
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+	return backoff
+}
+
+func (policy *RetryPolicy) jitter(backoff time.Duration) time.Duration {
+	if policy.Jitter <= 0 {
+		return backoff
+	}
+	return time.Duration(float64(backoff) * (1 + policy.Jitter*(2*rand.Float64()-1)))
+}
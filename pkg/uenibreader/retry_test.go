@@ -0,0 +1,151 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader_test
+
+import (
+	"errors"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+type retry struct {
+	method  string
+	attempt int
+}
+
+func setupRetry(policy uenibreader.RetryPolicy) (*mockSdlBackend, *uenibreader.Reader, *[]time.Duration, *[]retry) {
+	m, reader := setup()
+	var sleeps []time.Duration
+	var retries []retry
+	uenibreader.SetRetrySleep(func(d time.Duration) {
+		sleeps = append(sleeps, d)
+	})
+	policy.OnRetry = func(method string, attempt int, err error) {
+		retries = append(retries, retry{method, attempt})
+	}
+	reader.SetRetryPolicy(policy)
+	return m, reader, &sleeps, &retries
+}
+
+func TestQueryIsRetriedAfterBackendError(t *testing.T) {
+	m, reader, sleeps, retries := setupRetry(uenibreader.RetryPolicy{
+		MaxAttempts:        5,
+		InitialBackoff:     10 * time.Millisecond,
+		MaxBackoff:         30 * time.Millisecond,
+		RetryBackendErrors: true,
+	})
+	ueID := &uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "2"}
+	m.On("Get", "uenib/somegnb", []string{"2,UEMAP_ENBUEX2APID"}).Return(nil, errors.New("Some DB Backend Error")).Times(3)
+	m.On("Get", "uenib/somegnb", []string{"2,UEMAP_ENBUEX2APID"}).Return(
+		map[string]interface{}{"2,UEMAP_ENBUEX2APID": "1"}, nil).Once()
+
+	id, err := reader.GetMeNbUEX2APID(ueID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), id)
+	assert.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond}, *sleeps)
+	assert.Equal(t, []retry{{"GetMeNbUEX2APID", 1}, {"GetMeNbUEX2APID", 2}, {"GetMeNbUEX2APID", 3}}, *retries)
+	m.AssertExpectations(t)
+}
+
+func TestQueryReturnsLastErrorWhenAttemptsExhausted(t *testing.T) {
+	m, reader, sleeps, _ := setupRetry(uenibreader.RetryPolicy{
+		MaxAttempts:                2,
+		InitialBackoff:             time.Millisecond,
+		RetryValueNotFoundFailures: true,
+	})
+	ueID := &uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "2"}
+	m.On("Get", "uenib/somegnb", []string{"2,UEMAP_ENBUEX2APID"}).Return(
+		map[string]interface{}{"2,UEMAP_ENBUEX2APID": nil}, nil).Twice()
+
+	_, err := reader.GetMeNbUEX2APID(ueID)
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+	assert.Equal(t, 2, uenibreader.Attempts(err))
+	assert.Equal(t, 1, len(*sleeps))
+	m.AssertExpectations(t)
+}
+
+func TestQueryIsNotRetriedIfErrorClassNotOptedIn(t *testing.T) {
+	m, reader, sleeps, _ := setupRetry(uenibreader.RetryPolicy{MaxAttempts: 3, RetryValueNotFoundFailures: true})
+	ueID := &uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "2"}
+	m.On("Get", "uenib/somegnb", []string{"2,UEMAP_ENBUEX2APID"}).Return(nil, errors.New("Some DB Backend Error")).Once()
+
+	_, err := reader.GetMeNbUEX2APID(ueID)
+	assert.True(t, uenibreader.IsBackendError(err))
+	assert.Equal(t, 1, uenibreader.Attempts(err))
+	assert.Empty(t, *sleeps)
+
+	_, err = reader.GetMeNbUEX2APID(&uenib.UeID{GNb: "somegnb"})
+	assert.True(t, uenibreader.IsValidationError(err))
+	assert.Equal(t, 1, uenibreader.Attempts(err))
+	m.AssertExpectations(t)
+}
+
+type failFastError struct{}
+
+func (failFastError) Error() string  { return "rejected" }
+func (failFastError) FailFast() bool { return true }
+
+func TestFailFastBackendErrorIsNotRetriedAndIsLoggedOnDebugLevel(t *testing.T) {
+	m, reader, sleeps, _ := setupRetry(uenibreader.DefaultRetryPolicy())
+	logger := &recordingLogger{}
+	reader.SetLogger(logger)
+	ueID := &uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "2"}
+	m.On("Get", "uenib/somegnb", []string{"2,UEMAP_ENBUEX2APID"}).Return(nil, failFastError{}).Once()
+
+	_, err := reader.GetMeNbUEX2APID(ueID)
+	assert.True(t, uenibreader.IsBackendError(err))
+	assert.True(t, errors.Is(err, failFastError{}))
+	assert.Equal(t, 1, uenibreader.Attempts(err))
+	assert.Empty(t, *sleeps)
+	assert.Equal(t, 1, len(logger.entries))
+	assert.Equal(t, uenibreader.LOG_LEVEL_DEBUG, logger.entries[0].level)
+	m.AssertExpectations(t)
+}
+
+func TestSubscribeEventsIsRetriedPerChannel(t *testing.T) {
+	m, reader, _, retries := setupRetry(uenibreader.DefaultRetryPolicy())
+	m.On("SubscribeChannel", "uenib/somegnb", mock.AnythingOfType("func(string, ...string)"),
+		[]string{"somegnb_DUAL_CONNECTIVITY"}).Return(errors.New("Some DB Backend Error")).Once()
+	m.On("SubscribeChannel", "uenib/somegnb", mock.AnythingOfType("func(string, ...string)"),
+		[]string{"somegnb_DUAL_CONNECTIVITY"}).Return(nil).Once()
+	m.On("SubscribeChannel", "uenib/somegnb", mock.AnythingOfType("func(string, ...string)"),
+		[]string{"somegnb_MOBILITY"}).Return(nil).Once()
+
+	err := reader.SubscribeEvents([]string{"somegnb"},
+		[]uenibreader.EventCategory{uenibreader.DualConnectivity, uenibreader.Mobility},
+		func(gNb string, eventCategory uenibreader.EventCategory, events []string) {})
+	assert.Nil(t, err)
+	assert.Equal(t, []retry{{"SubscribeEvents", 1}}, *retries)
+	m.AssertExpectations(t)
+}
+
+func TestRetryJitterStaysWithinFraction(t *testing.T) {
+	m, reader, sleeps, _ := setupRetry(uenibreader.RetryPolicy{
+		MaxAttempts:        10,
+		InitialBackoff:     100 * time.Millisecond,
+		MaxBackoff:         100 * time.Millisecond,
+		Jitter:             0.5,
+		RetryBackendErrors: true,
+	})
+	m.On("GetAll", "uenib/somegnb").Return(nil, errors.New("Some DB Backend Error"))
+
+	_, err := reader.GetUeIDs("somegnb")
+	assert.Equal(t, 10, uenibreader.Attempts(err))
+	assert.Equal(t, 9, len(*sleeps))
+	for _, d := range *sleeps {
+		assert.True(t, d >= 50*time.Millisecond && d <= 150*time.Millisecond)
+	}
+}
+
+func TestAttemptsOfNilError(t *testing.T) {
+	assert.Equal(t, 0, uenibreader.Attempts(nil))
+}
@@ -0,0 +1,156 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader
+
+import (
+	"fmt"
+	"github.com/nokia/ue-nib-library/internal"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"strconv"
+)
+
+//snapshotAttempts is the number of times GetUeSnapshot() reads UE's data, before it gives up
+//because of concurrent modifications.
+const snapshotAttempts = 3
+
+//GetUeSnapshot returns all the data of a UE: UE's X2AP IDs, last-updated time, state, serving
+//cells and bearers, read consistently at one UE version.
+//
+//Other queries read the UE's lists, like the E-RAB IDs, and the keys of the listed items in
+//separate database reads, so they may see the UE half-way through an update done by the UE-NIB
+//writer. GetUeSnapshot() reads UE's version with every read and reads the data again, if the
+//version has changed in between. If the writer does not store the version, a listed item
+//missing its keys is treated as a concurrent modification. The data is read at most 3 times,
+//after which a concurrent modification error is returned.
+//Parameter ueID identifies User equipment (UE).
+func (reader *Reader) GetUeSnapshot(ueID *uenib.UeID) (*uenib.UeSnapshot, error) {
+	var ret *uenib.UeSnapshot
+	err := reader.retry("GetUeSnapshot", func() (err error) {
+		ret, err = reader.getUeSnapshot(ueID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getUeSnapshot(ueID *uenib.UeID) (*uenib.UeSnapshot, error) {
+	id, err := reader.resolveUeID(ueID)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 1; ; attempt++ {
+		snapshot, modified, err := reader.readUeSnapshot(id)
+		if !modified {
+			if err == nil && reader.isStale(snapshot.Ue.LastUpdated) {
+				return nil, toValueNotFoundFailure(id, internal.DbKeyUeLastUpdated(id))
+			}
+			return snapshot, err
+		}
+		reader.log(LOG_LEVEL_DEBUG, "concurrent modification detected", "ueID", id, "attempt", attempt)
+		if attempt == snapshotAttempts {
+			if err != nil {
+				return nil, err
+			}
+			return nil, &UeNibError{Class: ERROR_CLASS_CONCURRENT_MODIFICATION, UeID: *id,
+				Key: internal.DbKeyUeVersion(id), msg: fmt.Sprintf("UE modified during %d reads", attempt)}
+		}
+	}
+}
+
+//readUeSnapshot reads UE's data. It returns true, if the UE was modified during the read. If the
+//UE-NIB writer does not store UE's version, the error of a listed item missing its keys is
+//returned with true.
+func (reader *Reader) readUeSnapshot(id *uenib.UeID) (*uenib.UeSnapshot, bool, error) {
+	versionKey := internal.DbKeyUeVersion(id)
+	mapKey := internal.DbKeyUeMapENbToGNbUeX2ApID(id)
+	erabIDsKey := internal.DbKeyUeErabIDs(id)
+	sCellIndexesKey := internal.DbKeyUeSCellIndexes(id)
+
+	keys := append([]string{versionKey, mapKey, internal.DbKeyUeLastUpdated(id), internal.DbKeyUeStateEvent(id),
+		internal.DbKeyUeStateCause(id), erabIDsKey, sCellIndexesKey}, internal.GetPsCellAllDbKeys(id)...)
+	q, err := reader.newGetQuery(id, keys)
+	if err != nil {
+		return nil, false, err
+	}
+
+	snapshot := &uenib.UeSnapshot{Ue: uenib.Ue{UeID: *id}}
+	if snapshot.Ue.UeID.GNbUeX2ApID, err = q.getKeyStringValue(id, mapKey); err != nil {
+		return nil, false, err
+	}
+	version, hasVersion := q.kvMap[versionKey].(string)
+	if hasVersion {
+		if snapshot.Version, err = strconv.ParseUint(version, 10, 64); err != nil {
+			return nil, false, withKey(toValidationError(id, err), versionKey)
+		}
+	}
+	if snapshot.Ue.LastUpdated, err = q.getOptionalKeyLastUpdatedValue(id, internal.DbKeyUeLastUpdated(id)); err != nil {
+		return nil, false, err
+	}
+
+	var erabIDs []uenib.ErabID
+	if val, ok := q.kvMap[erabIDsKey].(string); ok && val != "" {
+		if erabIDs, err = parseErabIDsStringToErabIDSlice(id, val); err != nil {
+			return nil, false, withKey(err, erabIDsKey)
+		}
+	}
+	sCellIndexes, err := q.getOptionalKeySCellIndexesValue(id, sCellIndexesKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var itemKeys []string
+	for _, erabID := range erabIDs {
+		itemKeys = append(itemKeys, internal.GetErabAllDbKeys(id, erabID)...)
+	}
+	for _, sCellIndex := range sCellIndexes {
+		itemKeys = append(itemKeys, internal.GetSCellAllDbKeys(id, sCellIndex)...)
+	}
+	if len(itemKeys) > 0 {
+		itemQ, err := reader.newGetQuery(id, append([]string{versionKey}, itemKeys...))
+		if err != nil {
+			return nil, false, err
+		}
+		if itemVersion, ok := itemQ.kvMap[versionKey].(string); ok != hasVersion || itemVersion != version {
+			return nil, true, nil
+		}
+		for _, key := range itemKeys {
+			q.kvMap[key] = itemQ.kvMap[key]
+		}
+	}
+
+	if err = q.getUeSnapshotValues(id, snapshot, erabIDs, sCellIndexes); err != nil {
+		return nil, !hasVersion && IsValueNotFoundFailure(err), err
+	}
+	return snapshot, false, nil
+}
+
+func (q *query) getUeSnapshotValues(id *uenib.UeID, snapshot *uenib.UeSnapshot, erabIDs []uenib.ErabID,
+	sCellIndexes []uenib.SCellIndex) error {
+	var err error
+	if event, ok := q.kvMap[internal.DbKeyUeStateEvent(id)].(string); ok {
+		snapshot.State = &uenib.UeState{Event: event}
+		if cause, ok := q.kvMap[internal.DbKeyUeStateCause(id)].(string); ok {
+			snapshot.State.Cause = cause
+		}
+	}
+	if val, ok := q.kvMap[internal.DbKeyPsCellPci(id)]; ok && val != nil {
+		var cells uenib.ServingCells
+		if cells.PsCell, err = q.getPsCellValue(id); err != nil {
+			return err
+		}
+		if cells.SCells, err = q.getSCellsValue(id, sCellIndexes); err != nil {
+			return err
+		}
+		snapshot.ServingCells = &cells
+	}
+	if len(erabIDs) > 0 {
+		if snapshot.Bearers, err = q.getBearersValue(id, erabIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader_test
+
+import (
+	"errors"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const snapshotTestNs = "uenib/somegnb"
+
+//writingDb calls the write function, which simulates the UE-NIB writer, before each Get.
+type writingDb struct {
+	*uenibmem.Db
+	gets  int
+	write func(db *uenibmem.Db, get int)
+}
+
+func (db *writingDb) Get(ns string, keys []string) (map[string]interface{}, error) {
+	db.gets++
+	if db.write != nil {
+		db.write(db.Db, db.gets)
+	}
+	return db.Db.Get(ns, keys)
+}
+
+func setErab(db *uenibmem.Db, erabID string, drbID string) {
+	db.Set(snapshotTestNs,
+		"1,"+erabID+",UE_ERAB_DRB_ID", drbID,
+		"1,"+erabID+",UE_ERAB_S1_UL_GTP_TUNNEL_ADDR", "addr",
+		"1,"+erabID+",UE_ERAB_S1_UL_GTP_TUNNEL_TEID", "teid",
+		"1,"+erabID+",UE_ERAB_QOS_ARP_PL", "1",
+		"1,"+erabID+",UE_ERAB_QOS_QCI", "9")
+}
+
+func setupSnapshotDb(version bool) *writingDb {
+	db := &writingDb{Db: uenibmem.NewDb()}
+	db.Set(snapshotTestNs,
+		"1,UEMAP_GNBUEX2APID", "11",
+		"11,UEMAP_ENBUEX2APID", "1",
+		"1,UE_LAST_UPDATED", "1589278272123",
+		"1,UE_STATE_EVENT", "SgNBAdditionRequest",
+		"1,UE_PSCELL_PCI", "10",
+		"1,UE_PSCELL_FREQ", "632628",
+		"1,UE_SCELL_INDEXES", "2",
+		"1,2,UE_SCELL_PCI", "20",
+		"1,2,UE_SCELL_FREQ", "632629",
+		"1,UE_ERAB_IDS", "5")
+	setErab(db.Db, "5", "1")
+	if version {
+		db.Set(snapshotTestNs, "1,UE_VERSION", "1")
+	}
+	return db
+}
+
+//replaceErab replaces E-RAB 5 with E-RAB 6 as the UE-NIB writer does in one atomic write.
+func replaceErab(db *uenibmem.Db, version string) {
+	db.Remove(snapshotTestNs, []string{"1,5,UE_ERAB_DRB_ID", "1,5,UE_ERAB_S1_UL_GTP_TUNNEL_ADDR",
+		"1,5,UE_ERAB_S1_UL_GTP_TUNNEL_TEID", "1,5,UE_ERAB_QOS_ARP_PL", "1,5,UE_ERAB_QOS_QCI"})
+	setErab(db, "6", "2")
+	db.Set(snapshotTestNs, "1,UE_ERAB_IDS", "6", "1,UE_VERSION", version)
+}
+
+func TestGetUeSnapshotSuccess(t *testing.T) {
+	db := setupSnapshotDb(true)
+	reader := uenibreader.NewReaderWithDbBackend(db)
+
+	snapshot, err := reader.GetUeSnapshot(&uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "11"})
+	assert.Nil(t, err)
+	assert.Equal(t, uenib.UeID{GNb: "somegnb", GNbUeX2ApID: "11", ENbUeX2ApID: "1"}, snapshot.Ue.UeID)
+	assert.True(t, time.Unix(1589278272, 123000000).Equal(snapshot.Ue.LastUpdated))
+	assert.Equal(t, uint64(1), snapshot.Version)
+	assert.Equal(t, &uenib.UeState{Event: "SgNBAdditionRequest"}, snapshot.State)
+	assert.Equal(t, uint32(10), snapshot.ServingCells.PsCell.Pci)
+	assert.Equal(t, 1, len(snapshot.ServingCells.SCells))
+	assert.Equal(t, uint32(20), snapshot.ServingCells.SCells[0].Pci)
+	assert.Equal(t, 1, len(snapshot.Bearers))
+	assert.Equal(t, uenib.ErabID(5), snapshot.Bearers[0].ErabID)
+	assert.Equal(t, 3, db.gets)
+}
+
+func TestGetUeSnapshotOfUeWithoutListsReadsOnce(t *testing.T) {
+	db := &writingDb{Db: uenibmem.NewDb()}
+	db.Set(snapshotTestNs, "1,UEMAP_GNBUEX2APID", "11")
+	reader := uenibreader.NewReaderWithDbBackend(db)
+
+	snapshot, err := reader.GetUeSnapshot(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.Nil(t, err)
+	assert.Nil(t, snapshot.State)
+	assert.Nil(t, snapshot.ServingCells)
+	assert.Nil(t, snapshot.Bearers)
+	assert.Equal(t, uint64(0), snapshot.Version)
+	assert.Equal(t, 1, db.gets)
+}
+
+func TestGetUeSnapshotReadsAgainIfVersionChanges(t *testing.T) {
+	db := setupSnapshotDb(true)
+	db.write = func(db *uenibmem.Db, get int) {
+		if get == 2 {
+			replaceErab(db, "2")
+		}
+	}
+	reader := uenibreader.NewReaderWithDbBackend(db)
+
+	snapshot, err := reader.GetUeSnapshot(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), snapshot.Version)
+	assert.Equal(t, uenib.ErabID(6), snapshot.Bearers[0].ErabID)
+	assert.Equal(t, uint32(2), snapshot.Bearers[0].DrbID)
+	assert.Equal(t, 4, db.gets)
+}
+
+func TestGetUeSnapshotReturnsErrorIfUeIsModifiedDuringEachRead(t *testing.T) {
+	db := setupSnapshotDb(true)
+	db.write = func(db *uenibmem.Db, get int) {
+		db.Set(snapshotTestNs, "1,UE_VERSION", string(rune('0'+get)))
+	}
+	reader := uenibreader.NewReaderWithDbBackend(db)
+
+	_, err := reader.GetUeSnapshot(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.True(t, uenibreader.IsConcurrentModificationError(err))
+	assert.True(t, errors.Is(err, uenibreader.ErrConcurrentModification))
+	var ueNibErr *uenibreader.UeNibError
+	assert.True(t, errors.As(err, &ueNibErr))
+	assert.Equal(t, "1,UE_VERSION", ueNibErr.Key)
+	assert.Equal(t, "GetUeSnapshot", ueNibErr.Operation)
+	assert.True(t, ueNibErr.Temporary())
+	assert.EqualError(t, err, "UE-NIB "+ueNibErr.UeID.String()+" concurrent modification: UE modified during 3 reads")
+	assert.Equal(t, 6, db.gets)
+}
+
+func TestGetUeSnapshotWithoutVersionReadsAgainIfListedItemMissesKeys(t *testing.T) {
+	db := setupSnapshotDb(false)
+	db.Set(snapshotTestNs, "1,UE_ERAB_IDS", "5,6")
+	db.write = func(db *uenibmem.Db, get int) {
+		if get == 3 {
+			setErab(db, "6", "2")
+		}
+	}
+	reader := uenibreader.NewReaderWithDbBackend(db)
+
+	snapshot, err := reader.GetUeSnapshot(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(snapshot.Bearers))
+	assert.Equal(t, 4, db.gets)
+}
+
+func TestGetUeSnapshotWithVersionReturnsErrorIfListedItemMissesKeys(t *testing.T) {
+	db := setupSnapshotDb(true)
+	db.Set(snapshotTestNs, "1,UE_ERAB_IDS", "5,6")
+	reader := uenibreader.NewReaderWithDbBackend(db)
+
+	_, err := reader.GetUeSnapshot(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+	assert.Equal(t, 2, db.gets)
+}
+
+func TestGetUeSnapshotReturnsErrorIfUeNotFound(t *testing.T) {
+	reader := uenibreader.NewReaderWithDbBackend(uenibmem.NewDb())
+	_, err := reader.GetUeSnapshot(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+}
@@ -0,0 +1,176 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nokia/ue-nib-library/internal"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"time"
+)
+
+//SetStaleThreshold sets the stale UE filter of the Reader. A UE, which has not been updated by
+//the UE-NIB writer within the given threshold, is treated as if it did not exist: the queries of
+//the UE return a value not found failure and GetUeIDs() does not return it. A UE without the
+//last-updated time is never treated as stale. By default, and if the threshold is 0, there is
+//no filter. SetStaleThreshold() should be called before the Reader is used.
+func (reader *Reader) SetStaleThreshold(threshold time.Duration) {
+	reader.staleThreshold = threshold
+}
+
+//GetUe returns UE with both X2AP IDs resolved and the time when the UE-NIB writer updated the UE
+//last time. The last-updated time is zero, if the writer has not stored it.
+//Parameter ueID identifies User equipment (UE).
+func (reader *Reader) GetUe(ueID *uenib.UeID) (*uenib.Ue, error) {
+	var ret *uenib.Ue
+	err := reader.retry("GetUe", func() (err error) {
+		ret, err = reader.getUe(ueID)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) getUe(ueID *uenib.UeID) (*uenib.Ue, error) {
+	id, err := reader.resolveUeID(ueID)
+	if err != nil {
+		return nil, err
+	}
+	mapKey := internal.DbKeyUeMapENbToGNbUeX2ApID(id)
+	lastUpdatedKey := internal.DbKeyUeLastUpdated(id)
+
+	q, err := reader.newGetQuery(id, []string{mapKey, lastUpdatedKey})
+	if err != nil {
+		return nil, err
+	}
+	ue := &uenib.Ue{UeID: *id}
+	if ue.UeID.GNbUeX2ApID, err = q.getKeyStringValue(id, mapKey); err != nil {
+		return nil, err
+	}
+	if ue.LastUpdated, err = q.getOptionalKeyLastUpdatedValue(id, lastUpdatedKey); err != nil {
+		return nil, err
+	}
+	if reader.isStale(ue.LastUpdated) {
+		return nil, toValueNotFoundFailure(id, lastUpdatedKey)
+	}
+	return ue, nil
+}
+
+//ListStaleUes returns the UEs of a gNB, which the UE-NIB writer has not updated within the given
+//time, sorted by ENbUeX2ApID. Such a UE has possibly been left behind, because its removal was
+//lost. UEs without the last-updated time are not returned, because their age is not known.
+//The stale UE filter set by SetStaleThreshold() does not apply to ListStaleUes().
+//Parameter gNb identifies GNb RanName what is form of: <Antenna-Type>:<3 MCC digits>-<3 MNC digits>-<Node ID>.
+func (reader *Reader) ListStaleUes(gNb string, olderThan time.Duration) ([]uenib.Ue, error) {
+	var ret []uenib.Ue
+	err := reader.retry("ListStaleUes", func() (err error) {
+		ret, err = reader.listStaleUes(gNb, olderThan)
+		return err
+	})
+	return ret, err
+}
+
+func (reader *Reader) listStaleUes(gNb string, olderThan time.Duration) ([]uenib.Ue, error) {
+	gNbID := &uenib.UeID{GNb: gNb}
+	if olderThan < 0 {
+		return nil, toValidationError(gNbID, errors.New(fmt.Sprintf("%s :: negative age %s", gNbID.String(), olderThan)))
+	}
+	ueIDs, err := reader.getAllUeIDs(gNb)
+	if err != nil || len(ueIDs) == 0 {
+		return nil, err
+	}
+	lastUpdated, err := reader.getLastUpdated(gNbID, ueIDs)
+	if err != nil {
+		return nil, err
+	}
+	var ues []uenib.Ue
+	limit := time.Now().Add(-olderThan)
+	for i, ueID := range ueIDs {
+		if !lastUpdated[i].IsZero() && lastUpdated[i].Before(limit) {
+			ues = append(ues, uenib.Ue{UeID: ueID, LastUpdated: lastUpdated[i]})
+		}
+	}
+	return ues, nil
+}
+
+//getLastUpdated reads the last-updated times of the given UEs of a gNB in one query. The time is
+//zero for a UE without a valid last-updated time.
+func (reader *Reader) getLastUpdated(gNbID *uenib.UeID, ueIDs []uenib.UeID) ([]time.Time, error) {
+	keys := make([]string, len(ueIDs))
+	for i := range ueIDs {
+		keys[i] = internal.DbKeyUeLastUpdated(&ueIDs[i])
+	}
+	q, err := reader.newGetQuery(gNbID, keys)
+	if err != nil {
+		return nil, err
+	}
+	lastUpdated := make([]time.Time, len(ueIDs))
+	for i := range ueIDs {
+		t, err := q.getOptionalKeyLastUpdatedValue(&ueIDs[i], keys[i])
+		if err != nil {
+			reader.log(LOG_LEVEL_WARNING, "invalid last updated time", "ueID", &ueIDs[i], "error", err)
+			continue
+		}
+		lastUpdated[i] = t
+	}
+	return lastUpdated, nil
+}
+
+//filterStaleUeIDs removes the stale UEs from the given UEs of a gNB, if the stale UE filter is set.
+func (reader *Reader) filterStaleUeIDs(gNb string, ueIDs []uenib.UeID) ([]uenib.UeID, error) {
+	if reader.staleThreshold <= 0 || len(ueIDs) == 0 {
+		return ueIDs, nil
+	}
+	lastUpdated, err := reader.getLastUpdated(&uenib.UeID{GNb: gNb}, ueIDs)
+	if err != nil {
+		return nil, err
+	}
+	live := ueIDs[:0]
+	for i, ueID := range ueIDs {
+		if !reader.isStale(lastUpdated[i]) {
+			live = append(live, ueID)
+		}
+	}
+	return live, nil
+}
+
+//checkStale returns a value not found failure, if the stale UE filter is set and the UE is stale.
+//Parameter ueID must have ENbUeX2ApID set.
+func (reader *Reader) checkStale(ueID *uenib.UeID) error {
+	if reader.staleThreshold <= 0 {
+		return nil
+	}
+	key := internal.DbKeyUeLastUpdated(ueID)
+	q, err := reader.newGetQuery(ueID, []string{key})
+	if err != nil {
+		return err
+	}
+	lastUpdated, err := q.getOptionalKeyLastUpdatedValue(ueID, key)
+	if err != nil {
+		return err
+	}
+	if reader.isStale(lastUpdated) {
+		return toValueNotFoundFailure(ueID, key)
+	}
+	return nil
+}
+
+func (reader *Reader) isStale(lastUpdated time.Time) bool {
+	return reader.staleThreshold > 0 && !lastUpdated.IsZero() && time.Since(lastUpdated) > reader.staleThreshold
+}
+
+func (q *query) getOptionalKeyLastUpdatedValue(ueID *uenib.UeID, key string) (time.Time, error) {
+	if val, ok := q.kvMap[key]; ok && val != nil {
+		t, err := uenib.ParseLastUpdated(val.(string))
+		if err != nil {
+			return time.Time{}, withKey(toValidationError(ueID, err), key)
+		}
+		return t, nil
+	}
+	return time.Time{}, nil
+}
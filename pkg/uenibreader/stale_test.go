@@ -0,0 +1,110 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibreader_test
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const staleTestGnb = "somegnb"
+
+//setupStaleDb creates a database with a live UE 1/11, a stale UE 2/12 and UE 3/13 without the
+//last-updated time.
+func setupStaleDb() (*uenibmem.Db, time.Time, time.Time) {
+	db := uenibmem.NewDb()
+	live := time.Now().Add(-time.Second).Truncate(time.Millisecond)
+	stale := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	db.Set("uenib/"+staleTestGnb,
+		"11,UEMAP_ENBUEX2APID", "1", "1,UEMAP_GNBUEX2APID", "11",
+		"1,UE_LAST_UPDATED", uenib.FormatLastUpdated(live),
+		"12,UEMAP_ENBUEX2APID", "2", "2,UEMAP_GNBUEX2APID", "12",
+		"2,UE_LAST_UPDATED", uenib.FormatLastUpdated(stale),
+		"2,UE_PSCELL_PCI", "10", "2,UE_PSCELL_FREQ", "632628",
+		"13,UEMAP_ENBUEX2APID", "3", "3,UEMAP_GNBUEX2APID", "13")
+	return db, live, stale
+}
+
+func TestGetUeReturnsLastUpdated(t *testing.T) {
+	db, live, _ := setupStaleDb()
+	reader := uenibreader.NewReaderWithDbBackend(db)
+
+	ue, err := reader.GetUe(&uenib.UeID{GNb: staleTestGnb, GNbUeX2ApID: "11"})
+	assert.Nil(t, err)
+	assert.Equal(t, uenib.UeID{GNb: staleTestGnb, GNbUeX2ApID: "11", ENbUeX2ApID: "1"}, ue.UeID)
+	assert.True(t, live.Equal(ue.LastUpdated))
+
+	ue, err = reader.GetUe(&uenib.UeID{GNb: staleTestGnb, ENbUeX2ApID: "3"})
+	assert.Nil(t, err)
+	assert.Equal(t, "13", ue.UeID.GNbUeX2ApID)
+	assert.True(t, ue.LastUpdated.IsZero())
+
+	_, err = reader.GetUe(&uenib.UeID{GNb: staleTestGnb, ENbUeX2ApID: "4"})
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+}
+
+func TestGetUeReturnsErrorIfLastUpdatedIsInvalid(t *testing.T) {
+	db, _, _ := setupStaleDb()
+	db.Set("uenib/"+staleTestGnb, "1,UE_LAST_UPDATED", "yesterday")
+	reader := uenibreader.NewReaderWithDbBackend(db)
+
+	_, err := reader.GetUe(&uenib.UeID{GNb: staleTestGnb, ENbUeX2ApID: "1"})
+	assert.True(t, uenibreader.IsValidationError(err))
+	assert.Equal(t, "1,UE_LAST_UPDATED", err.(*uenibreader.UeNibError).Key)
+}
+
+func TestListStaleUes(t *testing.T) {
+	db, _, stale := setupStaleDb()
+	reader := uenibreader.NewReaderWithDbBackend(db)
+
+	ues, err := reader.ListStaleUes(staleTestGnb, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ues))
+	assert.Equal(t, uenib.UeID{GNb: staleTestGnb, GNbUeX2ApID: "12", ENbUeX2ApID: "2"}, ues[0].UeID)
+	assert.True(t, stale.Equal(ues[0].LastUpdated))
+
+	ues, err = reader.ListStaleUes(staleTestGnb, 2*time.Hour)
+	assert.Nil(t, err)
+	assert.Empty(t, ues)
+
+	_, err = reader.ListStaleUes(staleTestGnb, -time.Minute)
+	assert.True(t, uenibreader.IsValidationError(err))
+}
+
+func TestStaleThresholdTreatsStaleUesAsNotFound(t *testing.T) {
+	db, _, _ := setupStaleDb()
+	reader := uenibreader.NewReaderWithDbBackend(db)
+	staleUeID := &uenib.UeID{GNb: staleTestGnb, ENbUeX2ApID: "2"}
+	_, err := reader.GetPsCell(staleUeID)
+	assert.Nil(t, err)
+
+	reader.SetStaleThreshold(time.Minute)
+	_, err = reader.GetPsCell(staleUeID)
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+	assert.Equal(t, "2,UE_LAST_UPDATED", err.(*uenibreader.UeNibError).Key)
+	_, err = reader.GetUe(staleUeID)
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+	_, err = reader.GetSgNbUEX2APID(staleUeID)
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+	_, err = reader.GetMeNbUEX2APID(&uenib.UeID{GNb: staleTestGnb, GNbUeX2ApID: "12"})
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+
+	ueIDs, err := reader.GetUeIDs(staleTestGnb)
+	assert.Nil(t, err)
+	assert.Equal(t, []uenib.UeID{
+		{GNb: staleTestGnb, GNbUeX2ApID: "11", ENbUeX2ApID: "1"},
+		{GNb: staleTestGnb, GNbUeX2ApID: "13", ENbUeX2ApID: "3"},
+	}, ueIDs)
+	id, err := reader.GetSgNbUEX2APID(&uenib.UeID{GNb: staleTestGnb, ENbUeX2ApID: "3"})
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(13), id)
+}
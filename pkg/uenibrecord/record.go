@@ -0,0 +1,223 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package uenibrecord implements recording of UE-NIB events together with the database reads
+//done by the Reader, and a deterministic replay of the recording, for example to regression
+//test xApp logic against the UE-NIB events and data of a real incident.
+//
+//Recorder is a database backend decorator. Create a Reader with
+//uenibreader.NewReaderWithDbBackend() giving a Recorder, which wraps the real database backend,
+//and use the Reader as usual. Replayer is a database backend, which can be given to
+//uenibreader.NewReaderWithDbBackend() in the same way. Replay() calls the event callbacks
+//subscribed with SubscribeEvents() in the recorded order and the Reader queries return the
+//values read from the database at the time of the event.
+package uenibrecord
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nokia/ue-nib-library/pkg/uenibdump"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+//FormatVersion is the version of the recording file format written by this package.
+//ReadRecording() fails, if the file has a newer version.
+const FormatVersion = 1
+
+const formatName = "uenib-recording"
+
+//RecordType is the type of a record.
+type RecordType int
+
+const (
+	//RECORD_EVENT record has the events delivered to a subscribed channel.
+	RECORD_EVENT RecordType = iota + 1
+	//RECORD_GET record has the result of a database Get.
+	RECORD_GET
+	//RECORD_GET_ALL record has the result of a database GetAll.
+	RECORD_GET_ALL
+)
+
+var recordTypeNames = map[RecordType]string{
+	RECORD_EVENT:   "event",
+	RECORD_GET:     "get",
+	RECORD_GET_ALL: "getAll",
+}
+
+//String returns record type as a string.
+func (recordType RecordType) String() string {
+	if name, ok := recordTypeNames[recordType]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+//MarshalJSON encodes the record type as a JSON string.
+func (recordType RecordType) MarshalJSON() ([]byte, error) {
+	if _, ok := recordTypeNames[recordType]; !ok {
+		return nil, fmt.Errorf("unknown record type %d", recordType)
+	}
+	return json.Marshal(recordType.String())
+}
+
+//UnmarshalJSON decodes the record type from a JSON string.
+func (recordType *RecordType) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	for t, name := range recordTypeNames {
+		if name == str {
+			*recordType = t
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown record type '%s'", str)
+}
+
+//Record is a recorded event delivery or database read.
+//For RECORD_EVENT record Channel and Events are set. For RECORD_GET record Keys are the read keys
+//and Values the found ones. For RECORD_GET_ALL record Keys are the keys of the namespace.
+type Record struct {
+	Offset    time.Duration     `json:"offset"` //Time since the start of the recording.
+	Type      RecordType        `json:"type"`
+	Namespace string            `json:"namespace"`
+	Channel   string            `json:"channel,omitempty"`
+	Events    []string          `json:"events,omitempty"`
+	Keys      []string          `json:"keys,omitempty"`
+	Values    []uenibdump.Entry `json:"values,omitempty"`
+}
+
+//Recording is a sequence of records in the recorded order.
+type Recording struct {
+	Version int       `json:"version"`
+	Started time.Time `json:"started"`
+	Records []Record  `json:"records"`
+}
+
+//Write writes the recording as a recording file. The file is a JSON document, where key values,
+//which are not valid UTF-8, are base64 encoded like in the uenibdump package dump file.
+func (recording *Recording) Write(w io.Writer) error {
+	file := struct {
+		Format string `json:"format"`
+		*Recording
+	}{formatName, recording}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(file)
+}
+
+//ReadRecording reads a recording file. An error is returned, if the file is not a recording
+//file or it has a newer format version than FormatVersion.
+func ReadRecording(r io.Reader) (*Recording, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var header struct {
+		Format  string `json:"format"`
+		Version int    `json:"version"`
+	}
+	if err = json.Unmarshal(data, &header); err != nil || header.Format != formatName {
+		return nil, fmt.Errorf("not a UE-NIB recording file")
+	}
+	if header.Version < 1 || header.Version > FormatVersion {
+		return nil, fmt.Errorf("unsupported UE-NIB recording file version %d", header.Version)
+	}
+	recording := &Recording{}
+	if err = json.Unmarshal(data, recording); err != nil {
+		return nil, fmt.Errorf("UE-NIB recording file decode failure: %s", err.Error())
+	}
+	return recording, nil
+}
+
+//Recorder records the events and database reads going through it to the database backend.
+//NOTE: Use NewRecorder() function to create a Recorder instance.
+type Recorder struct {
+	db      uenibreader.DbBackend
+	now     func() time.Time
+	mutex   sync.Mutex
+	started time.Time
+	records []Record
+}
+
+//NewRecorder creates a new Recorder, which records the events and reads of the given database
+//backend. The recording is started immediately.
+func NewRecorder(db uenibreader.DbBackend) *Recorder {
+	return newRecorder(db, time.Now)
+}
+
+func newRecorder(db uenibreader.DbBackend, now func() time.Time) *Recorder {
+	return &Recorder{db: db, now: now, started: now()}
+}
+
+//Get reads the keys from the database backend and records the result.
+func (recorder *Recorder) Get(ns string, keys []string) (map[string]interface{}, error) {
+	kvMap, err := recorder.db.Get(ns, keys)
+	if err != nil {
+		return kvMap, err
+	}
+	record := Record{Type: RECORD_GET, Namespace: ns, Keys: append([]string(nil), keys...)}
+	for _, key := range keys {
+		switch val := kvMap[key].(type) {
+		case string:
+			record.Values = append(record.Values, uenibdump.Entry{Key: key, Value: val})
+		case []byte:
+			record.Values = append(record.Values, uenibdump.Entry{Key: key, Value: string(val)})
+		}
+	}
+	recorder.record(record)
+	return kvMap, err
+}
+
+//GetAll reads the keys of the namespace from the database backend and records the result.
+func (recorder *Recorder) GetAll(ns string) ([]string, error) {
+	keys, err := recorder.db.GetAll(ns)
+	if err != nil {
+		return keys, err
+	}
+	recorder.record(Record{Type: RECORD_GET_ALL, Namespace: ns, Keys: append([]string(nil), keys...)})
+	return keys, err
+}
+
+//SubscribeChannel subscribes the channels from the database backend. The events are recorded
+//before they are given to the callback, so that the reads done by the callback are recorded
+//after the event.
+func (recorder *Recorder) SubscribeChannel(ns string, cb func(string, ...string), channels ...string) error {
+	return recorder.db.SubscribeChannel(ns, func(ch string, events ...string) {
+		recorder.record(Record{Type: RECORD_EVENT, Namespace: ns, Channel: ch,
+			Events: append([]string(nil), events...)})
+		cb(ch, events...)
+	}, channels...)
+}
+
+//Close closes the database backend. The recording is kept.
+func (recorder *Recorder) Close() error {
+	return recorder.db.Close()
+}
+
+//Recording returns a copy of the records recorded so far.
+func (recorder *Recorder) Recording() *Recording {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return &Recording{
+		Version: FormatVersion,
+		Started: recorder.started.UTC(),
+		Records: append([]Record{}, recorder.records...),
+	}
+}
+
+func (recorder *Recorder) record(record Record) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	record.Offset = recorder.now().Sub(recorder.started)
+	recorder.records = append(recorder.records, record)
+}
@@ -0,0 +1,24 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibrecord
+
+import (
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"time"
+)
+
+//NewRecorderWithClock exports the private newRecorder function for unit tests.
+//Used to inject a fake clock for the record offsets.
+func NewRecorderWithClock(db uenibreader.DbBackend, now func() time.Time) *Recorder {
+	return newRecorder(db, now)
+}
+
+//SetSleep sets the function used to wait between the replayed events for unit tests.
+func (replayer *Replayer) SetSleep(sleep func(time.Duration)) {
+	replayer.sleep = sleep
+}
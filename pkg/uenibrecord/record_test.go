@@ -0,0 +1,160 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibrecord_test
+
+import (
+	"bytes"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibdump"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/nokia/ue-nib-library/pkg/uenibrecord"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testNs = "uenib/somegnb"
+const testChannel = "somegnb_DUAL_CONNECTIVITY"
+
+//fakeClock returns times one second apart.
+func fakeClock() func() time.Time {
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		t := now
+		now = now.Add(time.Second)
+		return t
+	}
+}
+
+//handledEvent is what the test xApp logic sees, when it handles an event.
+type handledEvent struct {
+	Event string
+	State string
+}
+
+//subscribeTestXApp subscribes events and reads UE's state for each event like an xApp would do.
+func subscribeTestXApp(t *testing.T, reader *uenibreader.Reader, handled *[]handledEvent) {
+	err := reader.SubscribeEvents([]string{"somegnb"}, []uenibreader.EventCategory{uenibreader.DualConnectivity},
+		func(gNb string, eventCategory uenibreader.EventCategory, events []string) {
+			for _, evt := range events {
+				state, err := reader.GetState(&uenib.UeID{GNb: gNb, ENbUeX2ApID: "1"})
+				if err != nil {
+					*handled = append(*handled, handledEvent{Event: evt, State: err.Error()})
+				} else {
+					*handled = append(*handled, handledEvent{Event: evt, State: state.Event})
+				}
+			}
+		})
+	assert.Nil(t, err)
+}
+
+func record(t *testing.T) (*uenibrecord.Recording, []handledEvent) {
+	var handled []handledEvent
+	db := uenibmem.NewDb()
+	recorder := uenibrecord.NewRecorderWithClock(db, fakeClock())
+	reader := uenibreader.NewReaderWithDbBackend(recorder)
+	subscribeTestXApp(t, reader, &handled)
+
+	db.SetAndPublish(testNs, []string{testChannel, "somegnb#2#1_ADD"},
+		"1,UE_STATE_EVENT", "SgNBAdditionRequest")
+	db.SetAndPublish(testNs, []string{testChannel, "somegnb#2#1_S1UL_TUNNEL_ESTABLISH"},
+		"1,UE_STATE_EVENT", "SgNBAdditionRequestAcknowledge", "1,UE_STATE_CAUSE", "radioNetwork:unspecified")
+	db.RemoveAll(testNs)
+	db.Publish(testNs, testChannel, "somegnb#2#1_REMOVE")
+	ueIDs, err := reader.GetUeIDs("somegnb")
+	assert.Nil(t, err)
+	assert.Empty(t, ueIDs)
+	return recorder.Recording(), handled
+}
+
+func TestRecorderRecordsEventsAndReads(t *testing.T) {
+	recording, _ := record(t)
+	assert.Equal(t, uenibrecord.FormatVersion, recording.Version)
+	assert.Equal(t, time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC), recording.Started)
+	assert.Equal(t, 7, len(recording.Records))
+	assert.Equal(t, uenibrecord.Record{
+		Offset: time.Second, Type: uenibrecord.RECORD_EVENT, Namespace: testNs,
+		Channel: testChannel, Events: []string{"somegnb#2#1_ADD"},
+	}, recording.Records[0])
+	assert.Equal(t, uenibrecord.Record{
+		Offset: 2 * time.Second, Type: uenibrecord.RECORD_GET, Namespace: testNs,
+		Keys:   []string{"1,UE_STATE_EVENT", "1,UE_STATE_CAUSE"},
+		Values: []uenibdump.Entry{{Key: "1,UE_STATE_EVENT", Value: "SgNBAdditionRequest"}},
+	}, recording.Records[1])
+}
+
+func TestReplayGivesSameResultsAsRecording(t *testing.T) {
+	recording, recorded := record(t)
+	var replayed []handledEvent
+	replayer := uenibrecord.NewReplayer(recording)
+	replayer.SetSleep(func(time.Duration) {})
+	subscribeTestXApp(t, uenibreader.NewReaderWithDbBackend(replayer), &replayed)
+	err := replayer.Replay(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(recorded))
+	assert.Equal(t, recorded, replayed)
+}
+
+func TestReplayWaitsAcceleratedEventIntervals(t *testing.T) {
+	recording, _ := record(t)
+	var sleeps []time.Duration
+	replayer := uenibrecord.NewReplayer(recording)
+	replayer.SetSleep(func(d time.Duration) { sleeps = append(sleeps, d) })
+	err := replayer.Replay(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, time.Second, time.Second}, sleeps)
+}
+
+func TestReplayWithoutWaiting(t *testing.T) {
+	recording, _ := record(t)
+	replayer := uenibrecord.NewReplayer(recording)
+	replayer.SetSleep(func(time.Duration) { t.Fatal("unexpected sleep") })
+	assert.Nil(t, replayer.Replay(0))
+	assert.NotNil(t, replayer.Replay(-1))
+}
+
+func TestReplayerGetAllReturnsRecordedKeys(t *testing.T) {
+	replayer := uenibrecord.NewReplayer(&uenibrecord.Recording{Records: []uenibrecord.Record{
+		{Type: uenibrecord.RECORD_GET, Namespace: testNs, Keys: []string{"b", "a"},
+			Values: []uenibdump.Entry{{Key: "b", Value: "1"}, {Key: "a", Value: "2"}}},
+	}})
+	replayer.Replay(0)
+	keys, _ := replayer.GetAll(testNs)
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	replayer = uenibrecord.NewReplayer(&uenibrecord.Recording{Records: []uenibrecord.Record{
+		{Type: uenibrecord.RECORD_GET_ALL, Namespace: testNs, Keys: []string{"c"}},
+	}})
+	replayer.Replay(0)
+	keys, _ = replayer.GetAll(testNs)
+	assert.Equal(t, []string{"c"}, keys)
+}
+
+func TestWriteAndReadRecordingRoundTrip(t *testing.T) {
+	recording, _ := record(t)
+	buf := &bytes.Buffer{}
+	err := recording.Write(buf)
+	assert.Nil(t, err)
+	assert.Contains(t, buf.String(), `"format": "uenib-recording"`)
+	assert.Contains(t, buf.String(), `"type": "getAll"`)
+	readRecording, err := uenibrecord.ReadRecording(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, recording, readRecording)
+}
+
+func TestReadRecordingReturnsErrorIfInvalidFile(t *testing.T) {
+	_, err := uenibrecord.ReadRecording(strings.NewReader(`{"format": "uenib-dump", "version": 1}`))
+	assert.EqualError(t, err, "not a UE-NIB recording file")
+	_, err = uenibrecord.ReadRecording(strings.NewReader(`{"format": "uenib-recording", "version": 2}`))
+	assert.EqualError(t, err, "unsupported UE-NIB recording file version 2")
+	_, err = uenibrecord.ReadRecording(strings.NewReader(
+		`{"format": "uenib-recording", "version": 1, "records": [{"type": "foo"}]}`))
+	assert.EqualError(t, err, "UE-NIB recording file decode failure: unknown record type 'foo'")
+}
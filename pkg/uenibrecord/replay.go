@@ -0,0 +1,161 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibrecord
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+//Replayer replays a recording as a database backend.
+//
+//Replay() delivers the recorded events to the subscribed callbacks in the recorded order. Before
+//an event is delivered, the database reads recorded after it, up to the next event, are applied
+//to the replayed database state. That way Get() returns the values the database had, when the
+//recorded xApp handled the event. Keys never read in the recording are not found.
+//NOTE: Use NewReplayer() function to create a Replayer instance.
+type Replayer struct {
+	recording   *Recording
+	sleep       func(time.Duration)
+	mutex       sync.Mutex
+	values      map[string]map[string]string
+	keys        map[string][]string
+	subscribers map[string][]subscriber
+}
+
+type subscriber struct {
+	cb       func(string, ...string)
+	channels map[string]bool
+}
+
+//NewReplayer creates a new Replayer of the recording.
+func NewReplayer(recording *Recording) *Replayer {
+	return &Replayer{
+		recording:   recording,
+		sleep:       time.Sleep,
+		values:      make(map[string]map[string]string),
+		keys:        make(map[string][]string),
+		subscribers: make(map[string][]subscriber),
+	}
+}
+
+//Replay replays the recording. The events are delivered from the calling goroutine and Replay()
+//returns after the last event has been delivered.
+//Parameter speed is the replay speed relative to the recording: 1 replays at the original speed,
+//10 ten times faster. Zero replays without waiting between the events.
+func (replayer *Replayer) Replay(speed float64) error {
+	if speed < 0 {
+		return fmt.Errorf("invalid replay speed %g", speed)
+	}
+	records := replayer.recording.Records
+	next := replayer.applyReads(records, 0)
+	var prevOffset time.Duration
+	for next < len(records) {
+		evt := records[next]
+		if speed > 0 && evt.Offset > prevOffset {
+			replayer.sleep(time.Duration(float64(evt.Offset-prevOffset) / speed))
+		}
+		prevOffset = evt.Offset
+		next = replayer.applyReads(records, next+1)
+		replayer.deliver(evt)
+	}
+	return nil
+}
+
+//Get returns the values of the keys in the replayed database state.
+func (replayer *Replayer) Get(ns string, keys []string) (map[string]interface{}, error) {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+	kvMap := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		if val, ok := replayer.values[ns][key]; ok {
+			kvMap[key] = val
+		} else {
+			kvMap[key] = nil
+		}
+	}
+	return kvMap, nil
+}
+
+//GetAll returns the keys of the namespace read last time in the recording. If there are no
+//recorded reads of the namespace keys, the keys having a value in the replayed state are returned.
+func (replayer *Replayer) GetAll(ns string) ([]string, error) {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+	if keys, ok := replayer.keys[ns]; ok {
+		return append([]string{}, keys...), nil
+	}
+	keys := make([]string, 0, len(replayer.values[ns]))
+	for key := range replayer.values[ns] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+//SubscribeChannel subscribes the callback to the given channels of the namespace.
+func (replayer *Replayer) SubscribeChannel(ns string, cb func(string, ...string), channels ...string) error {
+	s := subscriber{cb: cb, channels: make(map[string]bool, len(channels))}
+	for _, channel := range channels {
+		s.channels[channel] = true
+	}
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+	replayer.subscribers[ns] = append(replayer.subscribers[ns], s)
+	return nil
+}
+
+//Close removes all the subscriptions.
+func (replayer *Replayer) Close() error {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+	replayer.subscribers = make(map[string][]subscriber)
+	return nil
+}
+
+//applyReads applies the read records starting from the given index to the replayed state and
+//returns the index of the next event record.
+func (replayer *Replayer) applyReads(records []Record, start int) int {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+	i := start
+	for ; i < len(records) && records[i].Type != RECORD_EVENT; i++ {
+		record := records[i]
+		switch record.Type {
+		case RECORD_GET:
+			values := replayer.values[record.Namespace]
+			if values == nil {
+				values = make(map[string]string)
+				replayer.values[record.Namespace] = values
+			}
+			for _, key := range record.Keys {
+				delete(values, key)
+			}
+			for _, entry := range record.Values {
+				values[entry.Key] = entry.Value
+			}
+		case RECORD_GET_ALL:
+			replayer.keys[record.Namespace] = record.Keys
+		}
+	}
+	return i
+}
+
+//deliver calls the subscriber callbacks outside of the lock, so that a callback can read the state.
+func (replayer *Replayer) deliver(record Record) {
+	replayer.mutex.Lock()
+	subscribers := append([]subscriber(nil), replayer.subscribers[record.Namespace]...)
+	replayer.mutex.Unlock()
+	for _, s := range subscribers {
+		if s.channels[record.Channel] {
+			s.cb(record.Channel, record.Events...)
+		}
+	}
+}
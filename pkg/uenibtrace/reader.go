@@ -0,0 +1,283 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibtrace
+
+import (
+	"context"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"time"
+)
+
+//EventCallback defines the signature for the traced event callback function. Context ctx carries
+//the span of the event handling, see uenibreader.EventCallback for the other parameters.
+type EventCallback func(ctx context.Context, gNb string, eventCategory uenibreader.EventCategory, events []string)
+
+//ParsedEventCallback defines the signature for the traced callback function of parsed events, see
+//EventCallback and uenibreader.ParsedEventCallback for the parameters.
+type ParsedEventCallback func(ctx context.Context, gNb string, eventCategory uenibreader.EventCategory, events []uenibreader.Event)
+
+//Reader is a uenibreader.Reader, which traces its queries and event handling. The query methods
+//and the event subscriptions are traced, the other methods are the methods of the wrapped Reader.
+//NOTE: Use NewReader() function to create a Reader instance.
+type Reader struct {
+	*uenibreader.Reader
+	tracer Tracer
+	ctx    context.Context
+}
+
+//NewReader creates a new Reader, which reads UE-NIB with the given reader and creates the spans
+//with the given tracer. The retry policy, logger and the other options of the given reader apply
+//to the traced queries.
+func NewReader(reader *uenibreader.Reader, tracer Tracer) *Reader {
+	return &Reader{Reader: reader, tracer: tracer, ctx: context.Background()}
+}
+
+//WithContext returns a shallow copy of the Reader, which creates the query spans as children of
+//the span in the given context. The copy shares the wrapped Reader with the original Reader.
+func (reader *Reader) WithContext(ctx context.Context) *Reader {
+	r := *reader
+	r.ctx = ctx
+	return &r
+}
+
+//SubscribeEvents subscribes events like uenibreader.Reader.SubscribeEvents(). Each callback call
+//is traced with a span, which ends when the callback returns.
+func (reader *Reader) SubscribeEvents(gNbs []string, eventCategories []uenibreader.EventCategory, callback EventCallback) error {
+	return reader.Reader.SubscribeEvents(gNbs, eventCategories,
+		func(gNb string, eventCategory uenibreader.EventCategory, events []string) {
+			ctx, span := reader.startEventSpan(gNb, eventCategory, len(events))
+			defer span.End()
+			callback(ctx, gNb, eventCategory, events)
+		})
+}
+
+//SubscribeParsedEvents subscribes events like uenibreader.Reader.SubscribeParsedEvents(). Each
+//callback call is traced with a span, which ends when the callback returns.
+func (reader *Reader) SubscribeParsedEvents(gNbs []string, eventCategories []uenibreader.EventCategory, callback ParsedEventCallback) error {
+	return reader.Reader.SubscribeParsedEvents(gNbs, eventCategories,
+		func(gNb string, eventCategory uenibreader.EventCategory, events []uenibreader.Event) {
+			ctx, span := reader.startEventSpan(gNb, eventCategory, len(events))
+			defer span.End()
+			callback(ctx, gNb, eventCategory, events)
+		})
+}
+
+//startEventSpan starts the span of an event callback call and returns a context with the span
+//as the event span.
+func (reader *Reader) startEventSpan(gNb string, eventCategory uenibreader.EventCategory, eventCount int) (context.Context, Span) {
+	ctx, span := reader.tracer.StartSpan(reader.ctx, "uenib.event", nil,
+		Attribute{AttributeGNb, gNb},
+		Attribute{AttributeEventCategory, eventCategory.String()},
+		Attribute{AttributeEventCount, eventCount})
+	return ContextWithEventSpan(ctx, span), span
+}
+
+//countingDbBackend counts the keys read by a query. GetAll() is not counted, because it lists
+//the keys of a namespace without reading the values.
+type countingDbBackend struct {
+	uenibreader.DbBackend
+	keys int
+}
+
+func (db *countingDbBackend) Get(ns string, keys []string) (map[string]interface{}, error) {
+	db.keys += len(keys)
+	return db.DbBackend.Get(ns, keys)
+}
+
+type tracedQuery struct {
+	reader *uenibreader.Reader
+	db     *countingDbBackend
+	span   Span
+}
+
+func (reader *Reader) startQuery(method string, gNb string, ueID *uenib.UeID) *tracedQuery {
+	attributes := []Attribute{{AttributeGNb, gNb}}
+	if ueID != nil {
+		attributes = []Attribute{{AttributeGNb, ueID.GNb}, {AttributeUeID, ueID.String()}}
+	}
+	var links []Span
+	if eventSpan := EventSpanFromContext(reader.ctx); eventSpan != nil {
+		links = []Span{eventSpan}
+	}
+	_, span := reader.tracer.StartSpan(reader.ctx, "uenib."+method, links, attributes...)
+	q := &tracedQuery{span: span}
+	q.reader = reader.Reader.WithDbBackend(func(db uenibreader.DbBackend) uenibreader.DbBackend {
+		q.db = &countingDbBackend{DbBackend: db}
+		return q.db
+	})
+	return q
+}
+
+func (q *tracedQuery) end(err error) {
+	q.span.SetAttributes(Attribute{AttributeKeyCount, q.db.keys})
+	if err != nil {
+		q.span.RecordError(err)
+	}
+	q.span.End()
+}
+
+//GetMeNbUEX2APID calls uenibreader.Reader.GetMeNbUEX2APID() in a span.
+func (reader *Reader) GetMeNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
+	q := reader.startQuery("GetMeNbUEX2APID", "", ueID)
+	ret, err := q.reader.GetMeNbUEX2APID(ueID)
+	q.end(err)
+	return ret, err
+}
+
+//GetSgNbUEX2APID calls uenibreader.Reader.GetSgNbUEX2APID() in a span.
+func (reader *Reader) GetSgNbUEX2APID(ueID *uenib.UeID) (uint32, error) {
+	q := reader.startQuery("GetSgNbUEX2APID", "", ueID)
+	ret, err := q.reader.GetSgNbUEX2APID(ueID)
+	q.end(err)
+	return ret, err
+}
+
+//GetUeIDs calls uenibreader.Reader.GetUeIDs() in a span.
+func (reader *Reader) GetUeIDs(gNb string) ([]uenib.UeID, error) {
+	q := reader.startQuery("GetUeIDs", gNb, nil)
+	ret, err := q.reader.GetUeIDs(gNb)
+	q.end(err)
+	return ret, err
+}
+
+//GetUe calls uenibreader.Reader.GetUe() in a span.
+func (reader *Reader) GetUe(ueID *uenib.UeID) (*uenib.Ue, error) {
+	q := reader.startQuery("GetUe", "", ueID)
+	ret, err := q.reader.GetUe(ueID)
+	q.end(err)
+	return ret, err
+}
+
+//GetUeSnapshot calls uenibreader.Reader.GetUeSnapshot() in a span.
+func (reader *Reader) GetUeSnapshot(ueID *uenib.UeID) (*uenib.UeSnapshot, error) {
+	q := reader.startQuery("GetUeSnapshot", "", ueID)
+	ret, err := q.reader.GetUeSnapshot(ueID)
+	q.end(err)
+	return ret, err
+}
+
+//ListStaleUes calls uenibreader.Reader.ListStaleUes() in a span.
+func (reader *Reader) ListStaleUes(gNb string, olderThan time.Duration) ([]uenib.Ue, error) {
+	q := reader.startQuery("ListStaleUes", gNb, nil)
+	ret, err := q.reader.ListStaleUes(gNb, olderThan)
+	q.end(err)
+	return ret, err
+}
+
+//GetPsCell calls uenibreader.Reader.GetPsCell() in a span.
+func (reader *Reader) GetPsCell(ueID *uenib.UeID) (*uenib.Cell, error) {
+	q := reader.startQuery("GetPsCell", "", ueID)
+	ret, err := q.reader.GetPsCell(ueID)
+	q.end(err)
+	return ret, err
+}
+
+//GetServingCells calls uenibreader.Reader.GetServingCells() in a span.
+func (reader *Reader) GetServingCells(ueID *uenib.UeID) (*uenib.ServingCells, error) {
+	q := reader.startQuery("GetServingCells", "", ueID)
+	ret, err := q.reader.GetServingCells(ueID)
+	q.end(err)
+	return ret, err
+}
+
+//GetState calls uenibreader.Reader.GetState() in a span.
+func (reader *Reader) GetState(ueID *uenib.UeID) (*uenib.UeState, error) {
+	q := reader.startQuery("GetState", "", ueID)
+	ret, err := q.reader.GetState(ueID)
+	q.end(err)
+	return ret, err
+}
+
+//GetStateHistory calls uenibreader.Reader.GetStateHistory() in a span.
+func (reader *Reader) GetStateHistory(ueID *uenib.UeID, limit int) ([]uenib.UeState, error) {
+	q := reader.startQuery("GetStateHistory", "", ueID)
+	ret, err := q.reader.GetStateHistory(ueID, limit)
+	q.end(err)
+	return ret, err
+}
+
+//GetBearerIDs calls uenibreader.Reader.GetBearerIDs() in a span.
+func (reader *Reader) GetBearerIDs(ueID *uenib.UeID) ([]uenib.ErabID, error) {
+	q := reader.startQuery("GetBearerIDs", "", ueID)
+	ret, err := q.reader.GetBearerIDs(ueID)
+	q.end(err)
+	return ret, err
+}
+
+//GetBearers calls uenibreader.Reader.GetBearers() in a span.
+func (reader *Reader) GetBearers(ueID *uenib.UeID) ([]uenib.Bearer, error) {
+	q := reader.startQuery("GetBearers", "", ueID)
+	ret, err := q.reader.GetBearers(ueID)
+	q.end(err)
+	return ret, err
+}
+
+//GetErabS1ULGtpTE calls uenibreader.Reader.GetErabS1ULGtpTE() in a span.
+func (reader *Reader) GetErabS1ULGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	q := reader.startQuery("GetErabS1ULGtpTE", "", ueID)
+	ret, err := q.reader.GetErabS1ULGtpTE(ueID, erabID)
+	q.end(err)
+	return ret, err
+}
+
+//GetErabS1DLGtpTE calls uenibreader.Reader.GetErabS1DLGtpTE() in a span.
+func (reader *Reader) GetErabS1DLGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	q := reader.startQuery("GetErabS1DLGtpTE", "", ueID)
+	ret, err := q.reader.GetErabS1DLGtpTE(ueID, erabID)
+	q.end(err)
+	return ret, err
+}
+
+//GetErabX2UGtpTE calls uenibreader.Reader.GetErabX2UGtpTE() in a span.
+func (reader *Reader) GetErabX2UGtpTE(ueID *uenib.UeID, erabID uenib.ErabID) (*uenib.TunnelEndpoint, error) {
+	q := reader.startQuery("GetErabX2UGtpTE", "", ueID)
+	ret, err := q.reader.GetErabX2UGtpTE(ueID, erabID)
+	q.end(err)
+	return ret, err
+}
+
+//GetErabBearerType calls uenibreader.Reader.GetErabBearerType() in a span.
+func (reader *Reader) GetErabBearerType(ueID *uenib.UeID, erabID uenib.ErabID) (uenib.BearerType, error) {
+	q := reader.startQuery("GetErabBearerType", "", ueID)
+	ret, err := q.reader.GetErabBearerType(ueID, erabID)
+	q.end(err)
+	return ret, err
+}
+
+//GetErabS1ULGtpTEAddr calls uenibreader.Reader.GetErabS1ULGtpTEAddr() in a span.
+func (reader *Reader) GetErabS1ULGtpTEAddr(ueID *uenib.UeID, erabID uenib.ErabID) ([]byte, error) {
+	q := reader.startQuery("GetErabS1ULGtpTEAddr", "", ueID)
+	ret, err := q.reader.GetErabS1ULGtpTEAddr(ueID, erabID)
+	q.end(err)
+	return ret, err
+}
+
+//GetErabS1ULGtpTETeid calls uenibreader.Reader.GetErabS1ULGtpTETeid() in a span.
+func (reader *Reader) GetErabS1ULGtpTETeid(ueID *uenib.UeID, erabID uenib.ErabID) ([]byte, error) {
+	q := reader.startQuery("GetErabS1ULGtpTETeid", "", ueID)
+	ret, err := q.reader.GetErabS1ULGtpTETeid(ueID, erabID)
+	q.end(err)
+	return ret, err
+}
+
+//GetErabQosArpPL calls uenibreader.Reader.GetErabQosArpPL() in a span.
+func (reader *Reader) GetErabQosArpPL(ueID *uenib.UeID, erabID uenib.ErabID) (uint32, error) {
+	q := reader.startQuery("GetErabQosArpPL", "", ueID)
+	ret, err := q.reader.GetErabQosArpPL(ueID, erabID)
+	q.end(err)
+	return ret, err
+}
+
+//GetErabQosQci calls uenibreader.Reader.GetErabQosQci() in a span.
+func (reader *Reader) GetErabQosQci(ueID *uenib.UeID, erabID uenib.ErabID) (uint32, error) {
+	q := reader.startQuery("GetErabQosQci", "", ueID)
+	ret, err := q.reader.GetErabQosQci(ueID, erabID)
+	q.end(err)
+	return ret, err
+}
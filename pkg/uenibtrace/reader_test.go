@@ -0,0 +1,169 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+package uenibtrace_test
+
+import (
+	"context"
+	"github.com/nokia/ue-nib-library/pkg/uenib"
+	"github.com/nokia/ue-nib-library/pkg/uenibmem"
+	"github.com/nokia/ue-nib-library/pkg/uenibreader"
+	"github.com/nokia/ue-nib-library/pkg/uenibtrace"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type parentKey struct{}
+
+type fakeSpan struct {
+	name       string
+	parent     *fakeSpan
+	links      []uenibtrace.Span
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (span *fakeSpan) SetAttributes(attributes ...uenibtrace.Attribute) {
+	for _, a := range attributes {
+		span.attributes[a.Key] = a.Value
+	}
+}
+
+func (span *fakeSpan) RecordError(err error) {
+	span.err = err
+}
+
+func (span *fakeSpan) End() {
+	span.ended = true
+}
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (tracer *fakeTracer) StartSpan(ctx context.Context, name string, links []uenibtrace.Span,
+	attributes ...uenibtrace.Attribute) (context.Context, uenibtrace.Span) {
+	parent, _ := ctx.Value(parentKey{}).(*fakeSpan)
+	span := &fakeSpan{name: name, parent: parent, links: links, attributes: make(map[string]interface{})}
+	span.SetAttributes(attributes...)
+	tracer.spans = append(tracer.spans, span)
+	return context.WithValue(ctx, parentKey{}, span), span
+}
+
+func setup() (*uenibmem.Db, *fakeTracer, *uenibtrace.Reader) {
+	db := uenibmem.NewDb()
+	db.Set("uenib/somegnb", "1,UEMAP_GNBUEX2APID", "2", "2,UEMAP_ENBUEX2APID", "1",
+		"1,UE_PSCELL_PCI", "10", "1,UE_PSCELL_FREQ", "632628")
+	tracer := &fakeTracer{}
+	return db, tracer, uenibtrace.NewReader(uenibreader.NewReaderWithDbBackend(db), tracer)
+}
+
+func TestQueryCreatesSpan(t *testing.T) {
+	_, tracer, reader := setup()
+	ueID := &uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"}
+	cell, err := reader.GetPsCell(ueID)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(10), cell.Pci)
+
+	assert.Equal(t, 1, len(tracer.spans))
+	span := tracer.spans[0]
+	assert.Equal(t, "uenib.GetPsCell", span.name)
+	assert.Nil(t, span.parent)
+	assert.Empty(t, span.links)
+	assert.Equal(t, map[string]interface{}{
+		uenibtrace.AttributeGNb:      "somegnb",
+		uenibtrace.AttributeUeID:     ueID.String(),
+		uenibtrace.AttributeKeyCount: 2,
+	}, span.attributes)
+	assert.Nil(t, span.err)
+	assert.True(t, span.ended)
+}
+
+func TestQueryRecordsError(t *testing.T) {
+	_, tracer, reader := setup()
+	_, stateErr := reader.GetState(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.True(t, uenibreader.IsValueNotFoundFailure(stateErr))
+	ueIDs, err := reader.GetUeIDs("somegnb")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ueIDs))
+
+	assert.Equal(t, 2, len(tracer.spans))
+	assert.Equal(t, stateErr, tracer.spans[0].err)
+	assert.Equal(t, "uenib.GetUeIDs", tracer.spans[1].name)
+	assert.Equal(t, "somegnb", tracer.spans[1].attributes[uenibtrace.AttributeGNb])
+	assert.Equal(t, 1, tracer.spans[1].attributes[uenibtrace.AttributeKeyCount])
+}
+
+func TestEventHandlingCreatesSpanLinkedToQuerySpans(t *testing.T) {
+	db, tracer, reader := setup()
+	var eventCtx context.Context
+	err := reader.SubscribeEvents([]string{"somegnb"}, []uenibreader.EventCategory{uenibreader.DualConnectivity},
+		func(ctx context.Context, gNb string, eventCategory uenibreader.EventCategory, events []string) {
+			eventCtx = ctx
+			reader.WithContext(ctx).GetPsCell(&uenib.UeID{GNb: gNb, GNbUeX2ApID: "2"})
+		})
+	assert.Nil(t, err)
+	db.Publish("uenib/somegnb", "somegnb_DUAL_CONNECTIVITY", "somegnb#2#1_ADD")
+
+	assert.Equal(t, 2, len(tracer.spans))
+	eventSpan, querySpan := tracer.spans[0], tracer.spans[1]
+	assert.Equal(t, "uenib.event", eventSpan.name)
+	assert.Equal(t, map[string]interface{}{
+		uenibtrace.AttributeGNb:           "somegnb",
+		uenibtrace.AttributeEventCategory: "DUAL_CONNECTIVITY",
+		uenibtrace.AttributeEventCount:    1,
+	}, eventSpan.attributes)
+	assert.True(t, eventSpan.ended)
+	assert.Equal(t, eventSpan, uenibtrace.EventSpanFromContext(eventCtx))
+
+	assert.Equal(t, "uenib.GetPsCell", querySpan.name)
+	assert.Equal(t, eventSpan, querySpan.parent)
+	assert.Equal(t, []uenibtrace.Span{eventSpan}, querySpan.links)
+	assert.Nil(t, querySpan.err)
+}
+
+func TestParsedEventHandlingCreatesSpan(t *testing.T) {
+	db, tracer, reader := setup()
+	var received []uenibreader.Event
+	var eventCtx context.Context
+	err := reader.SubscribeParsedEvents([]string{"somegnb"}, []uenibreader.EventCategory{uenibreader.DualConnectivity},
+		func(ctx context.Context, gNb string, eventCategory uenibreader.EventCategory, events []uenibreader.Event) {
+			eventCtx = ctx
+			received = append(received, events...)
+		})
+	assert.Nil(t, err)
+	db.Publish("uenib/somegnb", "somegnb_DUAL_CONNECTIVITY", "somegnb#2#1_ADD")
+
+	assert.Equal(t, 1, len(received))
+	assert.Nil(t, received[0].Err)
+	assert.Equal(t, 1, len(tracer.spans))
+	assert.Equal(t, "uenib.event", tracer.spans[0].name)
+	assert.True(t, tracer.spans[0].ended)
+	assert.Equal(t, tracer.spans[0], uenibtrace.EventSpanFromContext(eventCtx))
+}
+
+func TestTracedReaderUsesOptionsAndStateOfWrappedReader(t *testing.T) {
+	db, tracer, _ := setup()
+	db.Set("uenib/somegnb", "1,UE_LAST_UPDATED", "1000")
+	wrapped := uenibreader.NewReaderWithDbBackend(db)
+	wrapped.SetStaleThreshold(time.Hour)
+	reader := uenibtrace.NewReader(wrapped, tracer)
+
+	_, err := reader.GetPsCell(&uenib.UeID{GNb: "somegnb", ENbUeX2ApID: "1"})
+	assert.True(t, uenibreader.IsValueNotFoundFailure(err))
+	assert.Equal(t, 1, tracer.spans[0].attributes[uenibtrace.AttributeKeyCount])
+
+	err = reader.SubscribeEvents([]string{"somegnb"}, []uenibreader.EventCategory{uenibreader.DualConnectivity},
+		func(ctx context.Context, gNb string, eventCategory uenibreader.EventCategory, events []string) {})
+	assert.Nil(t, err)
+	status := wrapped.Health(context.Background())
+	assert.Equal(t, 1, len(status.Subscriptions))
+	assert.Nil(t, reader.Close())
+	assert.False(t, wrapped.Health(context.Background()).Healthy)
+}
@@ -0,0 +1,74 @@
+/*
+   Copyright (c) 2020 Nokia.
+
+   Licensed under the BSD 3-Clause Clear License.
+   SPDX-License-Identifier: BSD-3-Clause-Clear
+*/
+
+//Package uenibtrace implements tracing of UE-NIB Reader queries and event handling.
+//
+//The library does not depend on any tracing SDK. Tracing is plugged in by implementing the
+//Tracer and Span interfaces, for example as a thin adapter over an OpenTelemetry tracer.
+//
+//Reader creates a span for each query with the gNB, UeID and the number of read database keys
+//as attributes. Each subscribed event callback call gets a span of its own, which is passed to
+//the callback in a context. When the events are handled with a Reader bound to that context,
+//the query spans are children of the event span and they link to it:
+//
+//	reader := uenibtrace.NewReader(uenibreader.NewReader(), tracer)
+//	reader.SubscribeEvents(gNbs, categories, func(ctx context.Context, gNb string,
+//		eventCategory uenibreader.EventCategory, events []string) {
+//		cell, err := reader.WithContext(ctx).GetPsCell(ueID)
+//		...
+//	})
+package uenibtrace
+
+import (
+	"context"
+)
+
+//Attribute keys of the spans created by Reader.
+const (
+	AttributeGNb           = "uenib.gnb"
+	AttributeUeID          = "uenib.ue_id"
+	AttributeKeyCount      = "uenib.key_count"
+	AttributeEventCategory = "uenib.event_category"
+	AttributeEventCount    = "uenib.event_count"
+)
+
+//Attribute is a span attribute. Value is a string or an int.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+//Tracer creates spans.
+type Tracer interface {
+	//StartSpan starts a new span, which is a child of the span in the given context, if any.
+	//The new span links to the given linked spans. The returned context contains the new span.
+	StartSpan(ctx context.Context, name string, links []Span, attributes ...Attribute) (context.Context, Span)
+}
+
+//Span is an operation traced by Tracer.
+type Span interface {
+	//SetAttributes sets attributes to the span.
+	SetAttributes(attributes ...Attribute)
+	//RecordError records an error of the operation.
+	RecordError(err error)
+	//End ends the span.
+	End()
+}
+
+type eventSpanKey struct{}
+
+//ContextWithEventSpan returns a copy of the context carrying the span of an event handling.
+//Query spans created with the returned context link to the event span.
+func ContextWithEventSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, eventSpanKey{}, span)
+}
+
+//EventSpanFromContext returns the span of an event handling carried by the context, or nil.
+func EventSpanFromContext(ctx context.Context) Span {
+	span, _ := ctx.Value(eventSpanKey{}).(Span)
+	return span
+}